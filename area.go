@@ -0,0 +1,202 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"math"
+	"sync"
+)
+
+// getAreaBounds returns the source interval covered by output pixel i,
+// in field pixels when interlaced
+func getAreaBounds(i int, scale float64, field, idx uint) (float64, float64) {
+	if field == 0 {
+		return float64(i) * scale, float64(i+1) * scale
+	}
+	// field pixel r covers full resolution pixels [2r+idx-0.5, 2r+idx+1.5)
+	x := float64(i<<field) + float64(idx) - 0.5
+	lo := (x*scale - float64(idx) + 0.5) / 2
+	hi := ((x+2)*scale - float64(idx) + 0.5) / 2
+	return lo, hi
+}
+
+// makeAreaKernel returns exact area coverage weights, with the same
+// outputs than makeDoubleKernel
+func makeAreaKernel(cfg *ResizerConfig, field, idx uint) ([]int16, []float64, []float64, int, int) {
+	scale := float64(cfg.Input) / float64(cfg.Output)
+	size := (cfg.Output + int(field*(1-idx))) >> field
+	fsize := (cfg.Input + int(field*(1-idx))) >> field
+	taps := 0
+	for i := 0; i < size; i++ {
+		lo, hi := getAreaBounds(i, scale, field, idx)
+		taps = max(taps, int(math.Ceil(hi))-int(math.Floor(lo)))
+	}
+	taps += taps & 1
+	taps = min(taps, fsize&^1)
+	offsets := make([]int16, cfg.Output)
+	sums := make([]float64, cfg.Output)
+	weights := make([]float64, cfg.Output*taps)
+	for i := 0; i < size; i++ {
+		lo, hi := getAreaBounds(i, scale, field, idx)
+		first := int(math.Floor(lo))
+		x := clip(first, 0, max(0, fsize-taps))
+		// makeIntegerKernel converts full resolution positions to fields
+		offsets[i] = int16(x<<field + int(idx) - int(field))
+		for src := first; float64(src) < hi; src++ {
+			weight := math.Min(hi, float64(src+1)) - math.Max(lo, float64(src))
+			j := clip(src, x, min(fsize, x+taps)-1) - x
+			weights[i*taps+j] += weight
+			sums[i] += weight
+		}
+	}
+	return offsets, sums, weights, taps, size
+}
+
+// getAreaFactor returns the integer reduction factor handled by
+// dedicated area scalers, or zero
+func getAreaFactor(cfg *ResizerConfig) int {
+	if cfg.Vertical && cfg.Interlaced || cfg.Output < 1 {
+		return 0
+	}
+	factor := cfg.Input / cfg.Output
+	if factor*cfg.Output != cfg.Input || factor < 2 || factor > 4 {
+		return 0
+	}
+	return factor
+}
+
+// areaScaler averages factor consecutive pixels for every output pixel
+type areaScaler func(dst, src []byte, width, height, dp, sp, pack int)
+
+// areaResizer reduces planes by an integer factor, every output pixel is
+// the rounded average of its source pixels
+type areaResizer struct {
+	cfg    ResizerConfig
+	factor int
+	scaler areaScaler
+}
+
+func newAreaResizer(cfg *ResizerConfig, factor int) Resizer {
+	r := &areaResizer{
+		cfg:    *cfg,
+		factor: factor,
+	}
+	scalers := [][]areaScaler{
+		{h8area2Go, h8area3Go, h8area4Go},
+		{v8area2Go, v8area3Go, v8area4Go},
+	}
+	r.scaler = scalers[bin(cfg.Vertical)][factor-2]
+	return r
+}
+
+func (r *areaResizer) Scalers(width int) []ScalerInfo {
+	return nil
+}
+
+func (r *areaResizer) Resize(dst, src []byte, width, height, dp, sp int) {
+	pk := r.cfg.Pack
+	dwidth := r.cfg.Output
+	dheight := height
+	step, xstep := 1, r.factor
+	if r.cfg.Vertical {
+		dwidth = width
+		dheight = r.cfg.Output
+		step, xstep = r.factor, 1
+	}
+	group := sync.WaitGroup{}
+	splitTiles(&group, r.cfg.Threads, dwidth, dheight, columnBlock, func(x, y, w, h int) {
+		r.scaler(dst[dp*y+x*pk:], src[sp*y*step+x*xstep*pk:], w, h, dp, sp, pk)
+	})
+	group.Wait()
+}
+
+// area reciprocals, (sum + factor/2) * recip >> 16 is exact for every
+// possible 8-bit sum, see TestAreaReciprocals
+const (
+	area2Recip = 1 << 15
+	area3Recip = 21846
+	area4Recip = 1 << 14
+)
+
+func h8area2Go(dst, src []byte, width, height, dp, sp, pack int) {
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width*pack]
+		s := src[sp*y:]
+		for x, i := 0, 0; x < len(d); x, i = x+pack, i+2*pack {
+			for c := i; c < i+pack; c++ {
+				sum := int(s[c]) + int(s[c+pack])
+				d[x+c-i] = byte((sum + 1) * area2Recip >> 16)
+			}
+		}
+	}
+}
+
+func h8area3Go(dst, src []byte, width, height, dp, sp, pack int) {
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width*pack]
+		s := src[sp*y:]
+		for x, i := 0, 0; x < len(d); x, i = x+pack, i+3*pack {
+			for c := i; c < i+pack; c++ {
+				sum := int(s[c]) + int(s[c+pack]) + int(s[c+2*pack])
+				d[x+c-i] = byte((sum + 1) * area3Recip >> 16)
+			}
+		}
+	}
+}
+
+func h8area4Go(dst, src []byte, width, height, dp, sp, pack int) {
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width*pack]
+		s := src[sp*y:]
+		for x, i := 0, 0; x < len(d); x, i = x+pack, i+4*pack {
+			for c := i; c < i+pack; c++ {
+				sum := int(s[c]) + int(s[c+pack]) + int(s[c+2*pack]) + int(s[c+3*pack])
+				d[x+c-i] = byte((sum + 2) * area4Recip >> 16)
+			}
+		}
+	}
+}
+
+func v8area2Go(dst, src []byte, width, height, dp, sp, pack int) {
+	width *= pack
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width]
+		s0 := src[sp*y*2:]
+		s1 := s0[sp:]
+		for x := range d {
+			sum := int(s0[x]) + int(s1[x])
+			d[x] = byte((sum + 1) * area2Recip >> 16)
+		}
+	}
+}
+
+func v8area3Go(dst, src []byte, width, height, dp, sp, pack int) {
+	width *= pack
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width]
+		s0 := src[sp*y*3:]
+		s1 := s0[sp:]
+		s2 := s1[sp:]
+		for x := range d {
+			sum := int(s0[x]) + int(s1[x]) + int(s2[x])
+			d[x] = byte((sum + 1) * area3Recip >> 16)
+		}
+	}
+}
+
+func v8area4Go(dst, src []byte, width, height, dp, sp, pack int) {
+	width *= pack
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width]
+		s0 := src[sp*y*4:]
+		s1 := s0[sp:]
+		s2 := s1[sp:]
+		s3 := s2[sp:]
+		for x := range d {
+			sum := int(s0[x]) + int(s1[x]) + int(s2[x]) + int(s3[x])
+			d[x] = byte((sum + 2) * area4Recip >> 16)
+		}
+	}
+}
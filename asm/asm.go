@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 )
 
@@ -16,10 +17,41 @@ type Asm struct {
 	data   int
 	label  int
 	errors []string
+	funcs  []*Function
 	// per function
 	name  string
 	args  int
 	stack int
+	fn    *Function
+}
+
+// Instruction is one recorded instruction, with operands in call order,
+// destination first
+type Instruction struct {
+	Op   string
+	Args []Operand
+}
+
+func (i Instruction) String() string {
+	args := []string{}
+	for j := len(i.Args) - 1; j >= 0; j-- {
+		args = append(args, i.Args[j].String())
+	}
+	return strings.TrimSpace(i.Op + " " + strings.Join(args, ", "))
+}
+
+// Function is the recorded instruction stream of one generated function
+type Function struct {
+	Name   string
+	Args   int // arguments size in bytes
+	Stack  int // local variables size in bytes
+	Code   []Instruction
+	labels map[label]int
+}
+
+// Functions returns every function generated so far
+func (a *Asm) Functions() []*Function {
+	return a.funcs
 }
 
 func NewAsm(w io.Writer) *Asm {
@@ -32,6 +64,14 @@ func (a *Asm) NewFunction(name string) {
 	a.name = name
 	a.args = 0
 	a.stack = 0
+	a.fn = &Function{Name: name, labels: map[label]int{}}
+	a.funcs = append(a.funcs, a.fn)
+}
+
+func (a *Asm) record(instruction string, args ...Operand) {
+	if a.fn != nil {
+		a.fn.Code = append(a.fn.Code, Instruction{instruction, args})
+	}
 }
 
 func (a *Asm) Data(name string, data []byte) Operand {
@@ -47,7 +87,13 @@ func (a *Asm) Data(name string, data []byte) Operand {
 	// it only works with go > 1.4 though
 	const RODATA = 8
 	a.write(fmt.Sprintf("GLOBL\t%v(SB), %v, $%v", name, RODATA, len(data)))
-	return literal(fmt.Sprintf("%v(SB)", name))
+	return &symbol{literal(fmt.Sprintf("%v(SB)", name)), data}
+}
+
+// symbol is global data, where every 8 bytes are one big-endian quad
+type symbol struct {
+	literal
+	data []byte
 }
 
 type Argument struct {
@@ -93,6 +139,10 @@ func (a *Asm) PushStack(name string) Operand {
 }
 
 func (a *Asm) Start() {
+	if a.fn != nil {
+		a.fn.Args = a.args
+		a.fn.Stack = a.stack
+	}
 	a.write(fmt.Sprintf("\nTEXT ·%v(SB),4,$%v-%v", a.name, a.stack, a.args))
 }
 
@@ -131,8 +181,14 @@ func (lit literal) String() string {
 	return string(lit)
 }
 
+type immediate struct {
+	literal
+	value int64
+}
+
 func Constant(value interface{}) Operand {
-	return literal(fmt.Sprintf("$%v", value))
+	v, _ := strconv.ParseInt(fmt.Sprint(value), 0, 64)
+	return immediate{literal(fmt.Sprintf("$%v", value)), v}
 }
 
 type Register struct{ literal }
@@ -147,15 +203,24 @@ const (
 	SX8
 )
 
+// memory is base + index * scale + displacement, base is optional
+type memory struct {
+	literal
+	base  Register
+	index Register
+	scale Scale
+	disp  int
+}
+
 func address(base Register) Operand {
-	return literal(fmt.Sprintf("(%v)", base.String()))
+	return memory{literal: literal(fmt.Sprintf("(%v)", base.String())), base: base}
 }
 
 func displaceaddress(base Register, index int) Operand {
 	if index == 0 {
 		return address(base)
 	}
-	return literal(fmt.Sprintf("%v(%v)", index, base.String()))
+	return memory{literal: literal(fmt.Sprintf("%v(%v)", index, base.String())), base: base, disp: index}
 }
 
 func scaledindex(index Register, scale Scale) string {
@@ -166,7 +231,7 @@ func scaledindex(index Register, scale Scale) string {
 }
 
 func indexaddress(base Register, index Register, scale Scale) Operand {
-	return literal(fmt.Sprintf("(%v)%v", base.String(), scaledindex(index, scale)))
+	return memory{literal(fmt.Sprintf("(%v)%v", base.String(), scaledindex(index, scale))), base, index, scale, 0}
 }
 
 func fulladdress(base Register, index Register, scale Scale, displacement int) Operand {
@@ -174,7 +239,7 @@ func fulladdress(base Register, index Register, scale Scale, displacement int) O
 	if displacement != 0 {
 		d = fmt.Sprintf("%v", displacement)
 	}
-	return literal(fmt.Sprintf("%v(%v)%v", d, base.String(), scaledindex(index, scale)))
+	return memory{literal(fmt.Sprintf("%v(%v)%v", d, base.String(), scaledindex(index, scale))), base, index, scale, displacement}
 }
 
 func Address(base Register, offsets ...interface{}) Operand {
@@ -191,7 +256,7 @@ func Address(base Register, offsets ...interface{}) Operand {
 		case Register:
 			return indexaddress(base, t, SX1)
 		case Scale:
-			return literal(scaledindex(base, t))
+			return memory{literal: literal(scaledindex(base, t)), index: base, scale: t}
 		}
 	case 2:
 		index, ok := offsets[0].(Register)
@@ -268,8 +333,34 @@ var (
 	X13 = SimdRegister{literal: "X13"}
 	X14 = SimdRegister{literal: "X14"}
 	X15 = SimdRegister{literal: "X15"}
+	Y0  = SimdRegister{literal: "Y0"}
+	Y1  = SimdRegister{literal: "Y1"}
+	Y2  = SimdRegister{literal: "Y2"}
+	Y3  = SimdRegister{literal: "Y3"}
+	Y4  = SimdRegister{literal: "Y4"}
+	Y5  = SimdRegister{literal: "Y5"}
+	Y6  = SimdRegister{literal: "Y6"}
+	Y7  = SimdRegister{literal: "Y7"}
+	Y8  = SimdRegister{literal: "Y8"}
+	Y9  = SimdRegister{literal: "Y9"}
+	Y10 = SimdRegister{literal: "Y10"}
+	Y11 = SimdRegister{literal: "Y11"}
+	Y12 = SimdRegister{literal: "Y12"}
+	Y13 = SimdRegister{literal: "Y13"}
+	Y14 = SimdRegister{literal: "Y14"}
+	Y15 = SimdRegister{literal: "Y15"}
 )
 
+// Y returns the 256-bits register whose low half is r
+func (r SimdRegister) Y() SimdRegister {
+	return SimdRegister{literal: "Y" + r.literal[1:]}
+}
+
+// X returns the 128-bits low half of r
+func (r SimdRegister) X() SimdRegister {
+	return SimdRegister{literal: "X" + r.literal[1:]}
+}
+
 type label string
 
 func (a *Asm) NewLabel(name string) label {
@@ -283,30 +374,44 @@ func (l label) String() string {
 }
 
 func (a *Asm) op0(instruction string) {
+	a.record(instruction)
 	a.write("\t\t" + instruction)
 }
 
 func (a *Asm) op1(instruction string, opa Operand) {
+	a.record(instruction, opa)
 	a.write("\t\t" + instruction + "\t" + opa.String())
 }
 
 func (a *Asm) op2(instruction string, opa, opb Operand) {
+	a.record(instruction, opa, opb)
 	a.write("\t\t" + instruction + "\t" + opb.String() + ", " + opa.String())
 }
 
 func (a *Asm) op3(instruction string, opa, opb, opc Operand) {
+	a.record(instruction, opa, opb, opc)
 	a.write(fmt.Sprintf("\t\t%v\t%v, %v, %v", instruction, opc.String(), opb.String(), opa.String()))
 }
 
+func (a *Asm) op4(instruction string, opa, opb, opc, opd Operand) {
+	a.record(instruction, opa, opb, opc, opd)
+	a.write(fmt.Sprintf("\t\t%v\t%v, %v, %v, %v", instruction, opd.String(), opc.String(), opb.String(), opa.String()))
+}
+
 func (a *Asm) Label(name label) {
+	if a.fn != nil {
+		a.fn.labels[name] = len(a.fn.Code)
+	}
 	a.write(name.String() + ":")
 }
 
-func (a *Asm) Ret() { a.op0("RET") }
+func (a *Asm) Ret()        { a.op0("RET") }
+func (a *Asm) Vzeroupper() { a.op0("VZEROUPPER") }
 
 func (a *Asm) Imulq(op Operand) { a.op1("IMULQ", op) }
 func (a *Asm) Incq(op Operand)  { a.op1("INCQ", op) }
 func (a *Asm) Je(name label)    { a.op1("JE", name) }
+func (a *Asm) Jlt(name label)   { a.op1("JLT", name) }
 func (a *Asm) Jmp(name label)   { a.op1("JMP", name) }
 func (a *Asm) Jne(name label)   { a.op1("JNE", name) }
 func (a *Asm) Mulq(op Operand)  { a.op1("MULQ", op) }
@@ -323,22 +428,65 @@ func (a *Asm) Movd(opa, opb Operand)       { a.op2("MOVL", opa, opb) }
 func (a *Asm) Movo(opa, opb Operand)       { a.op2("MOVO", opa, opb) }
 func (a *Asm) Movou(opa, opb Operand)      { a.op2("MOVOU", opa, opb) }
 func (a *Asm) Movq(opa, opb Operand)       { a.op2("MOVQ", opa, opb) }
+func (a *Asm) Movw(opa, opb Operand)       { a.op2("MOVW", opa, opb) }
 func (a *Asm) Movwqsx(opa, opb Operand)    { a.op2("MOVWQSX", opa, opb) }
+func (a *Asm) Movwqzx(opa, opb Operand)    { a.op2("MOVWQZX", opa, opb) }
 func (a *Asm) Orq(opa, opb Operand)        { a.op2("ORQ", opa, opb) }
 func (a *Asm) Packssdw(opa, opb Operand)   { a.op2("PACKSSLW", opa, opb) }
 func (a *Asm) Packuswb(opa, opb Operand)   { a.op2("PACKUSWB", opa, opb) }
+func (a *Asm) Paddb(opa, opb Operand)      { a.op2("PADDB", opa, opb) }
 func (a *Asm) Paddd(opa, opb Operand)      { a.op2("PADDL", opa, opb) }
+func (a *Asm) Paddw(opa, opb Operand)      { a.op2("PADDW", opa, opb) }
+func (a *Asm) Pand(opa, opb Operand)       { a.op2("PAND", opa, opb) }
 func (a *Asm) Pmaddwd(opa, opb Operand)    { a.op2("PMADDWL", opa, opb) }
+func (a *Asm) Pmaxub(opa, opb Operand)     { a.op2("PMAXUB", opa, opb) }
+func (a *Asm) Pminub(opa, opb Operand)     { a.op2("PMINUB", opa, opb) }
+func (a *Asm) Pmullw(opa, opb Operand)     { a.op2("PMULLW", opa, opb) }
 func (a *Asm) Psrad(opa, opb Operand)      { a.op2("PSRAL", opa, opb) }
+func (a *Asm) Psrlw(opa, opb Operand)      { a.op2("PSRLW", opa, opb) }
+func (a *Asm) Psubb(opa, opb Operand)      { a.op2("PSUBB", opa, opb) }
+func (a *Asm) Psubusb(opa, opb Operand)    { a.op2("PSUBUSB", opa, opb) }
 func (a *Asm) Punpckhbw(opa, opb Operand)  { a.op2("PUNPCKHBW", opa, opb) }
 func (a *Asm) Punpckhqdq(opa, opb Operand) { a.op2("PUNPCKHQDQ", opa, opb) }
 func (a *Asm) Punpcklbw(opa, opb Operand)  { a.op2("PUNPCKLBW", opa, opb) }
 func (a *Asm) Punpckldq(opa, opb Operand)  { a.op2("PUNPCKLLQ", opa, opb) }
 func (a *Asm) Punpcklqdq(opa, opb Operand) { a.op2("PUNPCKLQDQ", opa, opb) }
+func (a *Asm) Punpckhwd(opa, opb Operand)  { a.op2("PUNPCKHWL", opa, opb) }
+func (a *Asm) Punpcklwd(opa, opb Operand)  { a.op2("PUNPCKLWL", opa, opb) }
+func (a *Asm) Pshufb(opa, opb Operand)     { a.op2("PSHUFB", opa, opb) }
 func (a *Asm) Pxor(opa, opb Operand)       { a.op2("PXOR", opa, opb) }
 func (a *Asm) Shlq(opa, opb Operand)       { a.op2("SHLQ", opa, opb) }
 func (a *Asm) Shrq(opa, opb Operand)       { a.op2("SHRQ", opa, opb) }
 func (a *Asm) Subq(opa, opb Operand)       { a.op2("SUBQ", opa, opb) }
 
+func (a *Asm) Imul3q(opa, opb, opc Operand) { a.op3("IMUL3Q", opa, opb, opc) }
 func (a *Asm) Pinsrw(opa, opb, opc Operand) { a.op3("PINSRW", opa, opb, opc) }
 func (a *Asm) Shufps(opa, opb, opc Operand) { a.op3("SHUFPS", opa, opb, opc) }
+
+// vex-encoded instructions, destination first
+func (a *Asm) Vbroadcasti128(opa, opb Operand) { a.op2("VBROADCASTI128", opa, opb) }
+func (a *Asm) Vmovd(opa, opb Operand)          { a.op2("VMOVD", opa, opb) }
+func (a *Asm) Vmovdqa(opa, opb Operand)        { a.op2("VMOVDQA", opa, opb) }
+func (a *Asm) Vmovdqu(opa, opb Operand)        { a.op2("VMOVDQU", opa, opb) }
+func (a *Asm) Vmovq(opa, opb Operand)          { a.op2("VMOVQ", opa, opb) }
+
+func (a *Asm) Vextracti128(opa, opb, opc Operand) { a.op3("VEXTRACTI128", opa, opb, opc) }
+func (a *Asm) Vpackssdw(opa, opb, opc Operand)    { a.op3("VPACKSSDW", opa, opb, opc) }
+func (a *Asm) Vpackuswb(opa, opb, opc Operand)    { a.op3("VPACKUSWB", opa, opb, opc) }
+func (a *Asm) Vpaddd(opa, opb, opc Operand)       { a.op3("VPADDD", opa, opb, opc) }
+func (a *Asm) Vpaddw(opa, opb, opc Operand)       { a.op3("VPADDW", opa, opb, opc) }
+func (a *Asm) Vpmaddwd(opa, opb, opc Operand)     { a.op3("VPMADDWD", opa, opb, opc) }
+func (a *Asm) Vpshufb(opa, opb, opc Operand)      { a.op3("VPSHUFB", opa, opb, opc) }
+func (a *Asm) Vpsrad(opa, opb, opc Operand)       { a.op3("VPSRAD", opa, opb, opc) }
+func (a *Asm) Vpunpckhbw(opa, opb, opc Operand)   { a.op3("VPUNPCKHBW", opa, opb, opc) }
+func (a *Asm) Vpunpckhqdq(opa, opb, opc Operand)  { a.op3("VPUNPCKHQDQ", opa, opb, opc) }
+func (a *Asm) Vpunpcklbw(opa, opb, opc Operand)   { a.op3("VPUNPCKLBW", opa, opb, opc) }
+func (a *Asm) Vpunpckldq(opa, opb, opc Operand)   { a.op3("VPUNPCKLDQ", opa, opb, opc) }
+func (a *Asm) Vpunpcklqdq(opa, opb, opc Operand)  { a.op3("VPUNPCKLQDQ", opa, opb, opc) }
+func (a *Asm) Vpunpckhwd(opa, opb, opc Operand)   { a.op3("VPUNPCKHWD", opa, opb, opc) }
+func (a *Asm) Vpunpcklwd(opa, opb, opc Operand)   { a.op3("VPUNPCKLWD", opa, opb, opc) }
+func (a *Asm) Vpxor(opa, opb, opc Operand)        { a.op3("VPXOR", opa, opb, opc) }
+
+func (a *Asm) Vinserti128(opa, opb, opc, opd Operand) { a.op4("VINSERTI128", opa, opb, opc, opd) }
+func (a *Asm) Vpinsrw(opa, opb, opc, opd Operand)     { a.op4("VPINSRW", opa, opb, opc, opd) }
+func (a *Asm) Vshufps(opa, opb, opc, opd Operand)     { a.op4("VSHUFPS", opa, opb, opc, opd) }
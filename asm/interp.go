@@ -0,0 +1,745 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package asm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Machine runs recorded functions in pure go, so generated code can be
+// checked on any host. It only knows the instructions emitted by Asm and
+// reports any memory access outside of arguments, stack, global data or
+// slices given to Call.
+type Machine struct {
+	gp      [16]uint64
+	simd    [16][32]byte
+	zf, lt  bool
+	mem     []byte
+	regions [][2]int
+	symbols map[*symbol]int
+	fp, sp  int
+}
+
+const (
+	guard    = 64      // unmapped bytes before every memory region
+	maxSteps = 1 << 30 // catch endless loops
+)
+
+var gpnames = map[string]int{
+	"AX": 0, "CX": 1, "DX": 2, "BX": 3, "SP": 4, "BP": 5, "SI": 6, "DI": 7,
+	"R8": 8, "R9": 9, "R10": 10, "R11": 11, "R12": 12, "R13": 13, "R14": 14, "R15": 15,
+	"AL": 0, "CL": 1, "DL": 2, "BL": 3,
+}
+
+type fault string
+
+func (m *Machine) fail(format string, args ...interface{}) {
+	panic(fault(fmt.Sprintf(format, args...)))
+}
+
+// Call runs fn with args, which are ints or []byte & []int16 slices.
+// Slices are copied back once fn returns.
+func (m *Machine) Call(fn *Function, args ...interface{}) (err error) {
+	*m = Machine{symbols: map[*symbol]int{}}
+	m.fp = m.alloc(fn.Args)
+	m.sp = m.alloc(fn.Stack) + fn.Stack
+	addrs := []int{}
+	off := 0
+	for _, arg := range args {
+		if off >= fn.Args {
+			return fmt.Errorf("%v: too many arguments", fn.Name)
+		}
+		switch t := arg.(type) {
+		case int:
+			m.write(m.fp+off, uint64(t))
+			off += 8
+			continue
+		case []byte:
+			addr := m.alloc(len(t))
+			copy(m.mem[addr:], t)
+			addrs = append(addrs, addr)
+			m.write(m.fp+off, uint64(addr), uint64(len(t)), uint64(len(t)))
+		case []int16:
+			addr := m.alloc(len(t) * 2)
+			for i, v := range t {
+				binary.LittleEndian.PutUint16(m.mem[addr+i*2:], uint16(v))
+			}
+			addrs = append(addrs, addr)
+			m.write(m.fp+off, uint64(addr), uint64(len(t)), uint64(len(t)))
+		case []int32:
+			addr := m.alloc(len(t) * 4)
+			for i, v := range t {
+				binary.LittleEndian.PutUint32(m.mem[addr+i*4:], uint32(v))
+			}
+			addrs = append(addrs, addr)
+			m.write(m.fp+off, uint64(addr), uint64(len(t)), uint64(len(t)))
+		default:
+			return fmt.Errorf("%v: unsupported argument type %T", fn.Name, arg)
+		}
+		off += 24
+	}
+	if off != fn.Args {
+		return fmt.Errorf("%v: got %v bytes of arguments, want %v", fn.Name, off, fn.Args)
+	}
+	pc := 0
+	defer func() {
+		if r := recover(); r != nil {
+			f, ok := r.(fault)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%v: %v: %v", fn.Name, fn.Code[pc], string(f))
+		}
+	}()
+	for steps := 0; ; steps++ {
+		if pc >= len(fn.Code) {
+			return fmt.Errorf("%v: missing RET", fn.Name)
+		}
+		if steps > maxSteps {
+			return fmt.Errorf("%v: too many steps", fn.Name)
+		}
+		next, done := m.step(fn, fn.Code[pc])
+		if done {
+			break
+		}
+		if next < 0 {
+			next = pc + 1
+		}
+		pc = next
+	}
+	i := 0
+	for _, arg := range args {
+		switch t := arg.(type) {
+		case []byte:
+			copy(t, m.mem[addrs[i]:])
+			i++
+		case []int16:
+			for j := range t {
+				t[j] = int16(binary.LittleEndian.Uint16(m.mem[addrs[i]+j*2:]))
+			}
+			i++
+		case []int32:
+			for j := range t {
+				t[j] = int32(binary.LittleEndian.Uint32(m.mem[addrs[i]+j*4:]))
+			}
+			i++
+		}
+	}
+	return nil
+}
+
+func (m *Machine) alloc(size int) int {
+	start := (len(m.mem) + guard + 63) &^ 63
+	m.mem = append(m.mem, make([]byte, start+size-len(m.mem))...)
+	m.regions = append(m.regions, [2]int{start, start + size})
+	return start
+}
+
+func (m *Machine) write(addr int, values ...uint64) {
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(m.mem[addr+i*8:], v)
+	}
+}
+
+func (m *Machine) access(addr, size int) []byte {
+	for _, r := range m.regions {
+		if addr >= r[0] && addr+size <= r[1] {
+			return m.mem[addr : addr+size]
+		}
+	}
+	m.fail("invalid %v bytes access at %#x", size, addr)
+	return nil
+}
+
+func (m *Machine) reg(r Register) int {
+	idx, ok := gpnames[r.literal.String()]
+	if !ok {
+		m.fail("unknown register %v", r)
+	}
+	return idx
+}
+
+func (m *Machine) simdreg(op Operand) (int, int) {
+	r, ok := op.(SimdRegister)
+	if !ok {
+		m.fail("%v is not a simd register", op)
+	}
+	var idx int
+	if _, err := fmt.Sscanf(r.String()[1:], "%d", &idx); err != nil || idx > 15 {
+		m.fail("unknown register %v", r)
+	}
+	if r.String()[0] == 'Y' {
+		return idx, 32
+	}
+	return idx, 16
+}
+
+func (m *Machine) address(op Operand) int {
+	switch t := op.(type) {
+	case memory:
+		addr := t.disp
+		if t.base.literal != "" {
+			addr += int(m.gp[m.reg(t.base)])
+		}
+		if t.scale != SX0 {
+			addr += int(m.gp[m.reg(t.index)]) * int(t.scale)
+		}
+		return addr
+	case *Argument:
+		return m.fp + t.offset
+	case *StackOperand:
+		return m.sp - t.offset
+	case *symbol:
+		addr, ok := m.symbols[t]
+		if !ok {
+			addr = m.alloc(len(t.data))
+			// DATA directives store each 8 bytes as one big-endian quad
+			for i := 0; i+8 <= len(t.data); i += 8 {
+				binary.LittleEndian.PutUint64(m.mem[addr+i:], binary.BigEndian.Uint64(t.data[i:]))
+			}
+			m.symbols[t] = addr
+		}
+		return addr
+	}
+	m.fail("%v is not a memory operand", op)
+	return 0
+}
+
+func isRegister(op Operand) bool {
+	switch op.(type) {
+	case Register, SimdRegister, immediate:
+		return true
+	}
+	return false
+}
+
+// bytes reads size bytes from any operand
+func (m *Machine) bytes(op Operand, size int) []byte {
+	buf := make([]byte, 32)
+	switch t := op.(type) {
+	case Register:
+		binary.LittleEndian.PutUint64(buf, m.gp[m.reg(t)])
+	case immediate:
+		binary.LittleEndian.PutUint64(buf, uint64(t.value))
+	case SimdRegister:
+		idx, _ := m.simdreg(t)
+		copy(buf, m.simd[idx][:])
+	default:
+		copy(buf, m.access(m.address(op), size))
+	}
+	return buf[:size]
+}
+
+// put writes data to a general purpose register or to memory
+func (m *Machine) put(op Operand, data []byte) {
+	switch t := op.(type) {
+	case Register:
+		idx := m.reg(t)
+		buf := make([]byte, 8)
+		if len(data) < 4 {
+			binary.LittleEndian.PutUint64(buf, m.gp[idx])
+		}
+		copy(buf, data)
+		m.gp[idx] = binary.LittleEndian.Uint64(buf)
+	case immediate, SimdRegister:
+		m.fail("%v is not writable", op)
+	default:
+		copy(m.access(m.address(op), len(data)), data)
+	}
+}
+
+func (m *Machine) getq(op Operand) uint64 {
+	return binary.LittleEndian.Uint64(m.bytes(op, 8))
+}
+
+func (m *Machine) setq(op Operand, v uint64) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, v)
+	m.put(op, buf)
+}
+
+func (m *Machine) imm(op Operand) int {
+	t, ok := op.(immediate)
+	if !ok {
+		m.fail("%v is not an immediate", op)
+	}
+	return int(t.value)
+}
+
+// vector reads a simd source, legacy sse memory operands must be aligned
+func (m *Machine) vector(op Operand, size int, aligned bool) []byte {
+	if op == nil {
+		return nil
+	}
+	if aligned && !isRegister(op) && m.address(op)%16 != 0 {
+		m.fail("unaligned access at %#x", m.address(op))
+	}
+	return m.bytes(op, size)
+}
+
+// sse writes the low lane & preserves upper bits like legacy encodings
+func (m *Machine) sse(dst Operand, data []byte) {
+	idx, _ := m.simdreg(dst)
+	copy(m.simd[idx][:16], data)
+}
+
+// vex writes data & zeroes upper bits like vex encodings
+func (m *Machine) vex(dst Operand, data []byte) {
+	idx, _ := m.simdreg(dst)
+	m.simd[idx] = [32]byte{}
+	copy(m.simd[idx][:], data)
+}
+
+// store writes the low size bytes of a simd register to dst
+func (m *Machine) store(dst, src Operand, size int) {
+	m.put(dst, m.vector(src, size, false))
+}
+
+func (m *Machine) legacy(fn laneop, dst, a, b Operand, imm int) {
+	m.sse(dst, fn(m.vector(a, 16, true), m.vector(b, 16, true), imm))
+}
+
+func (m *Machine) lanes(fn laneop, dst, a, b Operand, imm int) {
+	_, size := m.simdreg(dst)
+	va, vb := m.vector(a, size, false), m.vector(b, size, false)
+	out := []byte{}
+	for i := 0; i < size; i += 16 {
+		var lb []byte
+		if vb != nil {
+			lb = vb[i : i+16]
+		}
+		out = append(out, fn(va[i:i+16], lb, imm)...)
+	}
+	m.vex(dst, out)
+}
+
+func (m *Machine) flags(r uint64, overflow bool) {
+	m.zf = r == 0
+	m.lt = (int64(r) < 0) != overflow
+}
+
+func (m *Machine) sub(x, y uint64) uint64 {
+	r := x - y
+	m.flags(r, int64((x^y)&(x^r)) < 0)
+	return r
+}
+
+func (m *Machine) add(x, y uint64) uint64 {
+	r := x + y
+	m.flags(r, int64((x^r)&(y^r)) < 0)
+	return r
+}
+
+// step runs one instruction & returns the next pc if it jumps
+func (m *Machine) step(fn *Function, in Instruction) (int, bool) {
+	a := in.Args
+	jump := func(cond bool) int {
+		if !cond {
+			return -1
+		}
+		l, _ := a[0].(label)
+		pc, ok := fn.labels[l]
+		if !ok {
+			m.fail("unknown label %v", a[0])
+		}
+		return pc
+	}
+	switch in.Op {
+	case "RET":
+		return -1, true
+	case "JMP":
+		return jump(true), false
+	case "JE":
+		return jump(m.zf), false
+	case "JNE":
+		return jump(!m.zf), false
+	case "JLT":
+		return jump(m.lt), false
+	case "ADDQ":
+		m.setq(a[0], m.add(m.getq(a[0]), m.getq(a[1])))
+	case "INCQ":
+		m.setq(a[0], m.add(m.getq(a[0]), 1))
+	case "SUBQ":
+		m.setq(a[0], m.sub(m.getq(a[0]), m.getq(a[1])))
+	case "NEGQ":
+		m.setq(a[0], m.sub(0, m.getq(a[0])))
+	case "CMPQ":
+		// printed operands are swapped, compare source to destination
+		m.sub(m.getq(a[1]), m.getq(a[0]))
+	case "ANDQ":
+		r := m.getq(a[0]) & m.getq(a[1])
+		m.flags(r, false)
+		m.setq(a[0], r)
+	case "ORQ":
+		r := m.getq(a[0]) | m.getq(a[1])
+		m.flags(r, false)
+		m.setq(a[0], r)
+	case "SHLQ", "SHRQ":
+		r, n := m.getq(a[0]), uint(m.getq(a[1])&63)
+		if in.Op == "SHLQ" {
+			r <<= n
+		} else {
+			r >>= n
+		}
+		if n != 0 {
+			m.flags(r, false)
+		}
+		m.setq(a[0], r)
+	case "MULQ", "IMULQ":
+		x, y := m.gp[0], m.getq(a[0])
+		hi, lo := bits.Mul64(x, y)
+		if in.Op == "IMULQ" {
+			if int64(x) < 0 {
+				hi -= y
+			}
+			if int64(y) < 0 {
+				hi -= x
+			}
+		}
+		m.gp[0], m.gp[2] = lo, hi
+	case "IMUL3Q":
+		m.setq(a[0], m.getq(a[1])*m.getq(a[2]))
+	case "LEAQ":
+		m.setq(a[0], uint64(m.address(a[1])))
+	case "MOVWQSX":
+		m.setq(a[0], uint64(int16(binary.LittleEndian.Uint16(m.bytes(a[1], 2)))))
+	case "MOVWQZX":
+		m.setq(a[0], uint64(binary.LittleEndian.Uint16(m.bytes(a[1], 2))))
+	case "MOVBQZX":
+		m.setq(a[0], uint64(m.bytes(a[1], 1)[0]))
+	case "MOVB":
+		m.put(a[0], m.bytes(a[1], 1))
+	case "MOVW":
+		m.put(a[0], m.bytes(a[1], 2))
+	case "CMOVQLT":
+		v := m.getq(a[1])
+		if m.lt {
+			m.setq(a[0], v)
+		}
+	case "MOVQ", "MOVL", "VMOVQ", "VMOVD":
+		size := 8
+		if in.Op == "MOVL" || in.Op == "VMOVD" {
+			size = 4
+		}
+		switch {
+		case !isSimd(a[0]) && !isSimd(a[1]):
+			v := make([]byte, 8)
+			copy(v, m.bytes(a[1], size))
+			m.setq(a[0], binary.LittleEndian.Uint64(v))
+		case !isSimd(a[0]):
+			m.store(a[0], a[1], size)
+		case in.Op[0] == 'V':
+			m.vex(a[0], m.bytes(a[1], size))
+		default:
+			m.sse(a[0], append(m.bytes(a[1], size), make([]byte, 16-size)...))
+		}
+	case "MOVO", "MOVOU":
+		aligned := in.Op == "MOVO"
+		if isSimd(a[0]) {
+			m.sse(a[0], m.vector(a[1], 16, aligned))
+		} else {
+			m.vector(a[0], 16, aligned)
+			m.store(a[0], a[1], 16)
+		}
+	case "VMOVDQA", "VMOVDQU":
+		reg := a[0]
+		if !isSimd(reg) {
+			reg = a[1]
+		}
+		_, size := m.simdreg(reg)
+		mem := a[1]
+		if reg == a[1] {
+			mem = a[0]
+		}
+		if in.Op == "VMOVDQA" && !isRegister(mem) && m.address(mem)%size != 0 {
+			m.fail("unaligned access at %#x", m.address(mem))
+		}
+		if isSimd(a[0]) {
+			m.vex(a[0], m.bytes(a[1], size))
+		} else {
+			m.store(a[0], a[1], size)
+		}
+	case "VBROADCASTI128":
+		v := m.bytes(a[1], 16)
+		m.vex(a[0], append(v, v...))
+	case "VINSERTI128":
+		v := m.bytes(a[1], 32)
+		copy(v[16*(m.imm(a[3])&1):], m.bytes(a[2], 16))
+		m.vex(a[0], v)
+	case "VEXTRACTI128":
+		v := m.bytes(a[1], 32)[16*(m.imm(a[2])&1):]
+		if isSimd(a[0]) {
+			m.vex(a[0], v[:16])
+		} else {
+			m.put(a[0], v[:16])
+		}
+	case "PINSRW":
+		v := m.bytes(a[0], 16)
+		copy(v[2*(m.imm(a[2])&7):], m.bytes(a[1], 2))
+		m.sse(a[0], v)
+	case "VPINSRW":
+		v := m.bytes(a[1], 16)
+		copy(v[2*(m.imm(a[3])&7):], m.bytes(a[2], 2))
+		m.vex(a[0], v)
+	case "VZEROUPPER":
+		for i := range m.simd {
+			copy(m.simd[i][16:], make([]byte, 16))
+		}
+	case "PSRAL", "PSRLW":
+		m.legacy(laneops[in.Op], a[0], a[0], nil, m.imm(a[1]))
+	case "PSHUFLW", "PSHUFHW":
+		m.legacy(laneops[in.Op], a[0], a[1], nil, m.imm(a[2]))
+	case "SHUFPS":
+		m.legacy(laneops[in.Op], a[0], a[0], a[1], m.imm(a[2]))
+	case "VPSRAD", "VPSHUFLW", "VPSHUFHW":
+		m.lanes(laneops[in.Op], a[0], a[1], nil, m.imm(a[2]))
+	case "VSHUFPS":
+		m.lanes(laneops[in.Op], a[0], a[1], a[2], m.imm(a[3]))
+	default:
+		fn, ok := laneops[in.Op]
+		switch {
+		case !ok:
+			m.fail("unsupported instruction")
+		case in.Op[0] == 'V':
+			m.lanes(fn, a[0], a[1], a[2], 0)
+		default:
+			m.legacy(fn, a[0], a[0], a[1], 0)
+		}
+	}
+	return -1, false
+}
+
+func isSimd(op Operand) bool {
+	_, ok := op.(SimdRegister)
+	return ok
+}
+
+// laneop computes one 128-bit lane from lanes a & b
+type laneop func(a, b []byte, imm int) []byte
+
+var laneops = map[string]laneop{
+	"PXOR":        pxor,
+	"VPXOR":       pxor,
+	"PUNPCKLBW":   unpack(1, 0),
+	"VPUNPCKLBW":  unpack(1, 0),
+	"PUNPCKHBW":   unpack(1, 8),
+	"VPUNPCKHBW":  unpack(1, 8),
+	"PUNPCKLWL":   unpack(2, 0),
+	"VPUNPCKLWD":  unpack(2, 0),
+	"PUNPCKHWL":   unpack(2, 8),
+	"VPUNPCKHWD":  unpack(2, 8),
+	"PUNPCKLLQ":   unpack(4, 0),
+	"VPUNPCKLDQ":  unpack(4, 0),
+	"PUNPCKLQDQ":  unpack(8, 0),
+	"VPUNPCKLQDQ": unpack(8, 0),
+	"PUNPCKHQDQ":  unpack(8, 8),
+	"VPUNPCKHQDQ": unpack(8, 8),
+	"PMADDWL":     pmaddwd,
+	"VPMADDWD":    pmaddwd,
+	"PADDL":       padd(4),
+	"VPADDD":      padd(4),
+	"PADDW":       padd(2),
+	"VPADDW":      padd(2),
+	"PADDB":       bytewise(func(a, b byte) byte { return a + b }),
+	"PSUBB":       bytewise(func(a, b byte) byte { return a - b }),
+	"PSUBUSB":     bytewise(psubusb),
+	"PMINUB":      bytewise(pminub),
+	"PMAXUB":      bytewise(pmaxub),
+	"PAND":        bytewise(func(a, b byte) byte { return a & b }),
+	"PMULLW":      pmullw,
+	"PSRLW":       psrlw,
+	"PSRAL":       psrad,
+	"VPSRAD":      psrad,
+	"PACKSSLW":    packssdw,
+	"VPACKSSDW":   packssdw,
+	"PACKUSWB":    packuswb,
+	"VPACKUSWB":   packuswb,
+	"PSHUFB":      pshufb,
+	"VPSHUFB":     pshufb,
+	"PSHUFLW":     pshufw(0),
+	"VPSHUFLW":    pshufw(0),
+	"PSHUFHW":     pshufw(4),
+	"VPSHUFHW":    pshufw(4),
+	"SHUFPS":      shufps,
+	"VSHUFPS":     shufps,
+}
+
+func word(v []byte, i int) int32 {
+	return int32(int16(binary.LittleEndian.Uint16(v[i*2:])))
+}
+
+func dword(v []byte, i int) int32 {
+	return int32(binary.LittleEndian.Uint32(v[i*4:]))
+}
+
+func pxor(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := range out {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+// unpack interleaves size elements from a & b, starting at byte half
+func unpack(size, half int) laneop {
+	return func(a, b []byte, imm int) []byte {
+		out := []byte{}
+		for i := half; i < half+8; i += size {
+			out = append(out, a[i:i+size]...)
+			out = append(out, b[i:i+size]...)
+		}
+		return out
+	}
+}
+
+func pmaddwd(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := 0; i < 4; i++ {
+		v := word(a, i*2)*word(b, i*2) + word(a, i*2+1)*word(b, i*2+1)
+		binary.LittleEndian.PutUint32(out[i*4:], uint32(v))
+	}
+	return out
+}
+
+func padd(size int) laneop {
+	return func(a, b []byte, imm int) []byte {
+		out := make([]byte, 16)
+		for i := 0; i < 16; i += size {
+			if size == 2 {
+				binary.LittleEndian.PutUint16(out[i:], binary.LittleEndian.Uint16(a[i:])+binary.LittleEndian.Uint16(b[i:]))
+			} else {
+				binary.LittleEndian.PutUint32(out[i:], binary.LittleEndian.Uint32(a[i:])+binary.LittleEndian.Uint32(b[i:]))
+			}
+		}
+		return out
+	}
+}
+
+// bytewise applies fn on each byte from a & b
+func bytewise(fn func(a, b byte) byte) laneop {
+	return func(a, b []byte, imm int) []byte {
+		out := make([]byte, 16)
+		for i := range out {
+			out[i] = fn(a[i], b[i])
+		}
+		return out
+	}
+}
+
+func psubusb(a, b byte) byte {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+func pminub(a, b byte) byte {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func pmaxub(a, b byte) byte {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func pmullw(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(word(a, i)*word(b, i)))
+	}
+	return out
+}
+
+func psrlw(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	if imm > 15 {
+		return out
+	}
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint16(out[i*2:], binary.LittleEndian.Uint16(a[i*2:])>>uint(imm))
+	}
+	return out
+}
+
+func psrad(a, b []byte, imm int) []byte {
+	if imm > 31 {
+		imm = 31
+	}
+	out := make([]byte, 16)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint32(out[i*4:], uint32(dword(a, i)>>uint(imm)))
+	}
+	return out
+}
+
+func clamp(v, min, max int32) int32 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func packssdw(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(clamp(dword(a, i), -32768, 32767)))
+		binary.LittleEndian.PutUint16(out[i*2+8:], uint16(clamp(dword(b, i), -32768, 32767)))
+	}
+	return out
+}
+
+func packuswb(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := 0; i < 8; i++ {
+		out[i] = byte(clamp(word(a, i), 0, 255))
+		out[i+8] = byte(clamp(word(b, i), 0, 255))
+	}
+	return out
+}
+
+func pshufb(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i, idx := range b[:16] {
+		if idx&0x80 == 0 {
+			out[i] = a[idx&15]
+		}
+	}
+	return out
+}
+
+// pshufw shuffles the 4 words starting at word half
+func pshufw(half int) laneop {
+	return func(a, b []byte, imm int) []byte {
+		out := append([]byte{}, a[:16]...)
+		for i := 0; i < 4; i++ {
+			src := half + (imm>>uint(i*2))&3
+			copy(out[(half+i)*2:], a[src*2:src*2+2])
+		}
+		return out
+	}
+}
+
+func shufps(a, b []byte, imm int) []byte {
+	out := make([]byte, 16)
+	for i := 0; i < 4; i++ {
+		src := a
+		if i > 1 {
+			src = b
+		}
+		idx := (imm >> uint(i*2)) & 3
+		copy(out[i*4:], src[idx*4:idx*4+4])
+	}
+	return out
+}
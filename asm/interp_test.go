@@ -0,0 +1,86 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package asm
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// genmadd generates a function applying one coeff pair on every pair of
+// source bytes, 16 source bytes at a time
+func genmadd(a *Asm) *Function {
+	a.NewFunction("madd")
+	dst := a.SliceArgument("dst")
+	src := a.SliceArgument("src")
+	cof := a.SliceArgument("cof")
+	round := a.Data("round", []byte{0, 0, 0x20, 0, 0, 0, 0x20, 0, 0, 0, 0x20, 0, 0, 0, 0x20, 0})
+	a.Start()
+	loop := a.NewLabel("loop")
+	a.Movq(DI, dst[0])
+	a.Movq(SI, src[0])
+	a.Movq(CX, src[1])
+	a.Movq(BP, cof[0])
+	a.Movou(X3, Address(BP))
+	a.Pxor(X4, X4)
+	a.Label(loop)
+	a.Movou(X0, Address(SI))
+	a.Movo(X1, X0)
+	a.Punpcklbw(X0, X4)
+	a.Punpckhbw(X1, X4)
+	a.Pmaddwd(X0, X3)
+	a.Pmaddwd(X1, X3)
+	a.Paddd(X0, round)
+	a.Paddd(X1, round)
+	a.Psrad(X0, Constant(14))
+	a.Psrad(X1, Constant(14))
+	a.Packssdw(X0, X1)
+	a.Packuswb(X0, X0)
+	a.Movq(Address(DI), X0)
+	a.Addq(SI, Constant(16))
+	a.Addq(DI, Constant(8))
+	a.Subq(CX, Constant(16))
+	a.Jne(loop)
+	a.Ret()
+	return a.Functions()[0]
+}
+
+func TestMachine(t *testing.T) {
+	fn := genmadd(NewAsm(ioutil.Discard))
+	src := make([]byte, 32)
+	for i := range src {
+		src[i] = byte(i * 8)
+	}
+	cof := []int16{}
+	for i := 0; i < 4; i++ {
+		cof = append(cof, 1<<13, 1<<14)
+	}
+	dst := make([]byte, 16)
+	m := &Machine{}
+	err := m.Call(fn, dst, src, cof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range dst {
+		pix := int(src[i*2])*(1<<13) + int(src[i*2+1])*(1<<14)
+		want := (pix + 1<<13) >> 14
+		if want > 255 {
+			want = 255
+		}
+		if int(v) != want {
+			t.Fatalf("invalid pixel %v: got %v, want %v", i, v, want)
+		}
+	}
+	// output buffer too small
+	err = m.Call(fn, dst[:15], src, cof)
+	if err == nil || !strings.Contains(err.Error(), "invalid 8 bytes access") {
+		t.Fatalf("missing invalid access: %v", err)
+	}
+	err = m.Call(fn, dst, src)
+	if err == nil {
+		t.Fatalf("missing arguments error")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const (
+	// autotuneRuns is the number of timed conversions per candidate, the
+	// fastest one is kept
+	autotuneRuns = 3
+)
+
+// Tuning describes the configuration of one resizer picked by an autotuned
+// Converter
+type Tuning struct {
+	Plane    int           // plane index
+	Vertical bool          // vertical resizer, horizontal otherwise
+	ISA      ISA           // instruction set
+	Threads  int           // number of threads
+	Duration time.Duration // fastest resize time
+	// Candidates are every benchmarked configuration of the resizer, in
+	// benchmark order, only set on the picked one
+	Candidates []Tuning
+}
+
+func (t Tuning) String() string {
+	dir := "horizontal"
+	if t.Vertical {
+		dir = "vertical"
+	}
+	return fmt.Sprintf("plane %v %v %v x%v %v", t.Plane, dir, t.ISA, t.Threads, t.Duration)
+}
+
+// Tunings returns configurations picked for every resizer, by plane then
+// horizontal first, or nil if the converter was not autotuned
+func (ctx *converterContext) Tunings() []Tuning {
+	return ctx.tunings
+}
+
+// getTuningISAs returns instruction sets worth benchmarking with cfg, which
+// are the ones implementing scalers
+func getTuningISAs(cfg *ConverterConfig) []ISA {
+	best := getISA(&ResizerConfig{DisableAsm: cfg.DisableAsm, ISA: cfg.ISA})
+	isas := []ISA{ISAGo}
+	for isa := ISAGo + 1; isa <= best; isa++ {
+		if hasScalers(isa) {
+			isas = append(isas, isa)
+		}
+	}
+	return isas
+}
+
+// getTuningThreads returns powers of two below threads, then threads
+func getTuningThreads(threads int) []int {
+	list := []int{}
+	for i := 1; i < threads; i <<= 1 {
+		list = append(list, i)
+	}
+	return append(list, threads)
+}
+
+// getTuningPlanes returns planes described by d
+func getTuningPlanes(d *Descriptor) []Plane {
+	planes := []Plane{}
+	for i := 0; i < d.Planes; i++ {
+		p := newPlane(d.GetWidth(i), d.GetHeight(i), d.Pack)
+		for j := range p.Data {
+			p.Data[j] = byte(j * 7)
+		}
+		planes = append(planes, *p)
+	}
+	return planes
+}
+
+// getResizeJob returns a function running the horizontal or vertical
+// resizer of plane idx alone, or nil if the plane has none
+func (ctx *converterContext) getResizeJob(idx int, vertical bool, dst, src []Plane) func() {
+	if ctx.ewa[idx] != nil {
+		return nil
+	}
+	s := ctx.runStages(idx, &src[idx])
+	d := &dst[idx]
+	if ctx.dither[idx] != nil {
+		d = ctx.dither[idx]
+	}
+	hrez, wrez := ctx.hrez[idx], ctx.wrez[idx]
+	if vertical && hrez != nil {
+		if wrez != nil {
+			d = ctx.buffer[idx]
+		}
+		return func() {
+			hrez.Resize(d.Data, s.Data, s.Width, s.Height, d.Pitch, s.Pitch)
+		}
+	}
+	if !vertical && wrez != nil {
+		if hrez != nil {
+			s = ctx.buffer[idx]
+		}
+		return func() {
+			wrez.Resize(d.Data, s.Data, s.Width, s.Height, d.Pitch, s.Pitch)
+		}
+	}
+	return nil
+}
+
+// timeJob returns the fastest of autotuneRuns job runs
+func timeJob(job func()) time.Duration {
+	// warm up buffers & caches
+	job()
+	best := time.Duration(0)
+	for i := 0; i < autotuneRuns; i++ {
+		start := time.Now()
+		job()
+		d := time.Since(start)
+		if i == 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// autotune returns a converter using the fastest instruction set & thread
+// count allowed by cfg on every resizer of every plane
+// Instruction sets picking the same scalers as a lower one are skipped
+func autotune(cfg *ConverterConfig, filter Filter) (Converter, error) {
+	src := getTuningPlanes(&cfg.Input)
+	dst := getTuningPlanes(&cfg.Output)
+	fixed := *cfg
+	fixed.Autotune = false
+	converter, err := NewConverter(&fixed, filter)
+	if err != nil {
+		return nil, err
+	}
+	best := converter.(*converterContext)
+	// candidates & picked one per plane & direction
+	candidates := [maxPlanes][2][]Tuning{}
+	picks := [maxPlanes][2]int{}
+	var scalers []ScalerInfo
+	for i, isa := range getTuningISAs(cfg) {
+		for j, threads := range getTuningThreads(cfg.Threads) {
+			tuned := fixed
+			tuned.ISA = isa
+			tuned.Threads = threads
+			converter, err := NewConverter(&tuned, filter)
+			if err != nil {
+				return nil, err
+			}
+			ctx := converter.(*converterContext)
+			if j == 0 {
+				infos := ctx.Scalers()
+				if i > 0 && reflect.DeepEqual(infos, scalers) {
+					break
+				}
+				scalers = infos
+			}
+			for p := 0; p < cfg.Output.Planes; p++ {
+				for k, vertical := range []bool{false, true} {
+					job := ctx.getResizeJob(p, vertical, dst, src)
+					if job == nil {
+						continue
+					}
+					d := timeJob(job)
+					list := append(candidates[p][k], Tuning{p, vertical, isa, threads, d, nil})
+					candidates[p][k] = list
+					if len(list) > 1 && d >= list[picks[p][k]].Duration {
+						continue
+					}
+					picks[p][k] = len(list) - 1
+					if vertical {
+						best.hrez[p] = ctx.hrez[p]
+					} else {
+						best.wrez[p] = ctx.wrez[p]
+					}
+				}
+			}
+		}
+	}
+	best.tunings = []Tuning{}
+	for p := 0; p < cfg.Output.Planes; p++ {
+		for k := range candidates[p] {
+			if list := candidates[p][k]; len(list) > 0 {
+				tuning := list[picks[p][k]]
+				tuning.Candidates = list
+				best.tunings = append(best.tunings, tuning)
+			}
+		}
+	}
+	best.Autotune = true
+	return best, nil
+}
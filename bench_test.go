@@ -7,6 +7,7 @@ package rez
 import (
 	"image"
 	_ "image/jpeg"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +31,7 @@ var (
 		{1920, 1080, 640, 480, true, false, NewBicubicFilter()},
 		{512, 512, 512, 512, true, false, NewBilinearFilter()},
 		{720, 576, 640, 480, false, true, NewBicubicFilter()},
+		{3840, 512, 1920, 8, false, false, NewBicubicFilter()},
 	}
 )
 
@@ -64,8 +66,60 @@ func BenchmarkImageBicubicIDownGo(b *testing.B)  { benchSpeed(b, benchs[7], fals
 func BenchmarkImageBicubicIDownAsm(b *testing.B) { benchSpeed(b, benchs[7], true) }
 func BenchmarkImageBicubicRgbGo(b *testing.B)    { benchSpeed(b, benchs[9], false) }
 func BenchmarkImageBicubicRgbAsm(b *testing.B)   { benchSpeed(b, benchs[9], true) }
+func BenchmarkImageBicubicStripGo(b *testing.B)  { benchSpeed(b, benchs[10], false) }
+func BenchmarkImageBicubicStripAsm(b *testing.B) { benchSpeed(b, benchs[10], true) }
 func BenchmarkCopy(b *testing.B)                 { benchSpeed(b, benchs[8], false) }
 
+// benchPrecise benchmarks bt with 16-bit intermediate planes
+func benchPrecise(b *testing.B, bt BenchType, asm bool) {
+	src := image.NewYCbCr(image.Rect(0, 0, bt.win, bt.hin), image.YCbCrSubsampleRatio420)
+	dst := image.NewYCbCr(image.Rect(0, 0, bt.wout, bt.hout), image.YCbCrSubsampleRatio420)
+	cfg, err := PrepareConversion(dst, src)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cfg.Precise = true
+	cfg.DisableAsm = !asm
+	converter, err := NewConverter(cfg, bt.filter)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(bt.wout*bt.hout*3) >> 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		converter.Convert(dst, src)
+	}
+}
+
+func BenchmarkImageBicubicUpPreciseGo(b *testing.B)    { benchPrecise(b, benchs[1], false) }
+func BenchmarkImageBicubicUpPreciseAsm(b *testing.B)   { benchPrecise(b, benchs[1], true) }
+func BenchmarkImageBicubicDownPreciseGo(b *testing.B)  { benchPrecise(b, benchs[4], false) }
+func BenchmarkImageBicubicDownPreciseAsm(b *testing.B) { benchPrecise(b, benchs[4], true) }
+
+// benchAntiRing benchmarks bt with full anti-ringing
+func benchAntiRing(b *testing.B, bt BenchType, asm bool) {
+	src := image.NewYCbCr(image.Rect(0, 0, bt.win, bt.hin), image.YCbCrSubsampleRatio420)
+	dst := image.NewYCbCr(image.Rect(0, 0, bt.wout, bt.hout), image.YCbCrSubsampleRatio420)
+	cfg, err := PrepareConversion(dst, src)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cfg.AntiRing = 1
+	cfg.DisableAsm = !asm
+	converter, err := NewConverter(cfg, bt.filter)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(bt.wout*bt.hout*3) >> 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		converter.Convert(dst, src)
+	}
+}
+
+func BenchmarkImageLanczosUpAntiRingGo(b *testing.B)  { benchAntiRing(b, benchs[2], false) }
+func BenchmarkImageLanczosUpAntiRingAsm(b *testing.B) { benchAntiRing(b, benchs[2], true) }
+
 func benchScaler(b *testing.B, asm, vertical bool, taps int) {
 	n := 96
 	src := make([]byte, n*n)
@@ -82,7 +136,10 @@ func benchScaler(b *testing.B, asm, vertical bool, taps int) {
 	if !vertical {
 		dp *= 2
 	}
-	resizer := NewResize(&cfg, NewLanczosFilter(taps>>1))
+	resizer, err := NewResize(&cfg, NewLanczosFilter(taps>>1))
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		resizer.Resize(dst, src, n, n, dp, n)
@@ -119,3 +176,165 @@ func BenchmarkHorizontalScaler12Go(b *testing.B)  { benchScaler(b, false, false,
 func BenchmarkHorizontalScaler12Asm(b *testing.B) { benchScaler(b, true, false, 12) }
 func BenchmarkHorizontalScalerNGo(b *testing.B)   { benchScaler(b, false, false, 14) }
 func BenchmarkHorizontalScalerNAsm(b *testing.B)  { benchScaler(b, true, false, 14) }
+
+// benchIsaScaler benchmarks scalers limited to isa
+func benchIsaScaler(b *testing.B, isa ISA, vertical bool, taps int) {
+	defer func(v ISA) { supportedISA = v }(supportedISA)
+	if supportedISA > isa {
+		supportedISA = isa
+	}
+	benchScaler(b, true, vertical, taps)
+}
+
+func BenchmarkVerticalScaler4Sse2(b *testing.B)    { benchIsaScaler(b, ISASSE2, true, 4) }
+func BenchmarkVerticalScaler8Sse2(b *testing.B)    { benchIsaScaler(b, ISASSE2, true, 8) }
+func BenchmarkHorizontalScaler2Sse2(b *testing.B)  { benchIsaScaler(b, ISASSE2, false, 2) }
+func BenchmarkHorizontalScaler4Sse2(b *testing.B)  { benchIsaScaler(b, ISASSE2, false, 4) }
+func BenchmarkHorizontalScaler8Sse2(b *testing.B)  { benchIsaScaler(b, ISASSE2, false, 8) }
+func BenchmarkHorizontalScaler2Ssse3(b *testing.B) { benchIsaScaler(b, ISASSSE3, false, 2) }
+func BenchmarkHorizontalScaler4Ssse3(b *testing.B) { benchIsaScaler(b, ISASSSE3, false, 4) }
+
+// benchDepth16 benchmarks 16-bit scalers halving 256x256 planes
+func benchDepth16(b *testing.B, isa ISA, vertical bool, taps int) {
+	n := 256
+	kind := horizontal16Scaler
+	width, height, size := n/2, n, n/2
+	off := make([]int16, size)
+	for i := range off {
+		off[i] = int16(min(i*2, n-taps))
+	}
+	if vertical {
+		kind = vertical16Scaler
+		width, height = n, n/2-taps
+		for i := range off {
+			off[i] = int16(bin(i > 0) * 2)
+		}
+	}
+	fn, info := getScaler(kind, taps, isa)
+	if info.ISA != isa {
+		b.Skip("unsupported isa")
+	}
+	cof := make([]int16, size*taps)
+	for i := range cof {
+		cof[i] = 1 << Bits / int16(taps)
+	}
+	cof = prepare16Coeffs(cof, size, taps, vertical, true, true, isa)
+	src := make([]byte, n*n*2)
+	dst := make([]byte, width*height*2)
+	b.SetBytes(int64(len(dst)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(dst, src, cof, off, taps, width, height, width*2, n*2)
+	}
+}
+
+func BenchmarkHorizontal16Scaler4Go(b *testing.B)   { benchDepth16(b, ISAGo, false, 4) }
+func BenchmarkHorizontal16Scaler4Sse2(b *testing.B) { benchDepth16(b, ISASSE2, false, 4) }
+func BenchmarkHorizontal16Scaler8Go(b *testing.B)   { benchDepth16(b, ISAGo, false, 8) }
+func BenchmarkHorizontal16Scaler8Sse2(b *testing.B) { benchDepth16(b, ISASSE2, false, 8) }
+func BenchmarkVertical16Scaler4Go(b *testing.B)     { benchDepth16(b, ISAGo, true, 4) }
+func BenchmarkVertical16Scaler4Sse2(b *testing.B)   { benchDepth16(b, ISASSE2, true, 4) }
+func BenchmarkVertical16Scaler8Go(b *testing.B)     { benchDepth16(b, ISAGo, true, 8) }
+func BenchmarkVertical16Scaler8Sse2(b *testing.B)   { benchDepth16(b, ISASSE2, true, 8) }
+
+// benchFold benchmarks vertical downscales with or without folded scalers
+// Only even downscale ratios have symmetric kernels with an even number of
+// taps, so 1.5x or 3x downscales never fold
+func benchFold(b *testing.B, fold bool, input, output int, filter Filter) {
+	if !fold {
+		defer func(v []scalerEntry) {
+			scalerRegistry[verticalFoldScaler] = v
+		}(scalerRegistry[verticalFoldScaler])
+		scalerRegistry[verticalFoldScaler] = nil
+	}
+	n := 256
+	src := make([]byte, n*input)
+	dst := make([]byte, n*output)
+	cfg := ResizerConfig{
+		Input:    input,
+		Output:   output,
+		Vertical: true,
+		Threads:  1,
+	}
+	resizer, err := NewResize(&cfg, filter)
+	if err != nil {
+		b.Fatal(err)
+	}
+	folded := false
+	for _, info := range resizer.Scalers(n) {
+		folded = folded || strings.HasPrefix(info.Name, "v8fold")
+	}
+	if fold && hasAsm() && getISA(&cfg) > ISAGo && !folded {
+		b.Fatal("no folded scaler picked")
+	}
+	b.SetBytes(int64(n * input))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resizer.Resize(dst, src, n, n, n, n)
+	}
+}
+
+func BenchmarkVerticalFold2x(b *testing.B)   { benchFold(b, true, 256, 128, NewLanczosFilter(3)) }
+func BenchmarkVerticalUnfold2x(b *testing.B) { benchFold(b, false, 256, 128, NewLanczosFilter(3)) }
+func BenchmarkVerticalFold4x(b *testing.B)   { benchFold(b, true, 256, 64, NewBicubicFilter()) }
+func BenchmarkVerticalUnfold4x(b *testing.B) { benchFold(b, false, 256, 64, NewBicubicFilter()) }
+
+func benchArea(b *testing.B, vertical bool, input, output int) {
+	n := 96
+	src := make([]byte, n*input)
+	dst := make([]byte, n*output)
+	cfg := ResizerConfig{
+		Input:    input,
+		Output:   output,
+		Vertical: vertical,
+		Threads:  1,
+		Sampling: SamplingArea,
+	}
+	dp, sp := output, input
+	if vertical {
+		dp, sp = n, n
+	}
+	resizer, err := NewResize(&cfg, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(n * input))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resizer.Resize(dst, src, n, n, dp, sp)
+	}
+}
+
+func BenchmarkVerticalArea2(b *testing.B)    { benchArea(b, true, 192, 96) }
+func BenchmarkVerticalArea4(b *testing.B)    { benchArea(b, true, 384, 96) }
+func BenchmarkVerticalArea20(b *testing.B)   { benchArea(b, true, 1920, 96) }
+func BenchmarkHorizontalArea2(b *testing.B)  { benchArea(b, false, 192, 96) }
+func BenchmarkHorizontalArea4(b *testing.B)  { benchArea(b, false, 384, 96) }
+func BenchmarkHorizontalArea20(b *testing.B) { benchArea(b, false, 1920, 96) }
+
+func benchPacked(b *testing.B, asm bool, pack int) {
+	n := 256
+	src := make([]byte, n*n*pack)
+	dst := make([]byte, n*n*pack*2)
+	cfg := ResizerConfig{
+		Input:      n,
+		Output:     n * 2,
+		Pack:       pack,
+		Threads:    1,
+		DisableAsm: !asm,
+	}
+	resizer, err := NewResize(&cfg, NewBicubicFilter())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(n * n * 2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resizer.Resize(dst, src, n, n, n*pack*2, n*pack)
+	}
+}
+
+func BenchmarkGrayScalerGo(b *testing.B)  { benchPacked(b, false, 1) }
+func BenchmarkGrayScalerAsm(b *testing.B) { benchPacked(b, true, 1) }
+func BenchmarkRgbaScalerGo(b *testing.B)  { benchPacked(b, false, 4) }
+func BenchmarkRgbaScalerAsm(b *testing.B) { benchPacked(b, true, 4) }
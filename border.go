@@ -0,0 +1,292 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+// Border is a method used to extend images beyond their edges
+type Border int
+
+const (
+	// BorderClamp repeats edge pixels
+	BorderClamp Border = iota
+	// BorderMirror reflects pixels around edges without repeating them
+	BorderMirror
+	// BorderWrap repeats the whole image, which suits tileable textures
+	BorderWrap
+	// BorderConstant uses a constant value beyond edges
+	BorderConstant
+)
+
+// getBorderIndex returns the source index used for i on a n-sized
+// dimension, or -1 if i must use the constant border value
+func getBorderIndex(i, n int, border Border) int {
+	if i >= 0 && i < n {
+		return i
+	}
+	switch border {
+	case BorderMirror:
+		if n == 1 {
+			return 0
+		}
+		period := 2*n - 2
+		if i < 0 {
+			i = -i
+		}
+		i %= period
+		if i >= n {
+			i = period - i
+		}
+		return i
+	case BorderWrap:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return i
+	case BorderConstant:
+		return -1
+	}
+	return clip(i, 0, n-1)
+}
+
+// getFieldIndex is getBorderIndex applied on the field of i when interlaced
+func getFieldIndex(i, n int, border Border, field uint) int {
+	if field == 0 {
+		return getBorderIndex(i, n, border)
+	}
+	idx := i & 1
+	j := getBorderIndex(i>>1, (n+1-idx)>>1, border)
+	if j < 0 {
+		return j
+	}
+	return j<<1 | idx
+}
+
+// isPadded returns whether border needs padded source planes
+func isPadded(border Border) bool {
+	return border == BorderWrap || border == BorderConstant
+}
+
+// getBorderMargin returns the number of pixels needed on both sides of
+// padded planes by taps kernels, which is even so fields are preserved
+func getBorderMargin(taps int, field uint) int {
+	return ((taps<<field)>>1 + 1) &^ 1
+}
+
+// strip is a padded copy of source pixels or rows around one edge, in
+// padded coordinates
+type strip struct {
+	first int // first padded pixel or row
+	size  int // number of padded pixels or rows
+}
+
+// segment is a kernel run part & the padded window it reads
+type segment struct {
+	kernel
+	first, last int // padded window, in pixels or rows
+}
+
+// splitKernel splits k into runs reading either source pixels only or
+// pixels around one edge, runs start on whole k.block & pixels
+// unit = scaler outputs per pixel, scale = offsets per pixel
+// taps = taps per pixel, m = field margin, n = field size
+func splitKernel(k *kernel, vertical bool, unit, scale, taps, m, n int) []segment {
+	count := k.count * unit
+	// absolute offsets in pixels or field rows
+	off := make([]int, count)
+	pos := 0
+	for i, v := range k.offsets[:count] {
+		if vertical {
+			pos += int(v)
+		} else {
+			pos = int(v) / scale
+		}
+		off[i] = pos
+	}
+	// trimmed kernels may step back, so the middle run starts after the
+	// last window crossing the left edge
+	x0, x1 := 0, count
+	for i, v := range off {
+		if v < m {
+			x0 = i + 1
+		}
+	}
+	for i, v := range off[x0:] {
+		if v+taps > m+n {
+			x1 = x0 + i
+			break
+		}
+	}
+	align := 1
+	if !vertical {
+		align = k.block * unit / gcd(k.block, unit)
+	}
+	x0 = (x0 + align - 1) / align * align
+	if x1 != count {
+		x1 = x1 / align * align
+	}
+	bounds := []int{0, count}
+	if x0 < x1 {
+		bounds = []int{0, x0, x1, count}
+	}
+	segments := []segment{}
+	for i := 0; i+1 < len(bounds); i++ {
+		a, b := bounds[i], bounds[i+1]
+		if a == b {
+			continue
+		}
+		s := segment{kernel: *k, first: off[a], last: off[a] + taps}
+		for _, v := range off[a:b] {
+			s.first = min(s.first, v)
+			s.last = max(s.last, v+taps)
+		}
+		s.start = k.start + a/unit
+		s.count = (b - a) / unit
+		if vertical {
+			s.coeffs = k.coeffs[a*k.rowcof:]
+			s.offsets = append([]int16{int16(off[a])}, k.offsets[a+1:b]...)
+		} else {
+			s.coeffs = k.coeffs[a/k.block*k.blockcof:]
+			s.offsets = append([]int16{}, k.offsets[a:b]...)
+		}
+		s.split = a > 0
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// splitBorders splits kernels of every field so that only runs reading
+// pixels beyond edges read padded strips, other runs read source planes
+// n = source size & m = margin, in pixels or rows
+// Returns kernels of every field & padded strips
+func splitBorders(cfg *ResizerConfig, fields [][]kernel, n, m int) ([][]kernel, []strip) {
+	field := bin(cfg.Vertical && cfg.Interlaced)
+	total := n + 2*m
+	parts := make([][]segment, len(fields))
+	left, right, both := 0, total, false
+	for i, kernels := range fields {
+		fm := m >> field
+		fn := (n + int(field)*(1-i)) >> field
+		for j := range kernels {
+			k := &kernels[j]
+			unit, scale, taps := 1, 1, k.size
+			if !cfg.Vertical {
+				// unpacked kernels have one output per sample
+				unit = cfg.Pack / k.pack
+				scale = cfg.Pack
+				taps = k.size / unit
+			}
+			for _, s := range splitKernel(k, cfg.Vertical, unit, scale, taps, fm, fn) {
+				// back to padded rows on both fields
+				s.first = s.first<<field | i
+				s.last = (s.last-1)<<field | i + 1
+				switch {
+				case s.first >= m && s.last <= m+n:
+					s.strip = -1
+				case s.last <= m+n:
+					s.strip = 0
+					left = max(left, s.last)
+				case s.first >= m:
+					s.strip = 1
+					right = min(right, s.first)
+				default:
+					s.strip = 0
+					both = true
+				}
+				parts[i] = append(parts[i], s)
+			}
+		}
+	}
+	// strips keep fields parity
+	right &^= 1
+	strips := []strip{{0, left}, {right, total - right}}
+	merged := both || left > right
+	if merged {
+		strips = []strip{{0, total}}
+	}
+	kernels := make([][]kernel, len(fields))
+	for i, segments := range parts {
+		for _, s := range segments {
+			base := m
+			if s.strip >= 0 {
+				if merged {
+					s.strip = 0
+				}
+				base = strips[s.strip].first
+			}
+			if cfg.Vertical {
+				s.offsets[0] -= int16(base >> field)
+			} else {
+				for j := range s.offsets {
+					s.offsets[j] -= int16(base * cfg.Pack)
+				}
+			}
+			kernels[i] = append(kernels[i], s.kernel)
+		}
+	}
+	return kernels, strips
+}
+
+// getStripSize returns the number of bytes used by s padded strip
+func (c *context) getStripSize(s strip, width, height, sample int) int {
+	if c.cfg.Vertical {
+		return width * c.cfg.Pack * sample * s.size
+	}
+	return s.size * c.cfg.Pack * sample * height
+}
+
+// padStrip copies s padded pixels or rows of src into dst
+// sample = bytes per source sample
+// Returns the padded strip pitch
+func (c *context) padStrip(dst, src []byte, s strip, width, height, sp, sample int) int {
+	pk := c.cfg.Pack * sample
+	// border value as source samples
+	value := make([]byte, pk)
+	for i := range value {
+		v := c.cfg.BorderValue[i/sample]
+		if sample == 1 {
+			value[i] = v
+		} else if i%sample == 1 {
+			value[i] = v // 8.8 fixed point, little endian
+		}
+	}
+	field := bin(c.cfg.Vertical && c.cfg.Interlaced)
+	m := c.margin
+	if !c.cfg.Vertical {
+		pitch := s.size * pk
+		for y := 0; y < height; y++ {
+			d := dst[pitch*y : pitch*(y+1)]
+			row := src[sp*y:]
+			for x := 0; x < s.size; x++ {
+				i := getBorderIndex(s.first+x-m, width, c.cfg.Border)
+				if i < 0 {
+					copy(d[x*pk:], value)
+					continue
+				}
+				copy(d[x*pk:], row[i*pk:i*pk+pk])
+			}
+		}
+		return pitch
+	}
+	pitch := width * pk
+	for y := 0; y < s.size; y++ {
+		d := dst[pitch*y : pitch*(y+1)]
+		i := getFieldIndex(s.first+y-m, height, c.cfg.Border, field)
+		if i >= 0 {
+			copy(d, src[sp*i:sp*i+pitch])
+			continue
+		}
+		for x := 0; x < width; x++ {
+			copy(d[x*pk:], value)
+		}
+	}
+	return pitch
+}
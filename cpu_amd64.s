@@ -0,0 +1,22 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// func cpuid(op, op2 uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB),4,$0-24
+		MOVL	op+0(FP), AX
+		MOVL	op2+4(FP), CX
+		CPUID
+		MOVL	AX, eax+8(FP)
+		MOVL	BX, ebx+12(FP)
+		MOVL	CX, ecx+16(FP)
+		MOVL	DX, edx+20(FP)
+		RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB),4,$0-8
+		MOVL	$0, CX
+		XGETBV
+		MOVL	AX, eax+0(FP)
+		MOVL	DX, edx+4(FP)
+		RET
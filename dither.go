@@ -0,0 +1,107 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"sync"
+)
+
+// Dither is a method used to quantize 16-bit intermediate samples to 8-bit
+type Dither int
+
+const (
+	// DitherNone rounds samples to the nearest value
+	DitherNone Dither = iota
+	// DitherOrdered adds a 8x8 bayer matrix threshold to samples
+	// It is deterministic and applied in parallel
+	DitherOrdered
+	// DitherDiffusion spreads quantization errors on neighbour samples
+	// with Floyd-Steinberg weights, it always runs on a single thread
+	DitherDiffusion
+)
+
+var (
+	bayer8x8 = [8][8]byte{
+		{0, 32, 8, 40, 2, 34, 10, 42},
+		{48, 16, 56, 24, 50, 18, 58, 26},
+		{12, 44, 4, 36, 14, 46, 6, 38},
+		{60, 28, 52, 20, 62, 30, 54, 22},
+		{3, 35, 11, 43, 1, 33, 9, 41},
+		{51, 19, 59, 27, 49, 17, 57, 25},
+		{15, 47, 7, 39, 13, 45, 5, 37},
+		{63, 31, 55, 23, 61, 29, 53, 21},
+	}
+)
+
+// ditherOrderedGo quantizes 16-bit 8.8 fixed point src into dst
+// y = index of the first row in the bayer matrix
+func ditherOrderedGo(dst, src []byte, y, width, height, dp, sp, pack int) {
+	di := 0
+	si := 0
+	for j := 0; j < height; j++ {
+		row := &bayer8x8[(y+j)&7]
+		d := dst[di : di+width*pack]
+		s := src[si:]
+		for i := range d {
+			v := int(s[i*2]) | int(s[i*2+1])<<8
+			d[i] = u8((v + int(row[(i/pack)&7])<<2 + 2) >> 8)
+		}
+		di += dp
+		si += sp
+	}
+}
+
+// ditherDiffusionGo quantizes 16-bit 8.8 fixed point src into dst
+func ditherDiffusionGo(dst, src []byte, width, height, dp, sp, pack int) {
+	size := width * pack
+	// errors are stored with one extra pixel on both sides
+	cur := make([]int, size+pack*2)
+	next := make([]int, size+pack*2)
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		d := dst[di : di+size]
+		s := src[si:]
+		for i := range d {
+			v := (int(s[i*2]) | int(s[i*2+1])<<8) + (cur[i+pack]+8)>>4
+			q := u8((v + 0x80) >> 8)
+			d[i] = q
+			e := v - int(q)<<8
+			cur[i+pack*2] += e * 7
+			next[i] += e * 3
+			next[i+pack] += e * 5
+			next[i+pack*2] += e
+		}
+		cur, next = next, cur
+		for i := range next {
+			next[i] = 0
+		}
+		di += dp
+		si += sp
+	}
+}
+
+// ditherPlane quantizes 16-bit src into dst with the input dither method
+func ditherPlane(dst, src *Plane, dither Dither, interlaced bool, threads int) {
+	field := bin(interlaced)
+	height := (dst.Height + int(field)) >> field
+	for idx := 0; idx < 1+int(field); idx++ {
+		d := dst.Data[dst.Pitch*idx:]
+		s := src.Data[src.Pitch*idx:]
+		dp := dst.Pitch << field
+		sp := src.Pitch << field
+		fheight := height - idx*int(dst.Height&1)
+		if dither == DitherDiffusion {
+			ditherDiffusionGo(d, s, dst.Width, fheight, dp, sp, dst.Pack)
+			continue
+		}
+		// columns start on multiples of 8 pixels, keeping bayer phases
+		group := sync.WaitGroup{}
+		splitTiles(&group, threads, dst.Width, fheight, columnBlock, func(x, y, w, h int) {
+			ditherOrderedGo(d[dp*y+x*dst.Pack:], s[sp*y+x*dst.Pack*2:], y, w, h, dp, sp, dst.Pack)
+		})
+		group.Wait()
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	ewaLutSize = 1024 // lut entries per squared pixel
+)
+
+// ewaResizer resizes both dimensions at once using elliptical weighted
+// averages, every output pixel is the weighted sum of source pixels inside
+// an ellipse whose weights only depend on the distance to its center
+type ewaResizer struct {
+	win, hin   int
+	wout, hout int
+	pack       int
+	interlaced bool
+	threads    int
+	sx, sy     float64   // source pixels per output pixel, at least 1
+	rx, ry     float64   // support radius in source pixels
+	radius     float64   // squared normalized support radius
+	lut        []float64 // weights indexed by squared distance
+	border     Border
+	value      [4]byte // constant border value per packed byte
+}
+
+func newEwaResizer(win, hin, wout, hout, pack int, interlaced bool, threads int, filter Filter, border Border, value [4]byte) *ewaResizer {
+	r := &ewaResizer{
+		win:        win,
+		hin:        hin,
+		wout:       wout,
+		hout:       hout,
+		pack:       pack,
+		interlaced: interlaced,
+		threads:    threads,
+		border:     border,
+		value:      value,
+		sx:         math.Max(1, float64(win)/float64(wout)),
+		sy:         math.Max(1, float64(hin)/float64(hout)),
+	}
+	support := getSupport(filter)
+	r.rx = support * r.sx
+	r.ry = support * r.sy
+	r.radius = support * support
+	r.lut = make([]float64, int(math.Ceil(r.radius*ewaLutSize))+1)
+	for i := range r.lut {
+		r.lut[i] = filter.Get(math.Sqrt(float64(i) / ewaLutSize))
+	}
+	return r
+}
+
+func (r *ewaResizer) Scalers(width int) []ScalerInfo {
+	return nil
+}
+
+func (r *ewaResizer) Resize(dst, src []byte, width, height, dp, sp int) {
+	field := bin(r.interlaced)
+	group := sync.WaitGroup{}
+	for i := uint(0); i < 1+field; i++ {
+		idx := int(i)
+		hin := (r.hin + int(field*(1-i))) >> field
+		hout := (r.hout + int(field*(1-i))) >> field
+		splitTiles(&group, r.threads, r.wout, hout, columnBlock, func(x, y, w, h int) {
+			r.resize(dst[dp*idx:], src[sp*idx:], hin, hout, x, x+w, y, y+h, dp<<field, sp<<field)
+		})
+	}
+	group.Wait()
+}
+
+// resize computes output columns x0 to x1 of rows y0 to y1
+func (r *ewaResizer) resize(dst, src []byte, hin, hout, x0, x1, y0, y1, dp, sp int) {
+	xscale := float64(r.win) / float64(r.wout)
+	yscale := float64(hin) / float64(hout)
+	// horizontal distances only depend on the output column, indexed from x0
+	xbegin := make([]int, x1-x0)
+	xdist := make([][]float64, x1-x0)
+	for x := x0; x < x1; x++ {
+		cx := (float64(x)+0.5)*xscale - 0.5
+		begin := int(math.Ceil(cx - r.rx))
+		end := int(math.Floor(cx + r.rx))
+		xbegin[x-x0] = begin
+		for i := begin; i <= end; i++ {
+			d := (float64(i) - cx) / r.sx
+			xdist[x-x0] = append(xdist[x-x0], d*d)
+		}
+	}
+	pk := r.pack
+	sums := make([]float64, pk)
+	for y := y0; y < y1; y++ {
+		cy := (float64(y)+0.5)*yscale - 0.5
+		begin := int(math.Ceil(cy - r.ry))
+		end := int(math.Floor(cy + r.ry))
+		d := dst[dp*y:]
+		for x := x0; x < x1; x++ {
+			for c := range sums {
+				sums[c] = 0
+			}
+			total := float64(0)
+			for j := begin; j <= end; j++ {
+				dy := (float64(j) - cy) / r.sy
+				dy *= dy
+				if dy >= r.radius {
+					continue
+				}
+				sj := getBorderIndex(j, hin, r.border)
+				s := src[sp*max(sj, 0):]
+				for k, dx := range xdist[x-x0] {
+					d2 := dx + dy
+					if d2 >= r.radius {
+						continue
+					}
+					w := r.lut[int(d2*ewaLutSize)]
+					total += w
+					si := getBorderIndex(xbegin[x-x0]+k, r.win, r.border)
+					if sj < 0 || si < 0 {
+						for c := range sums {
+							sums[c] += w * float64(r.value[c])
+						}
+						continue
+					}
+					for c := range sums {
+						sums[c] += w * float64(s[si*pk+c])
+					}
+				}
+			}
+			if total == 0 {
+				// support is too small to reach any source pixel
+				cx := (float64(x)+0.5)*xscale - 0.5
+				si := sp*clip(int(cy+0.5), 0, hin-1) + clip(int(cx+0.5), 0, r.win-1)*pk
+				copy(d[x*pk:x*pk+pk], src[si:si+pk])
+				continue
+			}
+			for c, sum := range sums {
+				d[x*pk+c] = u8(int(math.Floor(sum/total + 0.5)))
+			}
+		}
+	}
+}
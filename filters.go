@@ -5,17 +5,36 @@
 package rez
 
 import (
+	"fmt"
 	"math"
+	"strings"
 )
 
 // Filter is an interpolation filter interface
 // It is used to compute weights for every input pixel
+// Name returns a canonical name which can be given to FilterByName
 type Filter interface {
 	Taps() int
 	Name() string
 	Get(dx float64) float64
 }
 
+// SupportFilter is a Filter with a fractional support
+// Support returns the distance after which Get always returns 0
+// Taps must return Support rounded up
+type SupportFilter interface {
+	Filter
+	Support() float64
+}
+
+// getSupport returns the filter support in pixels
+func getSupport(f Filter) float64 {
+	if sf, ok := f.(SupportFilter); ok {
+		return sf.Support()
+	}
+	return float64(f.Taps())
+}
+
 type bilinear struct{}
 
 func (bilinear) Taps() int    { return 1 }
@@ -35,14 +54,15 @@ func NewBilinearFilter() Filter {
 
 type bicubic struct {
 	a, b, c, d, e, f, g float64
+	bp, cp              float64 // b & c parameters
 }
 
 func (bicubic) Taps() int {
 	return 2
 }
 
-func (bicubic) Name() string {
-	return "bicubic"
+func (f *bicubic) Name() string {
+	return formatFilterName("bicubic", "b", f.bp, "c", f.cp)
 }
 
 func (f *bicubic) Get(x float64) float64 {
@@ -58,7 +78,7 @@ func (f *bicubic) Get(x float64) float64 {
 // customized.
 // For example, the Mitchell-Netravali bicubic filter is b = c = 1/3
 func NewCustomBicubicFilter(b, c float64) Filter {
-	f := &bicubic{}
+	f := &bicubic{bp: b, cp: c}
 	f.a = 1 - b/3
 	f.b = -3 + 2*b + c
 	f.c = 2 - 3*b/2 - c
@@ -79,15 +99,19 @@ type lanczos struct {
 }
 
 func (f lanczos) Taps() int {
-	return int(f.alpha)
+	return int(math.Ceil(f.alpha))
 }
 
-func (lanczos) Name() string {
-	return "lanczos"
+func (f lanczos) Support() float64 {
+	return f.alpha
+}
+
+func (f lanczos) Name() string {
+	return formatFilterName("lanczos", "a", f.alpha)
 }
 
 func (f lanczos) Get(x float64) float64 {
-	if x > f.alpha {
+	if x >= f.alpha {
 		return 0
 	} else if x == 0 {
 		return 1
@@ -101,3 +125,331 @@ func (f lanczos) Get(x float64) float64 {
 func NewLanczosFilter(alpha int) Filter {
 	return lanczos{alpha: float64(alpha)}
 }
+
+// NewCustomLanczosFilter exports a lanczos filter where <alpha> is filter
+// size and can be fractional, like 2.5
+func NewCustomLanczosFilter(alpha float64) Filter {
+	return lanczos{alpha: alpha}
+}
+
+// NewMitchellFilter exports the Mitchell-Netravali bicubic filter
+func NewMitchellFilter() Filter {
+	return NewCustomBicubicFilter(1.0/3, 1.0/3)
+}
+
+// NewCatmullRomFilter exports the Catmull-Rom bicubic filter
+func NewCatmullRomFilter() Filter {
+	return NewCustomBicubicFilter(0, 0.5)
+}
+
+// NewBSplineFilter exports the cubic B-spline filter
+func NewBSplineFilter() Filter {
+	return NewCustomBicubicFilter(1, 0)
+}
+
+type hermite struct{}
+
+func (hermite) Taps() int    { return 1 }
+func (hermite) Name() string { return "hermite" }
+
+func (hermite) Get(x float64) float64 {
+	if x < 1 {
+		return 1 + x*x*(-3+x*2)
+	}
+	return 0
+}
+
+// NewHermiteFilter exports a hermite filter
+func NewHermiteFilter() Filter {
+	return hermite{}
+}
+
+type box struct{}
+
+func (box) Taps() int        { return 1 }
+func (box) Support() float64 { return 0.5 }
+func (box) Name() string     { return "box" }
+
+func (box) Get(x float64) float64 {
+	if x < 0.5 {
+		return 1
+	} else if x == 0.5 {
+		return 0.5
+	}
+	return 0
+}
+
+// NewBoxFilter exports a box filter
+func NewBoxFilter() Filter {
+	return box{}
+}
+
+type gaussian struct {
+	sigma float64
+}
+
+func (f gaussian) Taps() int {
+	return int(math.Ceil(f.sigma * 3))
+}
+
+func (f gaussian) Support() float64 {
+	return f.sigma * 3
+}
+
+func (f gaussian) Name() string {
+	return formatFilterName("gaussian", "sigma", f.sigma)
+}
+
+func (f gaussian) Get(x float64) float64 {
+	if x >= f.sigma*3 {
+		return 0
+	}
+	return math.Exp(-x * x / (2 * f.sigma * f.sigma))
+}
+
+// NewGaussianFilter exports a gaussian filter where <sigma> is the standard
+// deviation, truncated at 3 sigmas
+func NewGaussianFilter(sigma float64) Filter {
+	return gaussian{sigma: sigma}
+}
+
+type spline struct {
+	taps int
+}
+
+func (f spline) Taps() int {
+	return f.taps
+}
+
+func (f spline) Name() string {
+	return fmt.Sprintf("spline%v", f.taps*f.taps*4)
+}
+
+func (f spline) Get(x float64) float64 {
+	switch f.taps {
+	case 2:
+		if x < 1 {
+			return ((x-9.0/5)*x-1.0/5)*x + 1
+		} else if x < 2 {
+			x--
+			return ((-1.0/3*x+4.0/5)*x - 7.0/15) * x
+		}
+	case 3:
+		if x < 1 {
+			return ((13.0/11*x-453.0/209)*x-3.0/209)*x + 1
+		} else if x < 2 {
+			x--
+			return ((-6.0/11*x+270.0/209)*x - 156.0/209) * x
+		} else if x < 3 {
+			x -= 2
+			return ((1.0/11*x-45.0/209)*x + 26.0/209) * x
+		}
+	case 4:
+		if x < 1 {
+			return ((49.0/41*x-6387.0/2911)*x-3.0/2911)*x + 1
+		} else if x < 2 {
+			x--
+			return ((-24.0/41*x+4032.0/2911)*x - 2328.0/2911) * x
+		} else if x < 3 {
+			x -= 2
+			return ((6.0/41*x-1008.0/2911)*x + 582.0/2911) * x
+		} else if x < 4 {
+			x -= 3
+			return ((-1.0/41*x+168.0/2911)*x - 97.0/2911) * x
+		}
+	}
+	return 0
+}
+
+// NewSpline16Filter exports a 4-taps spline filter
+func NewSpline16Filter() Filter {
+	return spline{taps: 2}
+}
+
+// NewSpline36Filter exports a 6-taps spline filter
+func NewSpline36Filter() Filter {
+	return spline{taps: 3}
+}
+
+// NewSpline64Filter exports a 8-taps spline filter
+func NewSpline64Filter() Filter {
+	return spline{taps: 4}
+}
+
+type window int
+
+const (
+	hannWindow window = iota
+	hammingWindow
+	blackmanWindow
+	kaiserWindow
+)
+
+var (
+	windowNames = []string{"hann", "hamming", "blackman", "kaiser"}
+)
+
+type windowedSinc struct {
+	window window
+	alpha  float64
+	beta   float64
+}
+
+func (f windowedSinc) Taps() int {
+	return int(math.Ceil(f.alpha))
+}
+
+func (f windowedSinc) Support() float64 {
+	return f.alpha
+}
+
+func (f windowedSinc) Name() string {
+	if f.window == kaiserWindow {
+		return formatFilterName(windowNames[f.window], "a", f.alpha, "beta", f.beta)
+	}
+	return formatFilterName(windowNames[f.window], "a", f.alpha)
+}
+
+// besselI0 returns the zeroth order modified bessel function of the first kind
+func besselI0(x float64) float64 {
+	sum := 1.0
+	term := 1.0
+	for k := 1; term > sum*1e-16; k++ {
+		v := x / float64(2*k)
+		term *= v * v
+		sum += term
+	}
+	return sum
+}
+
+func (f windowedSinc) Get(x float64) float64 {
+	if x >= f.alpha {
+		return 0
+	} else if x == 0 {
+		return 1
+	}
+	b := x * math.Pi
+	t := x / f.alpha
+	w := float64(0)
+	switch f.window {
+	case hannWindow:
+		w = 0.5 + 0.5*math.Cos(math.Pi*t)
+	case hammingWindow:
+		w = 0.54 + 0.46*math.Cos(math.Pi*t)
+	case blackmanWindow:
+		w = 0.42 + 0.5*math.Cos(math.Pi*t) + 0.08*math.Cos(2*math.Pi*t)
+	case kaiserWindow:
+		w = besselI0(f.beta*math.Sqrt(1-t*t)) / besselI0(f.beta)
+	}
+	return math.Sin(b) / b * w
+}
+
+// NewHannFilter exports a hann-windowed sinc filter where <alpha> is filter
+// size
+func NewHannFilter(alpha int) Filter {
+	return windowedSinc{window: hannWindow, alpha: float64(alpha)}
+}
+
+// NewHammingFilter exports a hamming-windowed sinc filter where <alpha> is
+// filter size
+func NewHammingFilter(alpha int) Filter {
+	return windowedSinc{window: hammingWindow, alpha: float64(alpha)}
+}
+
+// NewBlackmanFilter exports a blackman-windowed sinc filter where <alpha> is
+// filter size
+func NewBlackmanFilter(alpha int) Filter {
+	return windowedSinc{window: blackmanWindow, alpha: float64(alpha)}
+}
+
+// NewKaiserFilter exports a kaiser-windowed sinc filter where <alpha> is
+// filter size and <beta> the window shape
+func NewKaiserFilter(alpha int, beta float64) Filter {
+	return windowedSinc{window: kaiserWindow, alpha: float64(alpha), beta: beta}
+}
+
+type blur struct {
+	filter Filter
+	blur   float64
+}
+
+func (f blur) Taps() int {
+	return int(math.Ceil(f.Support()))
+}
+
+func (f blur) Support() float64 {
+	return getSupport(f.filter) * f.blur
+}
+
+func (f blur) Name() string {
+	name := f.filter.Name()
+	sep := ":"
+	if strings.Contains(name, sep) {
+		sep = ","
+	}
+	return name + sep + formatFilterName("blur", "blur", f.blur)[len("blur:"):]
+}
+
+func (f blur) Get(x float64) float64 {
+	return f.filter.Get(x / f.blur)
+}
+
+// NewBlurFilter exports a filter stretching <filter> support by <blur>
+// Values above 1 blur, values below 1 sharpen
+func NewBlurFilter(filter Filter, value float64) Filter {
+	if value == 1 {
+		return filter
+	}
+	return blur{filter: filter, blur: value}
+}
+
+var (
+	// jincZeros are the first zeros of jinc(x)
+	jincZeros = []float64{
+		1.2196698912665045,
+		2.2331305943815286,
+		3.2383154841662362,
+		4.2410628637960699,
+		5.2427643768701817,
+	}
+)
+
+type jinc struct {
+	lobes  int
+	radius float64
+}
+
+func (f jinc) Taps() int {
+	return int(math.Ceil(f.radius))
+}
+
+func (f jinc) Support() float64 {
+	return f.radius
+}
+
+func (f jinc) Name() string {
+	return formatFilterName("jinc", "lobes", float64(f.lobes))
+}
+
+func jincValue(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	b := x * math.Pi
+	return 2 * math.J1(b) / b
+}
+
+func (f jinc) Get(x float64) float64 {
+	if x >= f.radius {
+		return 0
+	}
+	return jincValue(x) * jincValue(x*jincZeros[0]/f.radius)
+}
+
+// NewJincFilter exports a jinc-windowed jinc filter, also known as EWA
+// lanczos, where <lobes> is the number of lobes between 1 & 5
+// It is meant to be used with SamplingEWA
+func NewJincFilter(lobes int) Filter {
+	lobes = clip(lobes, 1, len(jincZeros))
+	return jinc{lobes: lobes, radius: jincZeros[lobes-1]}
+}
@@ -28,8 +28,11 @@ func h8scale2Go(dst, src []byte, cof, off []int16,
 func v8scale2Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -41,6 +44,48 @@ func v8scale2Go(dst, src []byte, cof, off []int16,
 	}
 }
 
+func h16scale2Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[2:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale2Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[2:]
+		di += dp
+	}
+}
+
 func h8scale4Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
@@ -65,8 +110,11 @@ func h8scale4Go(dst, src []byte, cof, off []int16,
 func v8scale4Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -80,6 +128,52 @@ func v8scale4Go(dst, src []byte, cof, off []int16,
 	}
 }
 
+func h16scale4Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1]) +
+				int(get16(s, int(xoff)+2, true))*int(c[2]) +
+				int(get16(s, int(xoff)+3, true))*int(c[3])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[4:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale4Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1]) +
+				int(get16(src[sp*2:], x, true))*int(cof[2]) +
+				int(get16(src[sp*3:], x, true))*int(cof[3])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[4:]
+		di += dp
+	}
+}
+
 func h8scale6Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
@@ -106,8 +200,11 @@ func h8scale6Go(dst, src []byte, cof, off []int16,
 func v8scale6Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -123,6 +220,56 @@ func v8scale6Go(dst, src []byte, cof, off []int16,
 	}
 }
 
+func h16scale6Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1]) +
+				int(get16(s, int(xoff)+2, true))*int(c[2]) +
+				int(get16(s, int(xoff)+3, true))*int(c[3]) +
+				int(get16(s, int(xoff)+4, true))*int(c[4]) +
+				int(get16(s, int(xoff)+5, true))*int(c[5])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[6:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale6Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1]) +
+				int(get16(src[sp*2:], x, true))*int(cof[2]) +
+				int(get16(src[sp*3:], x, true))*int(cof[3]) +
+				int(get16(src[sp*4:], x, true))*int(cof[4]) +
+				int(get16(src[sp*5:], x, true))*int(cof[5])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[6:]
+		di += dp
+	}
+}
+
 func h8scale8Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
@@ -151,8 +298,11 @@ func h8scale8Go(dst, src []byte, cof, off []int16,
 func v8scale8Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -170,6 +320,60 @@ func v8scale8Go(dst, src []byte, cof, off []int16,
 	}
 }
 
+func h16scale8Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1]) +
+				int(get16(s, int(xoff)+2, true))*int(c[2]) +
+				int(get16(s, int(xoff)+3, true))*int(c[3]) +
+				int(get16(s, int(xoff)+4, true))*int(c[4]) +
+				int(get16(s, int(xoff)+5, true))*int(c[5]) +
+				int(get16(s, int(xoff)+6, true))*int(c[6]) +
+				int(get16(s, int(xoff)+7, true))*int(c[7])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[8:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale8Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1]) +
+				int(get16(src[sp*2:], x, true))*int(cof[2]) +
+				int(get16(src[sp*3:], x, true))*int(cof[3]) +
+				int(get16(src[sp*4:], x, true))*int(cof[4]) +
+				int(get16(src[sp*5:], x, true))*int(cof[5]) +
+				int(get16(src[sp*6:], x, true))*int(cof[6]) +
+				int(get16(src[sp*7:], x, true))*int(cof[7])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[8:]
+		di += dp
+	}
+}
+
 func h8scale10Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
@@ -200,8 +404,11 @@ func h8scale10Go(dst, src []byte, cof, off []int16,
 func v8scale10Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -221,6 +428,64 @@ func v8scale10Go(dst, src []byte, cof, off []int16,
 	}
 }
 
+func h16scale10Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1]) +
+				int(get16(s, int(xoff)+2, true))*int(c[2]) +
+				int(get16(s, int(xoff)+3, true))*int(c[3]) +
+				int(get16(s, int(xoff)+4, true))*int(c[4]) +
+				int(get16(s, int(xoff)+5, true))*int(c[5]) +
+				int(get16(s, int(xoff)+6, true))*int(c[6]) +
+				int(get16(s, int(xoff)+7, true))*int(c[7]) +
+				int(get16(s, int(xoff)+8, true))*int(c[8]) +
+				int(get16(s, int(xoff)+9, true))*int(c[9])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[10:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale10Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1]) +
+				int(get16(src[sp*2:], x, true))*int(cof[2]) +
+				int(get16(src[sp*3:], x, true))*int(cof[3]) +
+				int(get16(src[sp*4:], x, true))*int(cof[4]) +
+				int(get16(src[sp*5:], x, true))*int(cof[5]) +
+				int(get16(src[sp*6:], x, true))*int(cof[6]) +
+				int(get16(src[sp*7:], x, true))*int(cof[7]) +
+				int(get16(src[sp*8:], x, true))*int(cof[8]) +
+				int(get16(src[sp*9:], x, true))*int(cof[9])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[10:]
+		di += dp
+	}
+}
+
 func h8scale12Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
@@ -253,8 +518,11 @@ func h8scale12Go(dst, src []byte, cof, off []int16,
 func v8scale12Go(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		d := dst[di:]
 		for x := range d[:width] {
 			pix := int(src[sp*0+x])*int(cof[0]) +
@@ -275,3 +543,65 @@ func v8scale12Go(dst, src []byte, cof, off []int16,
 		di += dp
 	}
 }
+
+func h16scale12Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := int(get16(s, int(xoff)+0, true))*int(c[0]) +
+				int(get16(s, int(xoff)+1, true))*int(c[1]) +
+				int(get16(s, int(xoff)+2, true))*int(c[2]) +
+				int(get16(s, int(xoff)+3, true))*int(c[3]) +
+				int(get16(s, int(xoff)+4, true))*int(c[4]) +
+				int(get16(s, int(xoff)+5, true))*int(c[5]) +
+				int(get16(s, int(xoff)+6, true))*int(c[6]) +
+				int(get16(s, int(xoff)+7, true))*int(c[7]) +
+				int(get16(s, int(xoff)+8, true))*int(c[8]) +
+				int(get16(s, int(xoff)+9, true))*int(c[9]) +
+				int(get16(s, int(xoff)+10, true))*int(c[10]) +
+				int(get16(s, int(xoff)+11, true))*int(c[11])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[12:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v16scale12Go(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := int(get16(src[sp*0:], x, true))*int(cof[0]) +
+				int(get16(src[sp*1:], x, true))*int(cof[1]) +
+				int(get16(src[sp*2:], x, true))*int(cof[2]) +
+				int(get16(src[sp*3:], x, true))*int(cof[3]) +
+				int(get16(src[sp*4:], x, true))*int(cof[4]) +
+				int(get16(src[sp*5:], x, true))*int(cof[5]) +
+				int(get16(src[sp*6:], x, true))*int(cof[6]) +
+				int(get16(src[sp*7:], x, true))*int(cof[7]) +
+				int(get16(src[sp*8:], x, true))*int(cof[8]) +
+				int(get16(src[sp*9:], x, true))*int(cof[9]) +
+				int(get16(src[sp*10:], x, true))*int(cof[10]) +
+				int(get16(src[sp*11:], x, true))*int(cof[11])
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[12:]
+		di += dp
+	}
+}
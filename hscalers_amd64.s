@@ -290,7 +290,7 @@ end_9:
 		JNE	yloop_5
 		RET
 
-TEXT ·h8scale8Amd64(SB),4,$40-136
+TEXT ·h8scale6Amd64(SB),4,$40-136
 		MOVQ	dp+120(FP), BX
 		MOVQ	width+104(FP), CX
 		MOVQ	CX, DX
@@ -315,6 +315,238 @@ yloop_10:
 		ORQ	CX, CX
 		JE	nosimdloop_13
 simdloop_11:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X1
+		PINSRW	$1, (SI)(R9*1), X1
+		PINSRW	$2, (SI)(R10*1), X1
+		PINSRW	$3, (SI)(R11*1), X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X2
+		PINSRW	$1, (SI)(R9*1), X2
+		PINSRW	$2, (SI)(R10*1), X2
+		PINSRW	$3, (SI)(R11*1), X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X3
+		PINSRW	$1, (SI)(R9*1), X3
+		PINSRW	$2, (SI)(R10*1), X3
+		PINSRW	$3, (SI)(R11*1), X3
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		PUNPCKLBW	X15, X1
+		PMADDWL	16(BP), X1
+		PUNPCKLBW	X15, X2
+		PMADDWL	32(BP), X2
+		PUNPCKLBW	X15, X3
+		PMADDWL	48(BP), X3
+		ADDQ	$64, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$32, BX
+		PADDL	X14, X0
+		PADDL	X14, X1
+		PADDL	X14, X2
+		PADDL	X14, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_11
+nosimdloop_13:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_14
+asmloop_12:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	$12, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_12
+end_14:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_10
+		RET
+
+TEXT ·h8scale8Amd64(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$4, CX
+		ANDQ	$15, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_15:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_18
+simdloop_16:
 		MOVWQSX	(BX), AX
 		MOVQ	(SI)(AX*1), X0
 		MOVWQSX	2(BX), DX
@@ -443,12 +675,12 @@ simdloop_11:
 		MOVOU	X0, (DI)
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	simdloop_11
-nosimdloop_13:
+		JNE	simdloop_16
+nosimdloop_18:
 		MOVQ	asmroll+-16(SP), CX
 		ORQ	CX, CX
-		JE	end_14
-asmloop_12:
+		JE	end_19
+asmloop_17:
 		MOVWQSX	(BX), DX
 		MOVBQZX	(SI)(DX*1), AX
 		MOVWQSX	(BP), DX
@@ -499,14 +731,14 @@ asmloop_12:
 		MOVB	AL, (DI)
 		ADDQ	$1, DI
 		SUBQ	$1, CX
-		JNE	asmloop_12
-end_14:
+		JNE	asmloop_17
+end_19:
 		MOVQ	srcref+-24(SP), SI
 		ADDQ	dstoff+-32(SP), DI
 		ADDQ	sp+128(FP), SI
 		MOVQ	SI, srcref+-24(SP)
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_10
+		JNE	yloop_15
 		RET
 
 TEXT ·h8scale10Amd64(SB),4,$40-136
@@ -527,13 +759,13 @@ TEXT ·h8scale10Amd64(SB),4,$40-136
 		MOVO	hbits_1<>(SB), X14
 		MOVQ	src+24(FP), SI
 		MOVQ	dst+0(FP), DI
-yloop_15:
+yloop_20:
 		MOVQ	off+72(FP), BX
 		MOVQ	cof+48(FP), BP
 		MOVQ	simdroll+-8(SP), CX
 		ORQ	CX, CX
-		JE	nosimdloop_18
-simdloop_16:
+		JE	nosimdloop_23
+simdloop_21:
 		MOVWQSX	(BX), R8
 		MOVWQSX	2(BX), R9
 		MOVWQSX	4(BX), R10
@@ -777,12 +1009,12 @@ simdloop_16:
 		MOVOU	X0, (DI)
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	simdloop_16
-nosimdloop_18:
+		JNE	simdloop_21
+nosimdloop_23:
 		MOVQ	asmroll+-16(SP), CX
 		ORQ	CX, CX
-		JE	end_19
-asmloop_17:
+		JE	end_24
+asmloop_22:
 		MOVWQSX	(BX), DX
 		MOVBQZX	(SI)(DX*1), AX
 		MOVWQSX	(BP), DX
@@ -843,14 +1075,14 @@ asmloop_17:
 		MOVB	AL, (DI)
 		ADDQ	$1, DI
 		SUBQ	$1, CX
-		JNE	asmloop_17
-end_19:
+		JNE	asmloop_22
+end_24:
 		MOVQ	srcref+-24(SP), SI
 		ADDQ	dstoff+-32(SP), DI
 		ADDQ	sp+128(FP), SI
 		MOVQ	SI, srcref+-24(SP)
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_15
+		JNE	yloop_20
 		RET
 
 TEXT ·h8scale12Amd64(SB),4,$40-136
@@ -871,13 +1103,13 @@ TEXT ·h8scale12Amd64(SB),4,$40-136
 		MOVO	hbits_1<>(SB), X14
 		MOVQ	src+24(FP), SI
 		MOVQ	dst+0(FP), DI
-yloop_20:
+yloop_25:
 		MOVQ	off+72(FP), BX
 		MOVQ	cof+48(FP), BP
 		MOVQ	simdroll+-8(SP), CX
 		ORQ	CX, CX
-		JE	nosimdloop_23
-simdloop_21:
+		JE	nosimdloop_28
+simdloop_26:
 		MOVWQSX	(BX), R8
 		MOVWQSX	2(BX), R9
 		MOVWQSX	4(BX), R10
@@ -1167,12 +1399,12 @@ simdloop_21:
 		MOVOU	X0, (DI)
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	simdloop_21
-nosimdloop_23:
+		JNE	simdloop_26
+nosimdloop_28:
 		MOVQ	asmroll+-16(SP), CX
 		ORQ	CX, CX
-		JE	end_24
-asmloop_22:
+		JE	end_29
+asmloop_27:
 		MOVWQSX	(BX), DX
 		MOVBQZX	(SI)(DX*1), AX
 		MOVWQSX	(BP), DX
@@ -1243,17 +1475,17 @@ asmloop_22:
 		MOVB	AL, (DI)
 		ADDQ	$1, DI
 		SUBQ	$1, CX
-		JNE	asmloop_22
-end_24:
+		JNE	asmloop_27
+end_29:
 		MOVQ	srcref+-24(SP), SI
 		ADDQ	dstoff+-32(SP), DI
 		ADDQ	sp+128(FP), SI
 		MOVQ	SI, srcref+-24(SP)
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_20
+		JNE	yloop_25
 		RET
 
-TEXT ·h8scaleNAmd64(SB),4,$64-136
+TEXT ·h8scale14Amd64(SB),4,$40-136
 		MOVQ	dp+120(FP), BX
 		MOVQ	width+104(FP), CX
 		MOVQ	CX, DX
@@ -1267,18 +1499,17 @@ TEXT ·h8scaleNAmd64(SB),4,$64-136
 		MOVQ	AX, srcref+-24(SP)
 		MOVQ	taps+96(FP), DX
 		SUBQ	$2, DX
-		MOVQ	DX, inner+-64(SP)
 		PXOR	X15, X15
 		MOVO	hbits_1<>(SB), X14
 		MOVQ	src+24(FP), SI
 		MOVQ	dst+0(FP), DI
-yloop_25:
+yloop_30:
 		MOVQ	off+72(FP), BX
 		MOVQ	cof+48(FP), BP
 		MOVQ	simdroll+-8(SP), CX
 		ORQ	CX, CX
-		JE	nosimdloop_28
-simdloop_26:
+		JE	nosimdloop_33
+simdloop_31:
 		MOVWQSX	(BX), R8
 		MOVWQSX	2(BX), R9
 		MOVWQSX	4(BX), R10
@@ -1321,9 +1552,6 @@ simdloop_26:
 		PUNPCKLBW	X15, X3
 		PMADDWL	48(BP), X3
 		ADDQ	$64, BP
-		MOVQ	DI, dstref+-48(SP)
-		MOVQ	inner+-64(SP), DI
-loop_30:
 		MOVWQSX	(BX), R8
 		MOVWQSX	2(BX), R9
 		MOVWQSX	4(BX), R10
@@ -1370,71 +1598,8868 @@ loop_30:
 		PADDL	X5, X1
 		PADDL	X6, X2
 		PADDL	X7, X3
-		SUBQ	$2, DI
-		JNE	loop_30
-		MOVQ	dstref+-48(SP), DI
-		MOVQ	taps+96(FP), AX
-		SUBQ	AX, SI
-		ADDQ	$32, BX
-		PADDL	X14, X0
-		PADDL	X14, X1
-		PADDL	X14, X2
-		PADDL	X14, X3
-		PSRAL	$14, X0
-		PSRAL	$14, X1
-		PSRAL	$14, X2
-		PSRAL	$14, X3
-		PACKSSLW	X1, X0
-		PACKSSLW	X3, X2
-		PACKUSWB	X2, X0
-		MOVOU	X0, (DI)
-		ADDQ	$16, DI
-		SUBQ	$1, CX
-		JNE	simdloop_26
-nosimdloop_28:
-		MOVQ	asmroll+-16(SP), CX
-		ORQ	CX, CX
-		JE	end_29
-asmloop_27:
-		MOVWQSX	(BX), DX
-		MOVBQZX	(SI)(DX*1), AX
-		MOVWQSX	(BP), DX
-		IMULQ	DX
-		MOVQ	AX, sum+-40(SP)
-		MOVQ	inner+-64(SP), AX
-		MOVQ	AX, count+-56(SP)
-loop_31:
-		MOVWQSX	(BX), DX
-		MOVBQZX	1(SI)(DX*1), AX
-		MOVWQSX	2(BP), DX
-		IMULQ	DX
-		ADDQ	$1, SI
-		ADDQ	$2, BP
-		ADDQ	AX, sum+-40(SP)
-		SUBQ	$1, count+-56(SP)
-		JNE	loop_31
-		MOVWQSX	(BX), DX
-		MOVBQZX	1(SI)(DX*1), AX
-		MOVWQSX	2(BP), DX
-		IMULQ	DX
-		ADDQ	$4, BP
-		SUBQ	inner+-64(SP), SI
-		ADDQ	sum+-40(SP), AX
-		ADDQ	$8192, AX
-		CMOVQLT	zero_0<>(SB), AX
-		SHRQ	$14, AX
-		CMPQ	u8max_2<>(SB), AX
-		CMOVQLT	u8max_2<>(SB), AX
-		ADDQ	$2, BX
-		MOVB	AL, (DI)
-		ADDQ	$1, DI
-		SUBQ	$1, CX
-		JNE	asmloop_27
-end_29:
-		MOVQ	srcref+-24(SP), SI
-		ADDQ	dstoff+-32(SP), DI
-		ADDQ	sp+128(FP), SI
-		MOVQ	SI, srcref+-24(SP)
-		SUBQ	$1, height+112(FP)
-		JNE	yloop_25
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$32, BX
+		PADDL	X14, X0
+		PADDL	X14, X1
+		PADDL	X14, X2
+		PADDL	X14, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_31
+nosimdloop_33:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_34
+asmloop_32:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	10(SI)(DX*1), AX
+		MOVWQSX	20(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	11(SI)(DX*1), AX
+		MOVWQSX	22(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	12(SI)(DX*1), AX
+		MOVWQSX	24(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	13(SI)(DX*1), AX
+		MOVWQSX	26(BP), DX
+		IMULQ	DX
+		ADDQ	$28, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_32
+end_34:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_30
+		RET
+
+TEXT ·h8scale16Amd64(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$4, CX
+		ANDQ	$15, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_35:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_38
+simdloop_36:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X1
+		PINSRW	$1, (SI)(R9*1), X1
+		PINSRW	$2, (SI)(R10*1), X1
+		PINSRW	$3, (SI)(R11*1), X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X2
+		PINSRW	$1, (SI)(R9*1), X2
+		PINSRW	$2, (SI)(R10*1), X2
+		PINSRW	$3, (SI)(R11*1), X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X3
+		PINSRW	$1, (SI)(R9*1), X3
+		PINSRW	$2, (SI)(R10*1), X3
+		PINSRW	$3, (SI)(R11*1), X3
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		PUNPCKLBW	X15, X1
+		PMADDWL	16(BP), X1
+		PUNPCKLBW	X15, X2
+		PMADDWL	32(BP), X2
+		PUNPCKLBW	X15, X3
+		PMADDWL	48(BP), X3
+		ADDQ	$64, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$32, BX
+		PADDL	X14, X0
+		PADDL	X14, X1
+		PADDL	X14, X2
+		PADDL	X14, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_36
+nosimdloop_38:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_39
+asmloop_37:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	10(SI)(DX*1), AX
+		MOVWQSX	20(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	11(SI)(DX*1), AX
+		MOVWQSX	22(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	12(SI)(DX*1), AX
+		MOVWQSX	24(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	13(SI)(DX*1), AX
+		MOVWQSX	26(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	14(SI)(DX*1), AX
+		MOVWQSX	28(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	15(SI)(DX*1), AX
+		MOVWQSX	30(BP), DX
+		IMULQ	DX
+		ADDQ	$32, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_37
+end_39:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_35
+		RET
+
+TEXT ·h8scaleNAmd64(SB),4,$64-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$4, CX
+		ANDQ	$15, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		MOVQ	DX, inner+-64(SP)
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_40:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_43
+simdloop_41:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X1
+		PINSRW	$1, (SI)(R9*1), X1
+		PINSRW	$2, (SI)(R10*1), X1
+		PINSRW	$3, (SI)(R11*1), X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X2
+		PINSRW	$1, (SI)(R9*1), X2
+		PINSRW	$2, (SI)(R10*1), X2
+		PINSRW	$3, (SI)(R11*1), X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X3
+		PINSRW	$1, (SI)(R9*1), X3
+		PINSRW	$2, (SI)(R10*1), X3
+		PINSRW	$3, (SI)(R11*1), X3
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		PUNPCKLBW	X15, X1
+		PMADDWL	16(BP), X1
+		PUNPCKLBW	X15, X2
+		PMADDWL	32(BP), X2
+		PUNPCKLBW	X15, X3
+		PMADDWL	48(BP), X3
+		ADDQ	$64, BP
+		MOVQ	DI, dstref+-48(SP)
+		MOVQ	inner+-64(SP), DI
+loop_45:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X4
+		PINSRW	$1, (SI)(R9*1), X4
+		PINSRW	$2, (SI)(R10*1), X4
+		PINSRW	$3, (SI)(R11*1), X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		PINSRW	$0, (SI)(R8*1), X5
+		PINSRW	$1, (SI)(R9*1), X5
+		PINSRW	$2, (SI)(R10*1), X5
+		PINSRW	$3, (SI)(R11*1), X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		PINSRW	$0, (SI)(R8*1), X6
+		PINSRW	$1, (SI)(R9*1), X6
+		PINSRW	$2, (SI)(R10*1), X6
+		PINSRW	$3, (SI)(R11*1), X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		PINSRW	$0, (SI)(R8*1), X7
+		PINSRW	$1, (SI)(R9*1), X7
+		PINSRW	$2, (SI)(R10*1), X7
+		PINSRW	$3, (SI)(R11*1), X7
+		ADDQ	$2, SI
+		PUNPCKLBW	X15, X4
+		PMADDWL	(BP), X4
+		PUNPCKLBW	X15, X5
+		PMADDWL	16(BP), X5
+		PUNPCKLBW	X15, X6
+		PMADDWL	32(BP), X6
+		PUNPCKLBW	X15, X7
+		PMADDWL	48(BP), X7
+		ADDQ	$64, BP
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		SUBQ	$2, DI
+		JNE	loop_45
+		MOVQ	dstref+-48(SP), DI
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$32, BX
+		PADDL	X14, X0
+		PADDL	X14, X1
+		PADDL	X14, X2
+		PADDL	X14, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_41
+nosimdloop_43:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_44
+asmloop_42:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVQ	inner+-64(SP), AX
+		MOVQ	AX, count+-56(SP)
+loop_46:
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$1, SI
+		ADDQ	$2, BP
+		ADDQ	AX, sum+-40(SP)
+		SUBQ	$1, count+-56(SP)
+		JNE	loop_46
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$4, BP
+		SUBQ	inner+-64(SP), SI
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_42
+end_44:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_40
+		RET
+
+TEXT ·h8p4scale2Amd64(SB),4,$16-136
+		MOVQ	width+104(FP), CX
+		ORQ	CX, CX
+		JE	end_47
+		MOVQ	dp+120(FP), BX
+		SHLQ	$2, CX
+		SUBQ	CX, BX
+		MOVQ	BX, dstoff+-16(SP)
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		MOVQ	dst+0(FP), DI
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+yloop_48:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	width+104(FP), CX
+xloop_49:
+		MOVWQSX	(BX), AX
+		ADDQ	SI, AX
+		MOVL	(AX), X0
+		MOVL	4(AX), X1
+		PUNPCKLBW	X1, X0
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		ADDQ	$16, BP
+		ADDQ	$2, BX
+		PADDL	X14, X0
+		PSRAL	$14, X0
+		PACKSSLW	X0, X0
+		PACKUSWB	X0, X0
+		MOVL	X0, (DI)
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	xloop_49
+		MOVQ	srcref+-8(SP), SI
+		ADDQ	dstoff+-16(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_48
+end_47:
+		RET
+
+TEXT ·h8p4scale4Amd64(SB),4,$16-136
+		MOVQ	width+104(FP), CX
+		ORQ	CX, CX
+		JE	end_50
+		MOVQ	dp+120(FP), BX
+		SHLQ	$2, CX
+		SUBQ	CX, BX
+		MOVQ	BX, dstoff+-16(SP)
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		MOVQ	dst+0(FP), DI
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+yloop_51:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	width+104(FP), CX
+xloop_52:
+		MOVWQSX	(BX), AX
+		ADDQ	SI, AX
+		MOVL	(AX), X0
+		MOVL	4(AX), X1
+		PUNPCKLBW	X1, X0
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		MOVL	8(AX), X2
+		MOVL	12(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	16(BP), X2
+		PADDL	X2, X0
+		ADDQ	$32, BP
+		ADDQ	$2, BX
+		PADDL	X14, X0
+		PSRAL	$14, X0
+		PACKSSLW	X0, X0
+		PACKUSWB	X0, X0
+		MOVL	X0, (DI)
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	xloop_52
+		MOVQ	srcref+-8(SP), SI
+		ADDQ	dstoff+-16(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_51
+end_50:
+		RET
+
+TEXT ·h8p4scale6Amd64(SB),4,$16-136
+		MOVQ	width+104(FP), CX
+		ORQ	CX, CX
+		JE	end_53
+		MOVQ	dp+120(FP), BX
+		SHLQ	$2, CX
+		SUBQ	CX, BX
+		MOVQ	BX, dstoff+-16(SP)
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		MOVQ	dst+0(FP), DI
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+yloop_54:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	width+104(FP), CX
+xloop_55:
+		MOVWQSX	(BX), AX
+		ADDQ	SI, AX
+		MOVL	(AX), X0
+		MOVL	4(AX), X1
+		PUNPCKLBW	X1, X0
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		MOVL	8(AX), X2
+		MOVL	12(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	16(BP), X2
+		PADDL	X2, X0
+		MOVL	16(AX), X2
+		MOVL	20(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	32(BP), X2
+		PADDL	X2, X0
+		ADDQ	$48, BP
+		ADDQ	$2, BX
+		PADDL	X14, X0
+		PSRAL	$14, X0
+		PACKSSLW	X0, X0
+		PACKUSWB	X0, X0
+		MOVL	X0, (DI)
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	xloop_55
+		MOVQ	srcref+-8(SP), SI
+		ADDQ	dstoff+-16(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_54
+end_53:
+		RET
+
+TEXT ·h8p4scale8Amd64(SB),4,$16-136
+		MOVQ	width+104(FP), CX
+		ORQ	CX, CX
+		JE	end_56
+		MOVQ	dp+120(FP), BX
+		SHLQ	$2, CX
+		SUBQ	CX, BX
+		MOVQ	BX, dstoff+-16(SP)
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		MOVQ	dst+0(FP), DI
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+yloop_57:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	width+104(FP), CX
+xloop_58:
+		MOVWQSX	(BX), AX
+		ADDQ	SI, AX
+		MOVL	(AX), X0
+		MOVL	4(AX), X1
+		PUNPCKLBW	X1, X0
+		PUNPCKLBW	X15, X0
+		PMADDWL	(BP), X0
+		MOVL	8(AX), X2
+		MOVL	12(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	16(BP), X2
+		PADDL	X2, X0
+		MOVL	16(AX), X2
+		MOVL	20(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	32(BP), X2
+		PADDL	X2, X0
+		MOVL	24(AX), X2
+		MOVL	28(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	48(BP), X2
+		PADDL	X2, X0
+		ADDQ	$64, BP
+		ADDQ	$2, BX
+		PADDL	X14, X0
+		PSRAL	$14, X0
+		PACKSSLW	X0, X0
+		PACKUSWB	X0, X0
+		MOVL	X0, (DI)
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	xloop_58
+		MOVQ	srcref+-8(SP), SI
+		ADDQ	dstoff+-16(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_57
+end_56:
+		RET
+
+TEXT ·h8p4scaleNAmd64(SB),4,$16-136
+		MOVQ	width+104(FP), CX
+		ORQ	CX, CX
+		JE	end_59
+		MOVQ	dp+120(FP), BX
+		SHLQ	$2, CX
+		SUBQ	CX, BX
+		MOVQ	BX, dstoff+-16(SP)
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		MOVQ	dst+0(FP), DI
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+yloop_60:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	width+104(FP), CX
+xloop_61:
+		MOVWQSX	(BX), AX
+		ADDQ	SI, AX
+		PXOR	X0, X0
+		MOVQ	taps+96(FP), DX
+		SHRQ	$1, DX
+loop_62:
+		MOVL	(AX), X2
+		MOVL	4(AX), X3
+		PUNPCKLBW	X3, X2
+		PUNPCKLBW	X15, X2
+		PMADDWL	(BP), X2
+		PADDL	X2, X0
+		ADDQ	$8, AX
+		ADDQ	$16, BP
+		SUBQ	$1, DX
+		JNE	loop_62
+		ADDQ	$2, BX
+		PADDL	X14, X0
+		PSRAL	$14, X0
+		PACKSSLW	X0, X0
+		PACKUSWB	X0, X0
+		MOVL	X0, (DI)
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	xloop_61
+		MOVQ	srcref+-8(SP), SI
+		ADDQ	dstoff+-16(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-8(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_60
+end_59:
+		RET
+
+TEXT ·h8scale2Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_63:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_66
+simdloop_64:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		VINSERTI128	$1, X4, Y0, Y0
+		VINSERTI128	$1, X5, Y1, Y1
+		VINSERTI128	$1, X6, Y2, Y2
+		VINSERTI128	$1, X7, Y3, Y3
+		ADDQ	$64, BX
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_64
+nosimdloop_66:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_67
+asmloop_65:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$4, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_65
+end_67:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_63
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale4Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_68:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_71
+simdloop_69:
+		MOVWQSX	(BX), AX
+		MOVWQSX	2(BX), DX
+		VMOVD	(SI)(AX*1), X0
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	4(BX), AX
+		MOVWQSX	6(BX), DX
+		VMOVD	(SI)(AX*1), X1
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X0, X0
+		VPUNPCKLDQ	X9, X1, X1
+		MOVWQSX	8(BX), AX
+		MOVWQSX	10(BX), DX
+		VMOVD	(SI)(AX*1), X2
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	12(BX), AX
+		MOVWQSX	14(BX), DX
+		VMOVD	(SI)(AX*1), X3
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X2, X2
+		VPUNPCKLDQ	X9, X3, X3
+		MOVWQSX	32(BX), AX
+		MOVWQSX	34(BX), DX
+		VMOVD	(SI)(AX*1), X4
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	36(BX), AX
+		MOVWQSX	38(BX), DX
+		VMOVD	(SI)(AX*1), X5
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X4, X4
+		VPUNPCKLDQ	X9, X5, X5
+		MOVWQSX	40(BX), AX
+		MOVWQSX	42(BX), DX
+		VMOVD	(SI)(AX*1), X6
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	44(BX), AX
+		MOVWQSX	46(BX), DX
+		VMOVD	(SI)(AX*1), X7
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X6, X6
+		VPUNPCKLDQ	X9, X7, X7
+		VINSERTI128	$1, X4, Y0, Y0
+		VINSERTI128	$1, X5, Y1, Y1
+		VINSERTI128	$1, X6, Y2, Y2
+		VINSERTI128	$1, X7, Y3, Y3
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		VMOVDQA	Y0, Y10
+		VMOVDQA	Y2, Y11
+		VSHUFPS	$221, Y1, Y10, Y10
+		VSHUFPS	$221, Y3, Y11, Y11
+		VSHUFPS	$136, Y1, Y0, Y0
+		VSHUFPS	$136, Y3, Y2, Y2
+		VPADDD	Y10, Y0, Y0
+		VPADDD	Y11, Y2, Y2
+		MOVWQSX	16(BX), AX
+		MOVWQSX	18(BX), DX
+		VMOVD	(SI)(AX*1), X4
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	20(BX), AX
+		MOVWQSX	22(BX), DX
+		VMOVD	(SI)(AX*1), X5
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X4, X4
+		VPUNPCKLDQ	X9, X5, X5
+		MOVWQSX	24(BX), AX
+		MOVWQSX	26(BX), DX
+		VMOVD	(SI)(AX*1), X6
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	28(BX), AX
+		MOVWQSX	30(BX), DX
+		VMOVD	(SI)(AX*1), X7
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X6, X6
+		VPUNPCKLDQ	X9, X7, X7
+		MOVWQSX	48(BX), AX
+		MOVWQSX	50(BX), DX
+		VMOVD	(SI)(AX*1), X10
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	52(BX), AX
+		MOVWQSX	54(BX), DX
+		VMOVD	(SI)(AX*1), X11
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X10, X10
+		VPUNPCKLDQ	X9, X11, X11
+		MOVWQSX	56(BX), AX
+		MOVWQSX	58(BX), DX
+		VMOVD	(SI)(AX*1), X12
+		VMOVD	(SI)(DX*1), X8
+		MOVWQSX	60(BX), AX
+		MOVWQSX	62(BX), DX
+		VMOVD	(SI)(AX*1), X13
+		VMOVD	(SI)(DX*1), X9
+		VPUNPCKLDQ	X8, X12, X12
+		VPUNPCKLDQ	X9, X13, X13
+		VINSERTI128	$1, X10, Y4, Y4
+		VINSERTI128	$1, X11, Y5, Y5
+		VINSERTI128	$1, X12, Y6, Y6
+		VINSERTI128	$1, X13, Y7, Y7
+		ADDQ	$64, BX
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	128(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	160(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	192(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	224(BP), Y7, Y7
+		VMOVDQA	Y4, Y10
+		VMOVDQA	Y6, Y11
+		VSHUFPS	$221, Y5, Y10, Y10
+		VSHUFPS	$221, Y7, Y11, Y11
+		VSHUFPS	$136, Y5, Y4, Y4
+		VSHUFPS	$136, Y7, Y6, Y6
+		VPADDD	Y10, Y4, Y4
+		VPADDD	Y11, Y6, Y6
+		ADDQ	$256, BP
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y4, Y4
+		VPADDD	Y14, Y6, Y6
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y4, Y4
+		VPSRAD	$14, Y6, Y6
+		VPACKSSDW	Y2, Y0, Y0
+		VPACKSSDW	Y6, Y4, Y4
+		VPACKUSWB	Y4, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_69
+nosimdloop_71:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_72
+asmloop_70:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	$8, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_70
+end_72:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_68
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale6Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_73:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_76
+simdloop_74:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_74
+nosimdloop_76:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_77
+asmloop_75:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	$12, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_75
+end_77:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_73
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale8Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_78:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_81
+simdloop_79:
+		MOVWQSX	(BX), AX
+		VMOVQ	(SI)(AX*1), X0
+		MOVWQSX	2(BX), DX
+		VMOVQ	(SI)(DX*1), X4
+		MOVWQSX	4(BX), AX
+		VMOVQ	(SI)(AX*1), X5
+		MOVWQSX	6(BX), DX
+		VMOVQ	(SI)(DX*1), X6
+		MOVWQSX	32(BX), AX
+		VMOVQ	(SI)(AX*1), X8
+		MOVWQSX	34(BX), DX
+		VMOVQ	(SI)(DX*1), X9
+		MOVWQSX	36(BX), AX
+		VMOVQ	(SI)(AX*1), X10
+		MOVWQSX	38(BX), DX
+		VMOVQ	(SI)(DX*1), X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y4, Y4
+		VINSERTI128	$1, X10, Y5, Y5
+		VINSERTI128	$1, X11, Y6, Y6
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	32(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	64(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	96(BP), Y6, Y6
+		VMOVDQA	Y0, Y12
+		VMOVDQA	Y5, Y13
+		VPUNPCKLQDQ	Y4, Y0, Y0
+		VPUNPCKHQDQ	Y4, Y12, Y12
+		VPADDD	Y12, Y0, Y0
+		VPUNPCKLQDQ	Y6, Y5, Y5
+		VPUNPCKHQDQ	Y6, Y13, Y13
+		VPADDD	Y13, Y5, Y5
+		VMOVDQA	Y0, Y12
+		VSHUFPS	$136, Y5, Y0, Y0
+		VSHUFPS	$221, Y5, Y12, Y12
+		VPADDD	Y12, Y0, Y0
+		MOVWQSX	8(BX), AX
+		VMOVQ	(SI)(AX*1), X1
+		MOVWQSX	10(BX), DX
+		VMOVQ	(SI)(DX*1), X4
+		MOVWQSX	12(BX), AX
+		VMOVQ	(SI)(AX*1), X5
+		MOVWQSX	14(BX), DX
+		VMOVQ	(SI)(DX*1), X6
+		MOVWQSX	40(BX), AX
+		VMOVQ	(SI)(AX*1), X8
+		MOVWQSX	42(BX), DX
+		VMOVQ	(SI)(DX*1), X9
+		MOVWQSX	44(BX), AX
+		VMOVQ	(SI)(AX*1), X10
+		MOVWQSX	46(BX), DX
+		VMOVQ	(SI)(DX*1), X11
+		VINSERTI128	$1, X8, Y1, Y1
+		VINSERTI128	$1, X9, Y4, Y4
+		VINSERTI128	$1, X10, Y5, Y5
+		VINSERTI128	$1, X11, Y6, Y6
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	128(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	160(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	192(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	224(BP), Y6, Y6
+		VMOVDQA	Y1, Y12
+		VMOVDQA	Y5, Y13
+		VPUNPCKLQDQ	Y4, Y1, Y1
+		VPUNPCKHQDQ	Y4, Y12, Y12
+		VPADDD	Y12, Y1, Y1
+		VPUNPCKLQDQ	Y6, Y5, Y5
+		VPUNPCKHQDQ	Y6, Y13, Y13
+		VPADDD	Y13, Y5, Y5
+		VMOVDQA	Y1, Y12
+		VSHUFPS	$136, Y5, Y1, Y1
+		VSHUFPS	$221, Y5, Y12, Y12
+		VPADDD	Y12, Y1, Y1
+		MOVWQSX	16(BX), AX
+		VMOVQ	(SI)(AX*1), X2
+		MOVWQSX	18(BX), DX
+		VMOVQ	(SI)(DX*1), X4
+		MOVWQSX	20(BX), AX
+		VMOVQ	(SI)(AX*1), X5
+		MOVWQSX	22(BX), DX
+		VMOVQ	(SI)(DX*1), X6
+		MOVWQSX	48(BX), AX
+		VMOVQ	(SI)(AX*1), X8
+		MOVWQSX	50(BX), DX
+		VMOVQ	(SI)(DX*1), X9
+		MOVWQSX	52(BX), AX
+		VMOVQ	(SI)(AX*1), X10
+		MOVWQSX	54(BX), DX
+		VMOVQ	(SI)(DX*1), X11
+		VINSERTI128	$1, X8, Y2, Y2
+		VINSERTI128	$1, X9, Y4, Y4
+		VINSERTI128	$1, X10, Y5, Y5
+		VINSERTI128	$1, X11, Y6, Y6
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	256(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	288(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	320(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	352(BP), Y6, Y6
+		VMOVDQA	Y2, Y12
+		VMOVDQA	Y5, Y13
+		VPUNPCKLQDQ	Y4, Y2, Y2
+		VPUNPCKHQDQ	Y4, Y12, Y12
+		VPADDD	Y12, Y2, Y2
+		VPUNPCKLQDQ	Y6, Y5, Y5
+		VPUNPCKHQDQ	Y6, Y13, Y13
+		VPADDD	Y13, Y5, Y5
+		VMOVDQA	Y2, Y12
+		VSHUFPS	$136, Y5, Y2, Y2
+		VSHUFPS	$221, Y5, Y12, Y12
+		VPADDD	Y12, Y2, Y2
+		MOVWQSX	24(BX), AX
+		VMOVQ	(SI)(AX*1), X3
+		MOVWQSX	26(BX), DX
+		VMOVQ	(SI)(DX*1), X4
+		MOVWQSX	28(BX), AX
+		VMOVQ	(SI)(AX*1), X5
+		MOVWQSX	30(BX), DX
+		VMOVQ	(SI)(DX*1), X6
+		MOVWQSX	56(BX), AX
+		VMOVQ	(SI)(AX*1), X8
+		MOVWQSX	58(BX), DX
+		VMOVQ	(SI)(DX*1), X9
+		MOVWQSX	60(BX), AX
+		VMOVQ	(SI)(AX*1), X10
+		MOVWQSX	62(BX), DX
+		VMOVQ	(SI)(DX*1), X11
+		VINSERTI128	$1, X8, Y3, Y3
+		VINSERTI128	$1, X9, Y4, Y4
+		VINSERTI128	$1, X10, Y5, Y5
+		VINSERTI128	$1, X11, Y6, Y6
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	384(BP), Y3, Y3
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	416(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	448(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	480(BP), Y6, Y6
+		VMOVDQA	Y3, Y12
+		VMOVDQA	Y5, Y13
+		VPUNPCKLQDQ	Y4, Y3, Y3
+		VPUNPCKHQDQ	Y4, Y12, Y12
+		VPADDD	Y12, Y3, Y3
+		VPUNPCKLQDQ	Y6, Y5, Y5
+		VPUNPCKHQDQ	Y6, Y13, Y13
+		VPADDD	Y13, Y5, Y5
+		VMOVDQA	Y3, Y12
+		VSHUFPS	$136, Y5, Y3, Y3
+		VSHUFPS	$221, Y5, Y12, Y12
+		VPADDD	Y12, Y3, Y3
+		ADDQ	$64, BX
+		ADDQ	$512, BP
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_79
+nosimdloop_81:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_82
+asmloop_80:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	$16, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_80
+end_82:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_78
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale10Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_83:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_86
+simdloop_84:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_84
+nosimdloop_86:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_87
+asmloop_85:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	$20, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_85
+end_87:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_83
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale12Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_88:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_91
+simdloop_89:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_89
+nosimdloop_91:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_92
+asmloop_90:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	10(SI)(DX*1), AX
+		MOVWQSX	20(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	11(SI)(DX*1), AX
+		MOVWQSX	22(BP), DX
+		IMULQ	DX
+		ADDQ	$24, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_90
+end_92:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_88
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale14Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_93:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_96
+simdloop_94:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_94
+nosimdloop_96:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_97
+asmloop_95:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	10(SI)(DX*1), AX
+		MOVWQSX	20(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	11(SI)(DX*1), AX
+		MOVWQSX	22(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	12(SI)(DX*1), AX
+		MOVWQSX	24(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	13(SI)(DX*1), AX
+		MOVWQSX	26(BP), DX
+		IMULQ	DX
+		ADDQ	$28, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_95
+end_97:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_93
+		VZEROUPPER
+		RET
+
+TEXT ·h8scale16Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_98:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_101
+simdloop_99:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_99
+nosimdloop_101:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_102
+asmloop_100:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	4(SI)(DX*1), AX
+		MOVWQSX	8(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	5(SI)(DX*1), AX
+		MOVWQSX	10(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	6(SI)(DX*1), AX
+		MOVWQSX	12(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	7(SI)(DX*1), AX
+		MOVWQSX	14(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	8(SI)(DX*1), AX
+		MOVWQSX	16(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	9(SI)(DX*1), AX
+		MOVWQSX	18(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	10(SI)(DX*1), AX
+		MOVWQSX	20(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	11(SI)(DX*1), AX
+		MOVWQSX	22(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	12(SI)(DX*1), AX
+		MOVWQSX	24(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	13(SI)(DX*1), AX
+		MOVWQSX	26(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	14(SI)(DX*1), AX
+		MOVWQSX	28(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	15(SI)(DX*1), AX
+		MOVWQSX	30(BP), DX
+		IMULQ	DX
+		ADDQ	$32, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_100
+end_102:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_98
+		VZEROUPPER
+		RET
+
+TEXT ·h8scaleNAvx2(SB),4,$64-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		MOVQ	DX, inner+-64(SP)
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_103:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_106
+simdloop_104:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X0, X0
+		VPINSRW	$1, (SI)(R9*1), X0, X0
+		VPINSRW	$2, (SI)(R10*1), X0, X0
+		VPINSRW	$3, (SI)(R11*1), X0, X0
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X1, X1
+		VPINSRW	$1, (SI)(R9*1), X1, X1
+		VPINSRW	$2, (SI)(R10*1), X1, X1
+		VPINSRW	$3, (SI)(R11*1), X1, X1
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X2, X2
+		VPINSRW	$1, (SI)(R9*1), X2, X2
+		VPINSRW	$2, (SI)(R10*1), X2, X2
+		VPINSRW	$3, (SI)(R11*1), X2, X2
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X3, X3
+		VPINSRW	$1, (SI)(R9*1), X3, X3
+		VPINSRW	$2, (SI)(R10*1), X3, X3
+		VPINSRW	$3, (SI)(R11*1), X3, X3
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y0, Y0
+		VINSERTI128	$1, X9, Y1, Y1
+		VINSERTI128	$1, X10, Y2, Y2
+		VINSERTI128	$1, X11, Y3, Y3
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPMADDWD	(BP), Y0, Y0
+		VPUNPCKLBW	Y15, Y1, Y1
+		VPMADDWD	32(BP), Y1, Y1
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPMADDWD	64(BP), Y2, Y2
+		VPUNPCKLBW	Y15, Y3, Y3
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$128, BP
+		MOVQ	DI, dstref+-48(SP)
+		MOVQ	inner+-64(SP), DI
+loop_108:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X4, X4
+		VPINSRW	$1, (SI)(R9*1), X4, X4
+		VPINSRW	$2, (SI)(R10*1), X4, X4
+		VPINSRW	$3, (SI)(R11*1), X4, X4
+		MOVWQSX	8(BX), R8
+		MOVWQSX	10(BX), R9
+		MOVWQSX	12(BX), R10
+		MOVWQSX	14(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X5, X5
+		VPINSRW	$1, (SI)(R9*1), X5, X5
+		VPINSRW	$2, (SI)(R10*1), X5, X5
+		VPINSRW	$3, (SI)(R11*1), X5, X5
+		MOVWQSX	16(BX), R8
+		MOVWQSX	18(BX), R9
+		MOVWQSX	20(BX), R10
+		MOVWQSX	22(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X6, X6
+		VPINSRW	$1, (SI)(R9*1), X6, X6
+		VPINSRW	$2, (SI)(R10*1), X6, X6
+		VPINSRW	$3, (SI)(R11*1), X6, X6
+		MOVWQSX	24(BX), R8
+		MOVWQSX	26(BX), R9
+		MOVWQSX	28(BX), R10
+		MOVWQSX	30(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X7, X7
+		VPINSRW	$1, (SI)(R9*1), X7, X7
+		VPINSRW	$2, (SI)(R10*1), X7, X7
+		VPINSRW	$3, (SI)(R11*1), X7, X7
+		MOVWQSX	32(BX), R8
+		MOVWQSX	34(BX), R9
+		MOVWQSX	36(BX), R10
+		MOVWQSX	38(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X8, X8
+		VPINSRW	$1, (SI)(R9*1), X8, X8
+		VPINSRW	$2, (SI)(R10*1), X8, X8
+		VPINSRW	$3, (SI)(R11*1), X8, X8
+		MOVWQSX	40(BX), R8
+		MOVWQSX	42(BX), R9
+		MOVWQSX	44(BX), R10
+		MOVWQSX	46(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X9, X9
+		VPINSRW	$1, (SI)(R9*1), X9, X9
+		VPINSRW	$2, (SI)(R10*1), X9, X9
+		VPINSRW	$3, (SI)(R11*1), X9, X9
+		MOVWQSX	48(BX), R8
+		MOVWQSX	50(BX), R9
+		MOVWQSX	52(BX), R10
+		MOVWQSX	54(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X10, X10
+		VPINSRW	$1, (SI)(R9*1), X10, X10
+		VPINSRW	$2, (SI)(R10*1), X10, X10
+		VPINSRW	$3, (SI)(R11*1), X10, X10
+		MOVWQSX	56(BX), R8
+		MOVWQSX	58(BX), R9
+		MOVWQSX	60(BX), R10
+		MOVWQSX	62(BX), R11
+		VPINSRW	$0, (SI)(R8*1), X11, X11
+		VPINSRW	$1, (SI)(R9*1), X11, X11
+		VPINSRW	$2, (SI)(R10*1), X11, X11
+		VPINSRW	$3, (SI)(R11*1), X11, X11
+		VINSERTI128	$1, X8, Y4, Y4
+		VINSERTI128	$1, X9, Y5, Y5
+		VINSERTI128	$1, X10, Y6, Y6
+		VINSERTI128	$1, X11, Y7, Y7
+		ADDQ	$2, SI
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPMADDWD	(BP), Y4, Y4
+		VPUNPCKLBW	Y15, Y5, Y5
+		VPMADDWD	32(BP), Y5, Y5
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPMADDWD	64(BP), Y6, Y6
+		VPUNPCKLBW	Y15, Y7, Y7
+		VPMADDWD	96(BP), Y7, Y7
+		ADDQ	$128, BP
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		SUBQ	$2, DI
+		JNE	loop_108
+		MOVQ	dstref+-48(SP), DI
+		MOVQ	taps+96(FP), AX
+		SUBQ	AX, SI
+		ADDQ	$64, BX
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_104
+nosimdloop_106:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_107
+asmloop_105:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVQ	inner+-64(SP), AX
+		MOVQ	AX, count+-56(SP)
+loop_109:
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$1, SI
+		ADDQ	$2, BP
+		ADDQ	AX, sum+-40(SP)
+		SUBQ	$1, count+-56(SP)
+		JNE	loop_109
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$4, BP
+		SUBQ	inner+-64(SP), SI
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_105
+end_107:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_103
+		VZEROUPPER
+		RET
+
+TEXT ·h8shuffle2Ssse3(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$4, CX
+		ANDQ	$15, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_110:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_113
+simdloop_111:
+		MOVWQSX	96(BP), AX
+		MOVOU	(SI)(AX*1), X0
+		PSHUFB	64(BP), X0
+		MOVO	X0, X1
+		PUNPCKLBW	X15, X0
+		PUNPCKHBW	X15, X1
+		PMADDWL	(BP), X0
+		PMADDWL	16(BP), X1
+		MOVWQSX	98(BP), AX
+		MOVOU	(SI)(AX*1), X2
+		PSHUFB	80(BP), X2
+		MOVO	X2, X3
+		PUNPCKLBW	X15, X2
+		PUNPCKHBW	X15, X3
+		PMADDWL	32(BP), X2
+		PMADDWL	48(BP), X3
+		ADDQ	$32, BX
+		ADDQ	$112, BP
+		PADDL	X14, X0
+		PADDL	X14, X1
+		PADDL	X14, X2
+		PADDL	X14, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_111
+nosimdloop_113:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_114
+asmloop_112:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$4, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_112
+end_114:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_110
+		RET
+
+TEXT ·h8shuffle4Ssse3(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$4, CX
+		ANDQ	$15, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		PXOR	X15, X15
+		MOVO	hbits_1<>(SB), X14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_115:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_118
+simdloop_116:
+		MOVWQSX	192(BP), AX
+		MOVOU	(SI)(AX*1), X0
+		PSHUFB	128(BP), X0
+		MOVO	X0, X1
+		PUNPCKLBW	X15, X0
+		PUNPCKHBW	X15, X1
+		PMADDWL	(BP), X0
+		PMADDWL	16(BP), X1
+		MOVWQSX	194(BP), AX
+		MOVOU	(SI)(AX*1), X2
+		PSHUFB	144(BP), X2
+		MOVO	X2, X3
+		PUNPCKLBW	X15, X2
+		PUNPCKHBW	X15, X3
+		PMADDWL	32(BP), X2
+		PMADDWL	48(BP), X3
+		MOVWQSX	196(BP), AX
+		MOVOU	(SI)(AX*1), X4
+		PSHUFB	160(BP), X4
+		MOVO	X4, X5
+		PUNPCKLBW	X15, X4
+		PUNPCKHBW	X15, X5
+		PMADDWL	64(BP), X4
+		PMADDWL	80(BP), X5
+		MOVWQSX	198(BP), AX
+		MOVOU	(SI)(AX*1), X6
+		PSHUFB	176(BP), X6
+		MOVO	X6, X7
+		PUNPCKLBW	X15, X6
+		PUNPCKHBW	X15, X7
+		PMADDWL	96(BP), X6
+		PMADDWL	112(BP), X7
+		ADDQ	$32, BX
+		MOVO	X0, X8
+		MOVO	X2, X9
+		SHUFPS	$221, X1, X8
+		SHUFPS	$221, X3, X9
+		SHUFPS	$136, X1, X0
+		SHUFPS	$136, X3, X2
+		PADDL	X8, X0
+		PADDL	X9, X2
+		MOVO	X4, X10
+		MOVO	X6, X11
+		SHUFPS	$221, X5, X10
+		SHUFPS	$221, X7, X11
+		SHUFPS	$136, X5, X4
+		SHUFPS	$136, X7, X6
+		PADDL	X10, X4
+		PADDL	X11, X6
+		ADDQ	$208, BP
+		PADDL	X14, X0
+		PADDL	X14, X2
+		PADDL	X14, X4
+		PADDL	X14, X6
+		PSRAL	$14, X0
+		PSRAL	$14, X2
+		PSRAL	$14, X4
+		PSRAL	$14, X6
+		PACKSSLW	X2, X0
+		PACKSSLW	X6, X4
+		PACKUSWB	X4, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_116
+nosimdloop_118:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_119
+asmloop_117:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	$8, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_117
+end_119:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_115
+		RET
+
+TEXT ·h8shuffle2Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_120:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_123
+simdloop_121:
+		MOVWQSX	192(BP), AX
+		VMOVDQU	(SI)(AX*1), X0
+		MOVWQSX	208(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y0, Y0
+		VPSHUFB	128(BP), Y0, Y0
+		VMOVDQA	Y0, Y1
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPUNPCKHBW	Y15, Y1, Y1
+		VPMADDWD	(BP), Y0, Y0
+		VPMADDWD	32(BP), Y1, Y1
+		MOVWQSX	194(BP), AX
+		VMOVDQU	(SI)(AX*1), X2
+		MOVWQSX	210(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y2, Y2
+		VPSHUFB	160(BP), Y2, Y2
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPUNPCKHBW	Y15, Y3, Y3
+		VPMADDWD	64(BP), Y2, Y2
+		VPMADDWD	96(BP), Y3, Y3
+		ADDQ	$64, BX
+		ADDQ	$224, BP
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y1, Y1
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_121
+nosimdloop_123:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_124
+asmloop_122:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	$4, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_122
+end_124:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_120
+		VZEROUPPER
+		RET
+
+TEXT ·h8shuffle4Avx2(SB),4,$40-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		SHRQ	$5, CX
+		ANDQ	$31, DX
+		MOVQ	BX, dstoff+-32(SP)
+		MOVQ	CX, simdroll+-8(SP)
+		MOVQ	DX, asmroll+-16(SP)
+		MOVQ	src+24(FP), AX
+		MOVQ	AX, srcref+-24(SP)
+		MOVQ	taps+96(FP), DX
+		SUBQ	$2, DX
+		VPXOR	Y15, Y15, Y15
+		VBROADCASTI128	hbits_1<>(SB), Y14
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+yloop_125:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), BP
+		MOVQ	simdroll+-8(SP), CX
+		ORQ	CX, CX
+		JE	nosimdloop_128
+simdloop_126:
+		MOVWQSX	384(BP), AX
+		VMOVDQU	(SI)(AX*1), X0
+		MOVWQSX	400(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y0, Y0
+		VPSHUFB	256(BP), Y0, Y0
+		VMOVDQA	Y0, Y1
+		VPUNPCKLBW	Y15, Y0, Y0
+		VPUNPCKHBW	Y15, Y1, Y1
+		VPMADDWD	(BP), Y0, Y0
+		VPMADDWD	32(BP), Y1, Y1
+		MOVWQSX	386(BP), AX
+		VMOVDQU	(SI)(AX*1), X2
+		MOVWQSX	402(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y2, Y2
+		VPSHUFB	288(BP), Y2, Y2
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y15, Y2, Y2
+		VPUNPCKHBW	Y15, Y3, Y3
+		VPMADDWD	64(BP), Y2, Y2
+		VPMADDWD	96(BP), Y3, Y3
+		MOVWQSX	388(BP), AX
+		VMOVDQU	(SI)(AX*1), X4
+		MOVWQSX	404(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y4, Y4
+		VPSHUFB	320(BP), Y4, Y4
+		VMOVDQA	Y4, Y5
+		VPUNPCKLBW	Y15, Y4, Y4
+		VPUNPCKHBW	Y15, Y5, Y5
+		VPMADDWD	128(BP), Y4, Y4
+		VPMADDWD	160(BP), Y5, Y5
+		MOVWQSX	390(BP), AX
+		VMOVDQU	(SI)(AX*1), X6
+		MOVWQSX	406(BP), DX
+		VINSERTI128	$1, (SI)(DX*1), Y6, Y6
+		VPSHUFB	352(BP), Y6, Y6
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y15, Y6, Y6
+		VPUNPCKHBW	Y15, Y7, Y7
+		VPMADDWD	192(BP), Y6, Y6
+		VPMADDWD	224(BP), Y7, Y7
+		ADDQ	$64, BX
+		VMOVDQA	Y0, Y8
+		VMOVDQA	Y2, Y9
+		VSHUFPS	$221, Y1, Y8, Y8
+		VSHUFPS	$221, Y3, Y9, Y9
+		VSHUFPS	$136, Y1, Y0, Y0
+		VSHUFPS	$136, Y3, Y2, Y2
+		VPADDD	Y8, Y0, Y0
+		VPADDD	Y9, Y2, Y2
+		VMOVDQA	Y4, Y10
+		VMOVDQA	Y6, Y11
+		VSHUFPS	$221, Y5, Y10, Y10
+		VSHUFPS	$221, Y7, Y11, Y11
+		VSHUFPS	$136, Y5, Y4, Y4
+		VSHUFPS	$136, Y7, Y6, Y6
+		VPADDD	Y10, Y4, Y4
+		VPADDD	Y11, Y6, Y6
+		ADDQ	$416, BP
+		VPADDD	Y14, Y0, Y0
+		VPADDD	Y14, Y2, Y2
+		VPADDD	Y14, Y4, Y4
+		VPADDD	Y14, Y6, Y6
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y4, Y4
+		VPSRAD	$14, Y6, Y6
+		VPACKSSDW	Y2, Y0, Y0
+		VPACKSSDW	Y6, Y4, Y4
+		VPACKUSWB	Y4, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	simdloop_126
+nosimdloop_128:
+		MOVQ	asmroll+-16(SP), CX
+		ORQ	CX, CX
+		JE	end_129
+asmloop_127:
+		MOVWQSX	(BX), DX
+		MOVBQZX	(SI)(DX*1), AX
+		MOVWQSX	(BP), DX
+		IMULQ	DX
+		MOVQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	1(SI)(DX*1), AX
+		MOVWQSX	2(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	2(SI)(DX*1), AX
+		MOVWQSX	4(BP), DX
+		IMULQ	DX
+		ADDQ	AX, sum+-40(SP)
+		MOVWQSX	(BX), DX
+		MOVBQZX	3(SI)(DX*1), AX
+		MOVWQSX	6(BP), DX
+		IMULQ	DX
+		ADDQ	$8, BP
+		ADDQ	sum+-40(SP), AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		ADDQ	$2, BX
+		MOVB	AL, (DI)
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_127
+end_129:
+		MOVQ	srcref+-24(SP), SI
+		ADDQ	dstoff+-32(SP), DI
+		ADDQ	sp+128(FP), SI
+		MOVQ	SI, srcref+-24(SP)
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_125
+		VZEROUPPER
+		RET
+DATA	sign_3<>+0x00(SB)/8, $0x8000800080008000
+DATA	sign_3<>+0x08(SB)/8, $0x8000800080008000
+GLOBL	sign_3<>(SB), 8, $16
+DATA	zero_4<>+0x00(SB)/8, $0x0000000000000000
+GLOBL	zero_4<>(SB), 8, $8
+DATA	u8max_5<>+0x00(SB)/8, $0x00000000000000FF
+GLOBL	u8max_5<>(SB), 8, $8
+DATA	u16max_6<>+0x00(SB)/8, $0x000000000000FFFF
+GLOBL	u16max_6<>(SB), 8, $8
+
+TEXT ·h16scale2Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_130:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_131
+simdloop_132:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PADDL	16(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$32, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_132
+nosimdloop_131:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_133
+asmloop_134:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$4, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_134
+end_133:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_130
+		RET
+
+TEXT ·h16scale4Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_135:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_136
+simdloop_137:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PADDL	32(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$48, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_137
+nosimdloop_136:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_138
+asmloop_139:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$8, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_139
+end_138:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_135
+		RET
+
+TEXT ·h16scale6Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_140:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_141
+simdloop_142:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PADDL	48(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$64, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_142
+nosimdloop_141:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_143
+asmloop_144:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$12, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_144
+end_143:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_140
+		RET
+
+TEXT ·h16scale8Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_145:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_146
+simdloop_147:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PADDL	64(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$80, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_147
+nosimdloop_146:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_148
+asmloop_149:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$16, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_149
+end_148:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_145
+		RET
+
+TEXT ·h16scale10Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_150:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_151
+simdloop_152:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PADDL	80(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$96, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_152
+nosimdloop_151:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_153
+asmloop_154:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$20, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_154
+end_153:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_150
+		RET
+
+TEXT ·h16scale12Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_155:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_156
+simdloop_157:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		PADDL	96(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$112, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_157
+nosimdloop_156:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_158
+asmloop_159:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$24, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_159
+end_158:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_155
+		RET
+
+TEXT ·h16scale14Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_160:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_161
+simdloop_162:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		MOVL	24(SI)(R8*2), X0
+		MOVL	24(SI)(R9*2), X1
+		MOVL	24(SI)(R10*2), X2
+		MOVL	24(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		PADDL	112(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$128, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_162
+nosimdloop_161:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_163
+asmloop_164:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	24(SI)(R8*2), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	26(SI)(R8*2), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$28, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_164
+end_163:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_160
+		RET
+
+TEXT ·h16scale16Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_165:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_166
+simdloop_167:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		MOVL	24(SI)(R8*2), X0
+		MOVL	24(SI)(R9*2), X1
+		MOVL	24(SI)(R10*2), X2
+		MOVL	24(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		MOVL	28(SI)(R8*2), X0
+		MOVL	28(SI)(R9*2), X1
+		MOVL	28(SI)(R10*2), X2
+		MOVL	28(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	112(R14), X0
+		PADDL	X0, X4
+		PADDL	128(R14), X4
+		PSRAL	$14, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$144, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_167
+nosimdloop_166:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_168
+asmloop_169:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	24(SI)(R8*2), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	26(SI)(R8*2), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	28(SI)(R8*2), AX
+		MOVWQSX	28(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	30(SI)(R8*2), AX
+		MOVWQSX	30(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$32, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_169
+end_168:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_165
+		RET
+
+TEXT ·h8to16scale2Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_170:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_171
+simdloop_172:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PADDL	16(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$32, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_172
+nosimdloop_171:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_173
+asmloop_174:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$4, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_174
+end_173:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_170
+		RET
+
+TEXT ·h8to16scale4Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_175:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_176
+simdloop_177:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PADDL	32(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$48, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_177
+nosimdloop_176:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_178
+asmloop_179:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$8, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_179
+end_178:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_175
+		RET
+
+TEXT ·h8to16scale6Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_180:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_181
+simdloop_182:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PADDL	48(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$64, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_182
+nosimdloop_181:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_183
+asmloop_184:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$12, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_184
+end_183:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_180
+		RET
+
+TEXT ·h8to16scale8Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_185:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_186
+simdloop_187:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 6(SI)(R8*1), X0
+		PINSRW	$1, 6(SI)(R9*1), X0
+		PINSRW	$2, 6(SI)(R10*1), X0
+		PINSRW	$3, 6(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PADDL	64(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$80, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_187
+nosimdloop_186:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_188
+asmloop_189:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	6(SI)(R8*1), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	7(SI)(R8*1), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$16, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_189
+end_188:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_185
+		RET
+
+TEXT ·h8to16scale10Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_190:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_191
+simdloop_192:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 6(SI)(R8*1), X0
+		PINSRW	$1, 6(SI)(R9*1), X0
+		PINSRW	$2, 6(SI)(R10*1), X0
+		PINSRW	$3, 6(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 8(SI)(R8*1), X0
+		PINSRW	$1, 8(SI)(R9*1), X0
+		PINSRW	$2, 8(SI)(R10*1), X0
+		PINSRW	$3, 8(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PADDL	80(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$96, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_192
+nosimdloop_191:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_193
+asmloop_194:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	6(SI)(R8*1), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	7(SI)(R8*1), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	8(SI)(R8*1), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	9(SI)(R8*1), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$20, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_194
+end_193:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_190
+		RET
+
+TEXT ·h8to16scale12Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_195:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_196
+simdloop_197:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 6(SI)(R8*1), X0
+		PINSRW	$1, 6(SI)(R9*1), X0
+		PINSRW	$2, 6(SI)(R10*1), X0
+		PINSRW	$3, 6(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 8(SI)(R8*1), X0
+		PINSRW	$1, 8(SI)(R9*1), X0
+		PINSRW	$2, 8(SI)(R10*1), X0
+		PINSRW	$3, 8(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 10(SI)(R8*1), X0
+		PINSRW	$1, 10(SI)(R9*1), X0
+		PINSRW	$2, 10(SI)(R10*1), X0
+		PINSRW	$3, 10(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		PADDL	96(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$112, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_197
+nosimdloop_196:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_198
+asmloop_199:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	6(SI)(R8*1), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	7(SI)(R8*1), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	8(SI)(R8*1), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	9(SI)(R8*1), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	10(SI)(R8*1), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	11(SI)(R8*1), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$24, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_199
+end_198:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_195
+		RET
+
+TEXT ·h8to16scale14Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_200:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_201
+simdloop_202:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 6(SI)(R8*1), X0
+		PINSRW	$1, 6(SI)(R9*1), X0
+		PINSRW	$2, 6(SI)(R10*1), X0
+		PINSRW	$3, 6(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 8(SI)(R8*1), X0
+		PINSRW	$1, 8(SI)(R9*1), X0
+		PINSRW	$2, 8(SI)(R10*1), X0
+		PINSRW	$3, 8(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 10(SI)(R8*1), X0
+		PINSRW	$1, 10(SI)(R9*1), X0
+		PINSRW	$2, 10(SI)(R10*1), X0
+		PINSRW	$3, 10(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 12(SI)(R8*1), X0
+		PINSRW	$1, 12(SI)(R9*1), X0
+		PINSRW	$2, 12(SI)(R10*1), X0
+		PINSRW	$3, 12(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		PADDL	112(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$128, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_202
+nosimdloop_201:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_203
+asmloop_204:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	6(SI)(R8*1), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	7(SI)(R8*1), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	8(SI)(R8*1), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	9(SI)(R8*1), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	10(SI)(R8*1), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	11(SI)(R8*1), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	12(SI)(R8*1), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	13(SI)(R8*1), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$28, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_204
+end_203:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_200
+		RET
+
+TEXT ·h8to16scale16Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+yloop_205:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_206
+simdloop_207:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		PINSRW	$0, (SI)(R8*1), X0
+		PINSRW	$1, (SI)(R9*1), X0
+		PINSRW	$2, (SI)(R10*1), X0
+		PINSRW	$3, (SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PINSRW	$0, 2(SI)(R8*1), X0
+		PINSRW	$1, 2(SI)(R9*1), X0
+		PINSRW	$2, 2(SI)(R10*1), X0
+		PINSRW	$3, 2(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 4(SI)(R8*1), X0
+		PINSRW	$1, 4(SI)(R9*1), X0
+		PINSRW	$2, 4(SI)(R10*1), X0
+		PINSRW	$3, 4(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 6(SI)(R8*1), X0
+		PINSRW	$1, 6(SI)(R9*1), X0
+		PINSRW	$2, 6(SI)(R10*1), X0
+		PINSRW	$3, 6(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 8(SI)(R8*1), X0
+		PINSRW	$1, 8(SI)(R9*1), X0
+		PINSRW	$2, 8(SI)(R10*1), X0
+		PINSRW	$3, 8(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 10(SI)(R8*1), X0
+		PINSRW	$1, 10(SI)(R9*1), X0
+		PINSRW	$2, 10(SI)(R10*1), X0
+		PINSRW	$3, 10(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 12(SI)(R8*1), X0
+		PINSRW	$1, 12(SI)(R9*1), X0
+		PINSRW	$2, 12(SI)(R10*1), X0
+		PINSRW	$3, 12(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		PINSRW	$0, 14(SI)(R8*1), X0
+		PINSRW	$1, 14(SI)(R9*1), X0
+		PINSRW	$2, 14(SI)(R10*1), X0
+		PINSRW	$3, 14(SI)(R11*1), X0
+		PUNPCKLBW	X14, X0
+		PMADDWL	112(R14), X0
+		PADDL	X0, X4
+		PADDL	128(R14), X4
+		PSRAL	$6, X4
+		PACKSSLW	X4, X4
+		PXOR	X15, X4
+		MOVQ	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$144, R14
+		ADDQ	$8, DI
+		SUBQ	$1, CX
+		JNE	simdloop_207
+nosimdloop_206:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_208
+asmloop_209:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVBQZX	(SI)(R8*1), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	1(SI)(R8*1), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	2(SI)(R8*1), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	3(SI)(R8*1), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	4(SI)(R8*1), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	5(SI)(R8*1), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	6(SI)(R8*1), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	7(SI)(R8*1), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	8(SI)(R8*1), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	9(SI)(R8*1), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	10(SI)(R8*1), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	11(SI)(R8*1), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	12(SI)(R8*1), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	13(SI)(R8*1), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	14(SI)(R8*1), AX
+		MOVWQSX	28(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVBQZX	15(SI)(R8*1), AX
+		MOVWQSX	30(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$32, R14
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	asmloop_209
+end_208:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_205
+		RET
+
+TEXT ·h16to8scale2Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_210:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_211
+simdloop_212:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		PADDL	16(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$32, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_212
+nosimdloop_211:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_213
+asmloop_214:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$4, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_214
+end_213:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_210
+		RET
+
+TEXT ·h16to8scale4Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_215:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_216
+simdloop_217:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		PADDL	32(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$48, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_217
+nosimdloop_216:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_218
+asmloop_219:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$8, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_219
+end_218:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_215
+		RET
+
+TEXT ·h16to8scale6Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_220:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_221
+simdloop_222:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		PADDL	48(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$64, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_222
+nosimdloop_221:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_223
+asmloop_224:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$12, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_224
+end_223:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_220
+		RET
+
+TEXT ·h16to8scale8Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_225:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_226
+simdloop_227:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		PADDL	64(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$80, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_227
+nosimdloop_226:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_228
+asmloop_229:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$16, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_229
+end_228:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_225
+		RET
+
+TEXT ·h16to8scale10Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_230:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_231
+simdloop_232:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		PADDL	80(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$96, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_232
+nosimdloop_231:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_233
+asmloop_234:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$20, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_234
+end_233:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_230
+		RET
+
+TEXT ·h16to8scale12Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_235:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_236
+simdloop_237:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		PADDL	96(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$112, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_237
+nosimdloop_236:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_238
+asmloop_239:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$24, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_239
+end_238:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_235
+		RET
+
+TEXT ·h16to8scale14Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_240:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_241
+simdloop_242:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		MOVL	24(SI)(R8*2), X0
+		MOVL	24(SI)(R9*2), X1
+		MOVL	24(SI)(R10*2), X2
+		MOVL	24(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		PADDL	112(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$128, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_242
+nosimdloop_241:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_243
+asmloop_244:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	24(SI)(R8*2), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	26(SI)(R8*2), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$28, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_244
+end_243:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_240
+		RET
+
+TEXT ·h16to8scale16Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+yloop_245:
+		MOVQ	off+72(FP), BX
+		MOVQ	cof+48(FP), R14
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		SHRQ	$2, CX
+		ORQ	CX, CX
+		JE	nosimdloop_246
+simdloop_247:
+		MOVWQSX	(BX), R8
+		MOVWQSX	2(BX), R9
+		MOVWQSX	4(BX), R10
+		MOVWQSX	6(BX), R11
+		MOVL	(SI)(R8*2), X0
+		MOVL	(SI)(R9*2), X1
+		MOVL	(SI)(R10*2), X2
+		MOVL	(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	(R14), X0
+		MOVO	X0, X4
+		MOVL	4(SI)(R8*2), X0
+		MOVL	4(SI)(R9*2), X1
+		MOVL	4(SI)(R10*2), X2
+		MOVL	4(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	16(R14), X0
+		PADDL	X0, X4
+		MOVL	8(SI)(R8*2), X0
+		MOVL	8(SI)(R9*2), X1
+		MOVL	8(SI)(R10*2), X2
+		MOVL	8(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	32(R14), X0
+		PADDL	X0, X4
+		MOVL	12(SI)(R8*2), X0
+		MOVL	12(SI)(R9*2), X1
+		MOVL	12(SI)(R10*2), X2
+		MOVL	12(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	48(R14), X0
+		PADDL	X0, X4
+		MOVL	16(SI)(R8*2), X0
+		MOVL	16(SI)(R9*2), X1
+		MOVL	16(SI)(R10*2), X2
+		MOVL	16(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	64(R14), X0
+		PADDL	X0, X4
+		MOVL	20(SI)(R8*2), X0
+		MOVL	20(SI)(R9*2), X1
+		MOVL	20(SI)(R10*2), X2
+		MOVL	20(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	80(R14), X0
+		PADDL	X0, X4
+		MOVL	24(SI)(R8*2), X0
+		MOVL	24(SI)(R9*2), X1
+		MOVL	24(SI)(R10*2), X2
+		MOVL	24(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	96(R14), X0
+		PADDL	X0, X4
+		MOVL	28(SI)(R8*2), X0
+		MOVL	28(SI)(R9*2), X1
+		MOVL	28(SI)(R10*2), X2
+		MOVL	28(SI)(R11*2), X3
+		PUNPCKLLQ	X1, X0
+		PUNPCKLLQ	X3, X2
+		PUNPCKLQDQ	X2, X0
+		PXOR	X15, X0
+		PMADDWL	112(R14), X0
+		PADDL	X0, X4
+		PADDL	128(R14), X4
+		PSRAL	$22, X4
+		PACKSSLW	X4, X4
+		PACKUSWB	X4, X4
+		MOVL	X4, (DI)
+		ADDQ	$8, BX
+		ADDQ	$144, R14
+		ADDQ	$4, DI
+		SUBQ	$1, CX
+		JNE	simdloop_247
+nosimdloop_246:
+		MOVQ	width+104(FP), CX
+		ANDQ	$3, CX
+		JE	end_248
+asmloop_249:
+		MOVWQSX	(BX), R8
+		MOVQ	$0, R12
+		MOVWQZX	(SI)(R8*2), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	2(SI)(R8*2), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	4(SI)(R8*2), AX
+		MOVWQSX	4(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	6(SI)(R8*2), AX
+		MOVWQSX	6(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	8(SI)(R8*2), AX
+		MOVWQSX	8(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	10(SI)(R8*2), AX
+		MOVWQSX	10(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	12(SI)(R8*2), AX
+		MOVWQSX	12(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	14(SI)(R8*2), AX
+		MOVWQSX	14(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	16(SI)(R8*2), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	18(SI)(R8*2), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	20(SI)(R8*2), AX
+		MOVWQSX	20(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	22(SI)(R8*2), AX
+		MOVWQSX	22(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	24(SI)(R8*2), AX
+		MOVWQSX	24(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	26(SI)(R8*2), AX
+		MOVWQSX	26(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	28(SI)(R8*2), AX
+		MOVWQSX	28(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		MOVWQZX	30(SI)(R8*2), AX
+		MOVWQSX	30(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$2097152, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$22, R12
+		CMPQ	u8max_5<>(SB), R12
+		CMOVQLT	u8max_5<>(SB), R12
+		MOVB	R12, (DI)
+		ADDQ	$2, BX
+		ADDQ	$32, R14
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	asmloop_249
+end_248:
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	sp+128(FP), SI
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_245
+		RET
+DATA	round_7<>+0x00(SB)/8, $0x0080008000800080
+DATA	round_7<>+0x08(SB)/8, $0x0080008000800080
+GLOBL	round_7<>(SB), 8, $16
+DATA	low_8<>+0x00(SB)/8, $0x00FF00FF00FF00FF
+DATA	low_8<>+0x08(SB)/8, $0x00FF00FF00FF00FF
+GLOBL	low_8<>(SB), 8, $16
+
+TEXT ·h8deringAmd64(SB),4,$0-112
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		PXOR	X13, X13
+		MOVO	round_7<>(SB), X14
+		MOVQ	strength+72(FP), AX
+		MOVQ	AX, X15
+		PUNPCKLWL	X15, X15
+		PUNPCKLLQ	X15, X15
+		PUNPCKLQDQ	X15, X15
+		MOVO	low_8<>(SB), X12
+yloop_250:
+		MOVQ	near+48(FP), BX
+		MOVQ	DI, R11
+		MOVQ	width+80(FP), CX
+		SHRQ	$3, CX
+xloop_251:
+		MOVL	(BX), R8
+		PINSRW	$0, (SI)(R8*1), X0
+		MOVL	4(BX), R9
+		PINSRW	$1, (SI)(R9*1), X0
+		MOVL	8(BX), R12
+		PINSRW	$2, (SI)(R12*1), X0
+		MOVL	12(BX), R13
+		PINSRW	$3, (SI)(R13*1), X0
+		MOVL	16(BX), R8
+		PINSRW	$4, (SI)(R8*1), X0
+		MOVL	20(BX), R9
+		PINSRW	$5, (SI)(R9*1), X0
+		MOVL	24(BX), R12
+		PINSRW	$6, (SI)(R12*1), X0
+		MOVL	28(BX), R13
+		PINSRW	$7, (SI)(R13*1), X0
+		MOVO	X0, X1
+		PAND	X12, X1
+		PSRLW	$8, X0
+		PACKUSWB	X1, X1
+		PACKUSWB	X0, X0
+		MOVQ	(R11), X2
+		MOVO	X1, X3
+		PMINUB	X0, X3
+		PMAXUB	X0, X1
+		PSUBUSB	X2, X3
+		MOVO	X2, X4
+		PSUBUSB	X1, X4
+		MOVO	X3, X5
+		PUNPCKLBW	X13, X3
+		PUNPCKHBW	X13, X5
+		PMULLW	X15, X3
+		PMULLW	X15, X5
+		PADDW	X14, X3
+		PADDW	X14, X5
+		PSRLW	$8, X3
+		PSRLW	$8, X5
+		PACKUSWB	X5, X3
+		MOVO	X4, X6
+		PUNPCKLBW	X13, X4
+		PUNPCKHBW	X13, X6
+		PMULLW	X15, X4
+		PMULLW	X15, X6
+		PADDW	X14, X4
+		PADDW	X14, X6
+		PSRLW	$8, X4
+		PSRLW	$8, X6
+		PACKUSWB	X6, X4
+		PADDB	X3, X2
+		PSUBB	X4, X2
+		MOVQ	X2, (R11)
+		ADDQ	$32, BX
+		ADDQ	$8, R11
+		SUBQ	$1, CX
+		JNE	xloop_251
+		ADDQ	dp+96(FP), DI
+		ADDQ	sp+104(FP), SI
+		SUBQ	$1, height+88(FP)
+		JNE	yloop_250
 		RET
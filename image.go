@@ -11,8 +11,20 @@ Featuring:
  - YCbCr, RGBA, NRGBA & Gray resizes
  - YCbCr Chroma subsample ratio conversions
  - Optional interlaced-aware resizes
+ - Optional non-separable EWA resizes
+ - Optional anti-ringing clamp
+ - Optional nearest-neighbour resizes
+ - Optional area-averaging downscales
+ - Optional multi-stage downscales for large ratios
+ - Optional 16-bit intermediate planes between passes
+ - Optional ordered & error-diffusion dithering
+ - Optional auto-tuning of instruction sets & thread counts
+ - Clamp, mirror, wrap & constant borders
+ - Bilinear, bicubic, spline, gaussian & windowed sinc filters
  - Parallel resizes
- - SIMD optimisations on AMD64
+ - SSE2, SSSE3 & AVX2 optimisations on AMD64, selected at runtime
+ - Mipmap pyramid generation
+ - Deep-zoom DZI & XYZ tile export
 
 The easiest way to use it is:
 
@@ -50,6 +62,11 @@ type Converter interface {
 	// Result is undefined if src points to the same data as dst
 	// Returns an error if the conversion fails
 	Convert(dst, src image.Image) error
+	// Scalers returns scalers used by conversions, in processing order
+	Scalers() []ScalerInfo
+	// Tunings returns configurations picked by Autotune for every resizer,
+	// nil without Autotune
+	Tunings() []Tuning
 }
 
 // ChromaRatio is a chroma subsampling ratio
@@ -140,6 +157,24 @@ type ConverterConfig struct {
 	Output     Descriptor // output description
 	Threads    int        // number of allowed "threads"
 	DisableAsm bool       // disable asm optimisations
+	Sampling   Sampling   // sampling method [default=SamplingFilter]
+	AntiRing   float64    // anti-ringing strength, see ResizerConfig
+	MultiStage bool       // halve large reductions with area sampling first
+	Bits       int        // coeffs fractional bits, see ResizerConfig
+	Precise    bool       // keep 16-bit intermediate planes between passes
+	// Dither selects how every output plane is quantized from 16-bit
+	// samples, it is ignored on copies, EWA & nearest sampling
+	// [default=DitherNone]
+	Dither [maxPlanes]Dither
+	Border Border // border method, see ResizerConfig
+	// BorderValue is the BorderConstant value for every plane, or for
+	// every packed pixel byte on packed images
+	BorderValue [4]byte
+	ISA         ISA // best instruction set allowed, see ResizerConfig
+	// Autotune benchmarks every instruction set up to ISA & thread counts
+	// up to Threads on the configured geometry, then keeps the fastest
+	// combination for every resizer of every plane, see Tunings
+	Autotune bool
 }
 
 const (
@@ -157,9 +192,14 @@ type Plane struct {
 
 type converterContext struct {
 	ConverterConfig
-	wrez   [maxPlanes]Resizer
-	hrez   [maxPlanes]Resizer
-	buffer [maxPlanes]*Plane
+	wrez    [maxPlanes]Resizer
+	hrez    [maxPlanes]Resizer
+	ewa     [maxPlanes]Resizer
+	buffer  [maxPlanes]*Plane
+	stages  [maxPlanes][]stage
+	wide    [maxPlanes]bool // true if buffer holds 16-bit samples
+	dither  [maxPlanes]*Plane
+	tunings []Tuning // picked configurations when autotuned
 }
 
 func toInterlacedString(interlaced bool) string {
@@ -220,61 +260,112 @@ func NewConverter(cfg *ConverterConfig, filter Filter) (Converter, error) {
 	if cfg.Threads == 0 {
 		cfg.Threads = runtime.GOMAXPROCS(0)
 	}
+	if cfg.Autotune {
+		return autotune(cfg, filter)
+	}
 	ctx := &converterContext{
 		ConverterConfig: *cfg,
 	}
 	size := 0
 	group := sync.WaitGroup{}
+	errs := [maxPlanes * 2]error{}
 	for i := 0; i < cfg.Output.Planes; i++ {
 		win := cfg.Input.GetWidth(i)
 		hin := cfg.Input.GetHeight(i)
 		wout := cfg.Output.GetWidth(i)
 		hout := cfg.Output.GetHeight(i)
-		if win < 2 || hin < 2 {
+		// we need at least 2 taps per resized dimension
+		filtered := cfg.Sampling != SamplingNearest
+		if filtered && (win != wout && win < 2 || hin != hout && hin < 2) {
 			return nil, fmt.Errorf("input size too small %vx%v", win, hin)
 		}
-		if wout < 2 || hout < 2 {
+		if wout < 1 || hout < 1+int(bin(cfg.Output.Interlaced)) {
 			return nil, fmt.Errorf("output size too small %vx%v", wout, hout)
 		}
+		if cfg.Dither[i] < DitherNone || cfg.Dither[i] > DitherDiffusion {
+			return nil, fmt.Errorf("invalid dither %v", cfg.Dither[i])
+		}
 		idx := i
+		value := cfg.BorderValue
+		if cfg.Input.Pack == 1 {
+			value = [4]byte{cfg.BorderValue[i]}
+		}
+		if cfg.Sampling == SamplingEWA {
+			if win != wout || hin != hout {
+				ctx.ewa[i] = newEwaResizer(win, hin, wout, hout, cfg.Input.Pack,
+					cfg.Output.Interlaced, cfg.Threads, filter, cfg.Border, value)
+			}
+			continue
+		}
+		if cfg.MultiStage && cfg.Sampling == SamplingFilter {
+			var err error
+			ctx.stages[i], win, hin, err = planStages(cfg, win, hin, wout, hout)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// nearest sampling does not round so it cannot lose precision
+		wide := cfg.Precise && win != wout && hin != hout && cfg.Sampling != SamplingNearest
+		dithered := cfg.Dither[i] != DitherNone && (win != wout || hin != hout) && cfg.Sampling != SamplingNearest
+		if dithered {
+			p := &Plane{
+				Width:  wout,
+				Height: hout,
+				Pitch:  align(wout*cfg.Output.Pack*2, 16),
+				Pack:   cfg.Output.Pack,
+			}
+			p.Data = make([]byte, p.Pitch*(hout-1)+wout*p.Pack*2)
+			ctx.dither[i] = p
+		}
 		if win != wout {
 			dispatch(&group, cfg.Threads, func() {
-				threads := min(cfg.Threads, hout)
-				ctx.wrez[idx] = NewResize(&ResizerConfig{
-					Depth:      8,
-					Input:      win,
-					Output:     wout,
-					Vertical:   false,
-					Interlaced: false,
-					Pack:       cfg.Input.Pack,
-					Threads:    threads,
-					DisableAsm: cfg.DisableAsm || wout < 16,
+				ctx.wrez[idx], errs[idx*2] = NewResize(&ResizerConfig{
+					Depth:       8,
+					Input:       win,
+					Output:      wout,
+					Vertical:    false,
+					Interlaced:  false,
+					Pack:        cfg.Input.Pack,
+					Threads:     cfg.Threads,
+					DisableAsm:  cfg.DisableAsm,
+					AntiRing:    cfg.AntiRing,
+					Sampling:    cfg.Sampling,
+					Bits:        cfg.Bits,
+					WideInput:   wide,
+					WideOutput:  dithered,
+					Border:      cfg.Border,
+					BorderValue: value,
+					ISA:         cfg.ISA,
 				}, filter)
 			})
 		}
 		if hin != hout {
 			dispatch(&group, cfg.Threads, func() {
-				threads := min(cfg.Threads, hout)
-				if cfg.Output.Interlaced {
-					threads = min(cfg.Threads, hout>>1)
-				}
-				ctx.hrez[idx] = NewResize(&ResizerConfig{
-					Depth:      8,
-					Input:      hin,
-					Output:     hout,
-					Vertical:   true,
-					Interlaced: cfg.Output.Interlaced,
-					Pack:       cfg.Output.Pack,
-					Threads:    threads,
-					DisableAsm: cfg.DisableAsm || wout < 16 || win < 16,
+				ctx.hrez[idx], errs[idx*2+1] = NewResize(&ResizerConfig{
+					Depth:       8,
+					Input:       hin,
+					Output:      hout,
+					Vertical:    true,
+					Interlaced:  cfg.Output.Interlaced,
+					Pack:        cfg.Output.Pack,
+					Threads:     cfg.Threads,
+					DisableAsm:  cfg.DisableAsm,
+					AntiRing:    cfg.AntiRing,
+					Sampling:    cfg.Sampling,
+					Bits:        cfg.Bits,
+					WideOutput:  wide || dithered && win == wout,
+					Border:      cfg.Border,
+					BorderValue: value,
+					ISA:         cfg.ISA,
 				}, filter)
 			})
 		}
 		if win != wout && hin != hout {
+			ctx.wide[i] = wide
 			p := &Plane{
 				Width:  win,
 				Height: hout,
-				Pitch:  align(win*cfg.Input.Pack, 16),
+				Pitch:  align(win*cfg.Input.Pack*int(1+bin(wide)), 16),
 				Pack:   cfg.Input.Pack,
 			}
 			size += p.Pitch * p.Height
@@ -286,13 +377,18 @@ func NewConverter(cfg *ConverterConfig, filter Filter) (Converter, error) {
 		idx := 0
 		for i := 0; i < cfg.Output.Planes; i++ {
 			if p := ctx.buffer[i]; p != nil {
-				size := p.Pitch*(p.Height-1) + p.Width*p.Pack
+				size := p.Pitch*(p.Height-1) + p.Width*p.Pack*int(1+bin(ctx.wide[i]))
 				p.Data = buffer[idx : idx+size]
 				idx += p.Pitch * p.Height
 			}
 		}
 	}
 	group.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
 	return ctx, nil
 }
 
@@ -333,6 +429,22 @@ func inspect(data image.Image, interlaced bool) (*Descriptor, []Plane, error) {
 	return nil, nil, fmt.Errorf("unknown image format")
 }
 
+// newImageLike allocates a w*h image with the same format as img
+func newImageLike(img image.Image, w, h int) (image.Image, error) {
+	rect := image.Rect(0, 0, w, h)
+	switch t := img.(type) {
+	case *image.YCbCr:
+		return image.NewYCbCr(rect, t.SubsampleRatio), nil
+	case *image.RGBA:
+		return image.NewRGBA(rect), nil
+	case *image.NRGBA:
+		return image.NewNRGBA(rect), nil
+	case *image.Gray:
+		return image.NewGray(rect), nil
+	}
+	return nil, fmt.Errorf("unknown image format")
+}
+
 func getYuvDescriptor(img *image.YCbCr, interlaced bool) Descriptor {
 	return Descriptor{
 		Width:      img.Rect.Dx(),
@@ -439,24 +551,48 @@ func inspectGray(img *image.Gray, interlaced bool) (*Descriptor, []Plane) {
 	return &d, getGrayPlane(img, &d)
 }
 
+func scalePlane(dst, src, buf *Plane, hrez, wrez Resizer) {
+	hdst := dst
+	wsrc := src
+	if hrez != nil && wrez != nil {
+		hdst = buf
+		wsrc = buf
+	}
+	if hrez != nil {
+		hrez.Resize(hdst.Data, src.Data, src.Width, src.Height, hdst.Pitch, src.Pitch)
+	}
+	if wrez != nil {
+		wrez.Resize(dst.Data, wsrc.Data, wsrc.Width, wsrc.Height, dst.Pitch, wsrc.Pitch)
+	}
+	if hrez == nil && wrez == nil {
+		copyPlane(dst.Data, src.Data, src.Width*src.Pack, src.Height, dst.Pitch, src.Pitch)
+	}
+}
+
 func resizePlane(group *sync.WaitGroup, threads int, dst, src, buf *Plane, hrez, wrez Resizer) {
 	dispatch(group, threads, func() {
-		hdst := dst
-		wsrc := src
-		if hrez != nil && wrez != nil {
-			hdst = buf
-			wsrc = buf
-		}
-		if hrez != nil {
-			hrez.Resize(hdst.Data, src.Data, src.Width, src.Height, hdst.Pitch, src.Pitch)
-		}
-		if wrez != nil {
-			wrez.Resize(dst.Data, wsrc.Data, wsrc.Width, wsrc.Height, dst.Pitch, wsrc.Pitch)
+		scalePlane(dst, src, buf, hrez, wrez)
+	})
+}
+
+func (ctx *converterContext) Scalers() []ScalerInfo {
+	infos := []ScalerInfo{}
+	add := func(r Resizer, width int) {
+		if r != nil {
+			infos = append(infos, r.Scalers(width)...)
 		}
-		if hrez == nil && wrez == nil {
-			copyPlane(dst.Data, src.Data, src.Width*src.Pack, src.Height, dst.Pitch, src.Pitch)
+	}
+	for i := 0; i < ctx.Output.Planes; i++ {
+		width := ctx.Input.GetWidth(i)
+		for _, s := range ctx.stages[i] {
+			add(s.hrez, width)
+			add(s.wrez, width)
+			width = s.output.Width
 		}
-	})
+		add(ctx.hrez[i], width)
+		add(ctx.wrez[i], width)
+	}
+	return infos
 }
 
 func (ctx *converterContext) Convert(output, input image.Image) error {
@@ -472,12 +608,36 @@ func (ctx *converterContext) Convert(output, input image.Image) error {
 	if err != nil {
 		return err
 	}
+	ctx.convert(dst, src)
+	return nil
+}
+
+// convert converts src planes into dst planes
+func (ctx *converterContext) convert(dst, src []Plane) {
 	group := sync.WaitGroup{}
 	for i := 0; i < ctx.Input.Planes; i++ {
+		if ctx.ewa[i] != nil {
+			resizePlane(&group, ctx.Threads, &dst[i], &src[i], nil, nil, ctx.ewa[i])
+			continue
+		}
+		if len(ctx.stages[i]) > 0 || ctx.dither[i] != nil {
+			idx := i
+			dispatch(&group, ctx.Threads, func() {
+				s := ctx.runStages(idx, &src[idx])
+				d := &dst[idx]
+				if ctx.dither[idx] != nil {
+					d = ctx.dither[idx]
+				}
+				scalePlane(d, s, ctx.buffer[idx], ctx.hrez[idx], ctx.wrez[idx])
+				if ctx.dither[idx] != nil {
+					ditherPlane(&dst[idx], d, ctx.Dither[idx], ctx.Output.Interlaced, ctx.Threads)
+				}
+			})
+			continue
+		}
 		resizePlane(&group, ctx.Threads, &dst[i], &src[i], ctx.buffer[i], ctx.hrez[i], ctx.wrez[i])
 	}
 	group.Wait()
-	return nil
 }
 
 // PrepareConversion returns a ConverterConfig properly set for a conversion
@@ -0,0 +1,262 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ISA is an instruction set used by scalers
+type ISA int
+
+const (
+	// ISAAuto selects the best instruction set supported by the cpu
+	ISAAuto ISA = iota
+	// ISAGo uses pure Go scalers
+	ISAGo
+	// ISASSE2 uses SSE2 scalers
+	ISASSE2
+	// ISASSSE3 uses SSSE3 scalers
+	ISASSSE3
+	// ISASSE41 has no dedicated scalers yet and picks SSSE3 ones
+	ISASSE41
+	// ISAAVX2 uses AVX2 scalers
+	ISAAVX2
+	// ISAAVX512BW has no dedicated scalers yet and picks AVX2 ones
+	ISAAVX512BW
+)
+
+var isaNames = []string{"auto", "go", "sse2", "ssse3", "sse4.1", "avx2", "avx512bw"}
+
+func (i ISA) String() string {
+	if i < ISAAuto || int(i) >= len(isaNames) {
+		return fmt.Sprintf("ISA(%d)", int(i))
+	}
+	return isaNames[i]
+}
+
+// ParseISA returns the instruction set named by name, case-insensitive
+func ParseISA(name string) (ISA, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, it := range isaNames {
+		if it == name {
+			return ISA(i), nil
+		}
+	}
+	return ISAAuto, fmt.Errorf("unknown isa %q", name)
+}
+
+const (
+	// IsaEnv is an environment variable which can lower the instruction
+	// set used by every scaler, like REZ_ISA=sse2
+	IsaEnv = "REZ_ISA"
+)
+
+var supportedISA = limitISA(detectISA(), os.Getenv(IsaEnv))
+
+// limitISA returns isa lowered to the one named by env, if any
+func limitISA(isa ISA, env string) ISA {
+	if len(env) == 0 {
+		return isa
+	}
+	limit, err := ParseISA(env)
+	if err != nil || limit == ISAAuto {
+		return isa
+	}
+	if limit < isa {
+		return limit
+	}
+	return isa
+}
+
+// SupportedISA returns the best instruction set supported by both the cpu
+// & the os, lowered by the IsaEnv environment variable
+func SupportedISA() ISA {
+	return supportedISA
+}
+
+// getISA returns the instruction set used by resizes with cfg
+func getISA(cfg *ResizerConfig) ISA {
+	if cfg.DisableAsm {
+		return ISAGo
+	}
+	if cfg.ISA != ISAAuto && cfg.ISA < supportedISA {
+		return cfg.ISA
+	}
+	return supportedISA
+}
+
+type scalerKind int
+
+const (
+	horizontalScaler scalerKind = iota
+	verticalScaler
+	packedScaler
+	verticalFoldScaler    // symmetric coeffs only
+	shuffleScaler         // horizontal, source windows fitting simd lanes only
+	horizontal16Scaler    // 16-bit samples
+	vertical16Scaler      // 16-bit samples
+	horizontal8to16Scaler // 8-bit sources, 16-bit destinations
+	horizontal16to8Scaler // 16-bit sources, 8-bit destinations
+	vertical8to16Scaler   // 8-bit sources, 16-bit destinations
+)
+
+// ScalerInfo describes a scaler implementation
+type ScalerInfo struct {
+	Name string // scaler name
+	ISA  ISA    // instruction set
+	Taps int    // taps per output pixel, 0 for any
+}
+
+type scalerEntry struct {
+	ScalerInfo
+	fn       scaler
+	minWidth int // narrower slices use the best lower isa scaler, 0 for none
+}
+
+var scalerRegistry = map[scalerKind][]scalerEntry{}
+
+// registerScaler registers a kind scaler implemented with isa
+// taps = number of taps handled, 0 for any
+func registerScaler(kind scalerKind, isa ISA, taps int, name string, fn scaler) {
+	scalerRegistry[kind] = append(scalerRegistry[kind], scalerEntry{
+		ScalerInfo{name, isa, taps}, fn, 0,
+	})
+}
+
+// hasScalers returns whether any scaler is implemented with isa
+func hasScalers(isa ISA) bool {
+	for _, entries := range scalerRegistry {
+		for _, e := range entries {
+			if e.ISA == isa {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setScalerMinWidth makes kind scalers implemented with isa hand slices
+// narrower than width bytes over to lower instruction sets
+func setScalerMinWidth(kind scalerKind, isa ISA, width int) {
+	for i := range scalerRegistry[kind] {
+		if e := &scalerRegistry[kind][i]; e.ISA == isa {
+			e.minWidth = width
+		}
+	}
+}
+
+// getScaler returns the best kind scaler for taps with at most isa, or nil
+// Dedicated taps scalers are preferred within the same instruction set
+func getScaler(kind scalerKind, taps int, isa ISA) (scaler, ScalerInfo) {
+	var best *scalerEntry
+	for i := range scalerRegistry[kind] {
+		e := &scalerRegistry[kind][i]
+		if e.ISA > isa || e.Taps != 0 && e.Taps != taps {
+			continue
+		}
+		if best == nil || e.ISA > best.ISA || e.ISA == best.ISA && e.Taps != 0 {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil, ScalerInfo{}
+	}
+	return best.fn, best.ScalerInfo
+}
+
+// getNarrowScaler returns the kind scaler for taps replacing the one
+// described by info on slices narrower than the returned width in bytes,
+// which is 0 if info scaler handles any width
+func getNarrowScaler(kind scalerKind, taps int, info ScalerInfo) (scaler, ScalerInfo, int) {
+	for _, e := range scalerRegistry[kind] {
+		if e.ScalerInfo != info || e.minWidth == 0 {
+			continue
+		}
+		if fn, narrow := getScaler(kind, taps, info.ISA-1); fn != nil {
+			return fn, narrow, e.minWidth
+		}
+	}
+	return nil, ScalerInfo{}, 0
+}
+
+// deringer applies anti-ringing on 8-bit samples, see v8deringGo
+type deringer func(dst, src []byte, near []int32, strength, width, height, dp, sp int)
+
+type deringEntry struct {
+	vertical bool
+	isa      ISA
+	fn       deringer
+}
+
+var deringRegistry []deringEntry
+
+// registerDeringer registers a vertical or horizontal anti-ringing pass
+// implemented with isa, horizontal ones only handle unpacked samples
+func registerDeringer(vertical bool, isa ISA, fn deringer) {
+	deringRegistry = append(deringRegistry, deringEntry{vertical, isa, fn})
+}
+
+// getDeringer returns the best vertical or horizontal anti-ringing pass
+// implemented with isa or lower, nil if none
+func getDeringer(vertical bool, isa ISA) deringer {
+	var best *deringEntry
+	for i := range deringRegistry {
+		e := &deringRegistry[i]
+		if e.vertical != vertical || e.isa > isa {
+			continue
+		}
+		if best == nil || e.isa > best.isa {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.fn
+}
+
+func init() {
+	for _, it := range []struct {
+		kind scalerKind
+		taps int
+		name string
+		fn   scaler
+	}{
+		{horizontalScaler, 2, "h8scale2Go", h8scale2Go},
+		{horizontalScaler, 4, "h8scale4Go", h8scale4Go},
+		{horizontalScaler, 6, "h8scale6Go", h8scale6Go},
+		{horizontalScaler, 8, "h8scale8Go", h8scale8Go},
+		{horizontalScaler, 10, "h8scale10Go", h8scale10Go},
+		{horizontalScaler, 12, "h8scale12Go", h8scale12Go},
+		{horizontalScaler, 0, "h8scaleNGo", h8scaleNGo},
+		{verticalScaler, 2, "v8scale2Go", v8scale2Go},
+		{verticalScaler, 4, "v8scale4Go", v8scale4Go},
+		{verticalScaler, 6, "v8scale6Go", v8scale6Go},
+		{verticalScaler, 8, "v8scale8Go", v8scale8Go},
+		{verticalScaler, 10, "v8scale10Go", v8scale10Go},
+		{verticalScaler, 12, "v8scale12Go", v8scale12Go},
+		{verticalScaler, 0, "v8scaleNGo", v8scaleNGo},
+		{packedScaler, 0, "h8p4scaleNGo", h8p4scaleNGo},
+		{horizontal16Scaler, 2, "h16scale2Go", h16scale2Go},
+		{horizontal16Scaler, 4, "h16scale4Go", h16scale4Go},
+		{horizontal16Scaler, 6, "h16scale6Go", h16scale6Go},
+		{horizontal16Scaler, 8, "h16scale8Go", h16scale8Go},
+		{horizontal16Scaler, 10, "h16scale10Go", h16scale10Go},
+		{horizontal16Scaler, 12, "h16scale12Go", h16scale12Go},
+		{horizontal16Scaler, 0, "h16scaleNGo", h16scaleNGo},
+		{vertical16Scaler, 2, "v16scale2Go", v16scale2Go},
+		{vertical16Scaler, 4, "v16scale4Go", v16scale4Go},
+		{vertical16Scaler, 6, "v16scale6Go", v16scale6Go},
+		{vertical16Scaler, 8, "v16scale8Go", v16scale8Go},
+		{vertical16Scaler, 10, "v16scale10Go", v16scale10Go},
+		{vertical16Scaler, 12, "v16scale12Go", v16scale12Go},
+		{vertical16Scaler, 0, "v16scaleNGo", v16scaleNGo},
+	} {
+		registerScaler(it.kind, ISAGo, it.taps, it.name, it.fn)
+	}
+}
@@ -5,6 +5,7 @@
 package rez
 
 import (
+	"fmt"
 	"math"
 	"sort"
 )
@@ -13,7 +14,21 @@ type kernel struct {
 	coeffs   []int16
 	offsets  []int16
 	size     int
-	cofscale int // how many more coeffs do we have
+	cofscale int  // how many more coeffs do we have
+	start    int  // first output pixel
+	count    int  // number of output pixels
+	pack     int  // bytes per scaler pixel
+	block    int  // output pixels per column block, columns split on blocks
+	blockcof int  // horizontal coeffs per column block
+	rowcof   int  // vertical coeffs per output row
+	strip    int  // padded strip read by the kernel, -1 for source planes
+	split    bool // true if split from the previous run on padded borders
+	sym      bool // true if every pixel coeffs are symmetric
+	scaler   scaler
+	info     ScalerInfo
+	narrow   scaler     // replaces scaler on slices narrower than minWidth
+	nInfo    ScalerInfo // narrow scaler info
+	minWidth int        // in bytes, 0 if scaler handles any width
 }
 
 func bin(v bool) uint {
@@ -41,13 +56,13 @@ func max(a, b int) int {
 }
 
 func makeDoubleKernel(cfg *ResizerConfig, filter Filter, field, idx uint) ([]int16, []float64, []float64, int, int) {
+	if cfg.Sampling == SamplingArea {
+		return makeAreaKernel(cfg, field, idx)
+	}
 	scale := float64(cfg.Output) / float64(cfg.Input)
 	step := math.Min(1, scale)
-	support := float64(filter.Taps()) / step
+	support := getSupport(filter) / step
 	taps := int(math.Ceil(support)) * 2
-	if !cfg.Vertical && taps == 6 && hasAsm() && !cfg.DisableAsm {
-		taps = 8
-	}
 	taps = min(taps, (cfg.Input>>field)&^1)
 	offsets := make([]int16, cfg.Output)
 	sums := make([]float64, cfg.Output)
@@ -59,9 +74,14 @@ func makeDoubleKernel(cfg *ResizerConfig, filter Filter, field, idx uint) ([]int
 	size := (cfg.Output + int(field*(1-idx))) >> field
 	step /= float64(1 + field)
 	xmid += xstep * float64(field*idx)
+	// padded borders read source pixels beyond edges
+	padded := isPadded(cfg.Border)
 	for i := 0; i < size; i++ {
 		left := int(math.Ceil(xmid)) - ftaps>>1
-		x := clip(left, 0, max(0, cfg.Input-ftaps))
+		x := left
+		if !padded {
+			x = clip(left, 0, max(0, cfg.Input-ftaps))
+		}
 		offsets[i] = int16(x)
 		for j := 0; j < ftaps; j++ {
 			src := left + j
@@ -69,8 +89,13 @@ func makeDoubleKernel(cfg *ResizerConfig, filter Filter, field, idx uint) ([]int
 				continue
 			}
 			weight := filter.Get(math.Abs(xmid-float64(src)) * step)
-			src = clip(src, x, cfg.Input-1) - x
-			src >>= field
+			if cfg.Border == BorderMirror {
+				src = getFieldIndex(src, cfg.Input, cfg.Border, field)
+			}
+			if !padded {
+				src = clip(src, x, cfg.Input-1)
+			}
+			src = (src - x) >> field
 			weights[i*taps+src] += weight
 			sums[i] += weight
 		}
@@ -98,58 +123,397 @@ func (w weights) Swap(i, j int) {
 	w[i], w[j] = w[j], w[i]
 }
 
-func makeIntegerKernel(taps, size int, cof, sums []float64, pos []int16, field, idx uint) ([]int16, []int16) {
+// isMirrored returns whether even-sized cof is symmetric, ignoring rounding
+// errors
+func isMirrored(cof []float64) bool {
+	if len(cof)&1 != 0 {
+		return false
+	}
+	eps := float64(0)
+	for _, w := range cof {
+		eps = math.Max(eps, math.Abs(w))
+	}
+	eps *= 1e-9
+	for i, j := 0, len(cof)-1; i < j; i, j = i+1, j-1 {
+		if math.Abs(cof[i]-cof[j]) > eps {
+			return false
+		}
+	}
+	return true
+}
+
+// wideShift returns the number of fractional bits removed from sums of
+// default bits coeffs times 8.8 fixed point samples, where in & out are set
+// for 16-bit sources & destinations
+func wideShift(in, out bool) uint {
+	return Bits + 8*bin(in) - 8*bin(out)
+}
+
+// getWideKind returns the kind of asm scalers reading & writing cfg wide
+// samples, or false if there are none
+func getWideKind(cfg *ResizerConfig) (scalerKind, bool) {
+	in, out := cfg.WideInput, cfg.WideOutput
+	switch {
+	case cfg.Bits != Bits:
+	case in && out && cfg.Vertical:
+		return vertical16Scaler, true
+	case in && out:
+		return horizontal16Scaler, true
+	case out && cfg.Vertical:
+		return vertical8to16Scaler, true
+	case out:
+		return horizontal8to16Scaler, true
+	case in && !cfg.Vertical:
+		return horizontal16to8Scaler, true
+	}
+	return 0, false
+}
+
+// fitsWide returns whether wide asm scalers sum every taps coeffs of cof
+// times biased samples & their bias dword within 32 bits
+func fitsWide(cof []int16, taps int, in, out bool) bool {
+	top, bias := 0xFF, 0
+	if in {
+		top, bias = 0xFFFF, 0x8000
+	}
+	round := 1 << (wideShift(in, out) - 1)
+	if out {
+		round -= 0x8000 << wideShift(in, out)
+	}
+	for i := 0; i < len(cof); i += taps {
+		pos, neg := 0, 0
+		for _, v := range cof[i : i+taps] {
+			if v > 0 {
+				pos += int(v)
+			} else {
+				neg -= int(v)
+			}
+		}
+		lo, hi := round-neg*top, round+pos*top
+		if (pos+neg)*max(bias, top-bias) > math.MaxInt32 ||
+			lo < math.MinInt32 || hi > math.MaxInt32 {
+			return false
+		}
+	}
+	return true
+}
+
+// makeIntegerKernel returns fixed-point coeffs with bits fractional bits
+// Returns an error if a kernel sums to zero, if one coeff overflows int16
+// or if one output pixel may overflow an int32 accumulator
+func makeIntegerKernel(taps, size int, cof, sums []float64, pos []int16, field, idx, bits uint) ([]int16, []int16, error) {
 	coeffs := make([]int16, taps*size)
 	offsets := make([]int16, size)
 	weights := make(weights, taps)
 	for i, sum := range sums[:size] {
-		for j, w := range cof[:taps] {
+		if sum == 0 || math.IsNaN(sum) || math.IsInf(sum, 0) {
+			return nil, nil, fmt.Errorf("invalid kernel sum %v at pixel %v", sum, i)
+		}
+		half := taps
+		if isMirrored(cof[:taps]) {
+			// round one half only so mirrored taps stay equal
+			half = taps >> 1
+		}
+		for j, w := range cof[:half] {
 			weights[j].weight = w
 			weights[j].offset = j
 		}
-		sort.Sort(weights)
+		sort.Sort(weights[:half])
 		diff := float64(0)
-		scale := 1 << Bits / sum
-		for _, it := range weights {
+		scale := float64(int(1)<<bits) / sum
+		total := 0
+		for _, it := range weights[:half] {
 			w := it.weight*scale + diff
 			iw := math.Floor(w + 0.5)
+			if iw > math.MaxInt16 || iw < math.MinInt16 || math.IsNaN(iw) {
+				return nil, nil, fmt.Errorf("coefficient %v overflows at pixel %v, use fewer bits", w, i)
+			}
 			coeffs[i*taps+it.offset] = int16(iw)
+			total += int(math.Abs(iw))
+			if half < taps {
+				coeffs[i*taps+taps-1-it.offset] = int16(iw)
+				total += int(math.Abs(iw))
+			}
 			diff = w - iw
 		}
+		if total*0xFF+1<<(bits-1) > math.MaxInt32 {
+			return nil, nil, fmt.Errorf("accumulator overflows at pixel %v", i)
+		}
 		cof = cof[taps:]
 		off := pos[i] + int16(field-idx)
 		offsets[i] = off >> field
 	}
-	return coeffs, offsets
+	return coeffs, offsets, nil
 }
 
-func makeKernel(cfg *ResizerConfig, filter Filter, idx uint) kernel {
+func makeKernel(cfg *ResizerConfig, filter Filter, idx uint) ([]kernel, int, error) {
 	field := bin(cfg.Interlaced)
 	pos, sums, cof, taps, size := makeDoubleKernel(cfg, filter, field, idx)
-	coeffs, offsets := makeIntegerKernel(taps, size, cof, sums, pos, field, idx)
-	//coeffs, offsets = reduceKernel(coeffs, offsets, taps, size)
-	if cfg.Vertical {
-		for i := len(offsets) - 1; i > 0; i-- {
-			offsets[i] = offsets[i] - offsets[i-1]
+	coeffs, offsets, err := makeIntegerKernel(taps, size, cof, sums, pos, field, idx, uint(cfg.Bits))
+	if err != nil {
+		return nil, 0, err
+	}
+	isa := getISA(cfg)
+	wide := cfg.WideInput || cfg.WideOutput
+	wkind, hasWide := getWideKind(cfg)
+	// rgba pixels have dedicated scalers
+	packed := !cfg.Vertical && cfg.Pack == 4 && !wide
+	fsize := (cfg.Input + int(field*(1-idx))) >> field
+	margin := 0
+	if isPadded(cfg.Border) {
+		// offsets are relative to padded planes
+		margin = getBorderMargin(taps, field)
+		for i := range offsets {
+			offsets[i] += int16(margin >> field)
+		}
+		fsize += 2 * margin >> field
+	}
+	kernels := reduceKernel(coeffs, offsets, taps, size, fsize)
+	for i := range kernels {
+		k := &kernels[i]
+		k.pack = 1
+		// wide asm scalers use biased coeffs
+		biased := false
+		switch {
+		case cfg.Vertical:
+			// columns share coeffs, blocks only keep simd loads whole
+			k.block = columnBlock
+			for j := len(k.offsets) - 1; j > 0; j-- {
+				k.offsets[j] = k.offsets[j] - k.offsets[j-1]
+			}
+			kind := verticalScaler
+			k.scaler, k.info = getScaler(kind, k.size, isa)
+			if fold, info := getScaler(verticalFoldScaler, k.size, isa); k.sym && fold != nil {
+				kind = verticalFoldScaler
+				k.scaler, k.info = fold, info
+			}
+			k.narrow, k.nInfo, k.minWidth = getNarrowScaler(kind, k.size, k.info)
+			if cfg.Bits != Bits {
+				k.scaler = newVerticalBitsScaler(uint(cfg.Bits))
+				k.info = ScalerInfo{"v8scaleBitsGo", ISAGo, 0}
+				k.minWidth = 0
+			}
+			if cfg.WideOutput {
+				k.scaler = newVerticalWideScaler(uint(cfg.Bits), cfg.WideInput)
+				k.info = ScalerInfo{"v16scaleWideGo", ISAGo, 0}
+				k.minWidth = 0
+			}
+			if hasWide && fitsWide(k.coeffs, k.size, cfg.WideInput, cfg.WideOutput) {
+				if fn, info := getScaler(wkind, k.size, isa); fn != nil {
+					k.scaler, k.info, biased = fn, info, true
+				}
+			}
+		case packed:
+			for j := range k.offsets {
+				k.offsets[j] <<= 2
+			}
+			k.pack = cfg.Pack
+			k.scaler, k.info = getScaler(packedScaler, k.size, isa)
+			if cfg.Bits != Bits {
+				k.scaler = newPackedBitsScaler(uint(cfg.Bits))
+				k.info = ScalerInfo{"h8p4scaleBitsGo", ISAGo, 0}
+			}
+			k.coeffs, k.cofscale = preparePackedCoeffs(k.coeffs, k.info.ISA)
+			k.block, k.blockcof = 1, k.size*k.cofscale
+			continue
+		default:
+			if cfg.Pack > 1 {
+				k.coeffs, k.offsets, k.size = unpack(k.coeffs, k.offsets, k.size, cfg.Pack)
+			}
+			k.scaler, k.info = getScaler(horizontalScaler, k.size, isa)
+			if cfg.Bits != Bits {
+				k.scaler = newHorizontalBitsScaler(uint(cfg.Bits))
+				k.info = ScalerInfo{"h8scaleBitsGo", ISAGo, 0}
+			}
+			if wide {
+				k.scaler = newHorizontalWideScaler(uint(cfg.Bits), cfg.WideInput, cfg.WideOutput)
+				k.info = ScalerInfo{"h16scaleWideGo", ISAGo, 0}
+			}
+			if hasWide && fitsWide(k.coeffs, k.size, cfg.WideInput, cfg.WideOutput) {
+				if fn, info := getScaler(wkind, k.size, isa); fn != nil {
+					k.scaler, k.info, biased = fn, info, true
+				}
+			}
+			// shuffles replace gathers when source windows fit in simd lanes
+			// shuffle coeffs hold absolute offsets which break on padded strips
+			if fn, info := getScaler(shuffleScaler, k.size, isa); fn != nil && k.info.ISA != ISAGo && !wide && !isPadded(cfg.Border) {
+				cof, ok := prepareShuffleCoeffs(k.coeffs, k.offsets, len(k.offsets), k.size, fsize*cfg.Pack, info.ISA)
+				if ok {
+					k.scaler, k.info = fn, info
+					k.coeffs, k.cofscale = cof, 2
+					// 16 pixels per block, see prepareShuffleCoeffs
+					k.block = columnBlock
+					k.blockcof = columnBlock / 16 * (16*k.size + 8*k.size + 8)
+					continue
+				}
+			}
 		}
+		if biased && k.info.ISA != ISAGo {
+			// groups of 4 pixels or rows end with one bias dword per sample
+			k.coeffs = prepare16Coeffs(k.coeffs, len(k.offsets), k.size, cfg.Vertical,
+				cfg.WideInput, cfg.WideOutput, k.info.ISA)
+			k.cofscale = 1
+			k.rowcof = 4*k.size + 8
+			k.block = columnBlock
+			k.blockcof = columnBlock / 4 * (4*k.size + 8)
+			continue
+		}
+		k.coeffs, k.cofscale = prepareCoeffs(cfg, k.coeffs, k.count, k.size, k.info.ISA)
+		if cfg.Vertical {
+			k.rowcof = k.size * k.cofscale
+			continue
+		}
+		// simd coeffs are interleaved by blocks of up to 32 pixels
+		k.block = 1
+		if k.info.ISA != ISAGo {
+			k.block = columnBlock
+		}
+		k.blockcof = k.block * k.size * k.cofscale
+	}
+	return kernels, margin, nil
+}
+
+const (
+	// minimum number of output pixels sharing a reduced kernel
+	minKernelRun = 16
+	// output pixels per column block on simd scalers
+	columnBlock = 32
+)
 
-	} else if cfg.Pack > 1 {
-		coeffs, offsets, taps = unpack(coeffs, offsets, taps, cfg.Pack)
+// isSymmetric returns whether cof is the same when reversed
+func isSymmetric(cof []int16) bool {
+	for i, j := 0, len(cof)-1; i < j; i, j = i+1, j-1 {
+		if cof[i] != cof[j] {
+			return false
+		}
 	}
-	coeffs, cofscale := prepareCoeffs(cfg, coeffs, size, taps)
-	return kernel{coeffs, offsets, taps, cofscale}
+	return true
 }
 
-func prepareCoeffs(cfg *ResizerConfig, cof []int16, size, taps int) ([]int16, int) {
-	if !hasAsm() || cfg.DisableAsm {
+// getKernelTaps returns the number of taps between the first & the last
+// non-zero coefficients, rounded up to the next even number
+func getKernelTaps(cof []int16) (int, int) {
+	left := 0
+	for left < len(cof)-1 && cof[left] == 0 {
+		left++
+	}
+	right := len(cof) - 1
+	for right > left && cof[right] == 0 {
+		right--
+	}
+	taps := right - left + 1
+	return left, taps + taps&1
+}
+
+// reduceKernel trims leading & trailing zero coefficients of every output
+// pixel, then splits output pixels into runs of at least minKernelRun pixels
+// sharing the same number of taps & symmetry, each run is returned as one
+// kernel
+// fsize = number of source pixels
+func reduceKernel(coeffs, offsets []int16, taps, size, fsize int) []kernel {
+	lefts := make([]int, size)
+	runs := []kernel{}
+	for i := 0; i < size; i++ {
+		left, n := getKernelTaps(coeffs[i*taps : i*taps+taps])
+		lefts[i] = left
+		sym := left+n <= taps && isSymmetric(coeffs[i*taps+left:i*taps+left+n])
+		if last := len(runs) - 1; last >= 0 && runs[last].size == n && runs[last].sym == sym {
+			runs[last].count++
+			continue
+		}
+		runs = append(runs, kernel{size: n, start: i, count: 1, sym: sym})
+	}
+	// merge short runs with their neighbours, but keep long symmetric runs
+	// apart so they can use folded scalers
+	kernels := []kernel{}
+	keep := func(k *kernel) bool { return k.sym && k.count >= minKernelRun }
+	for _, r := range runs {
+		last := len(kernels) - 1
+		if last >= 0 && (r.size == kernels[last].size && r.sym == kernels[last].sym ||
+			(r.count < minKernelRun || kernels[last].count < minKernelRun) &&
+				!keep(&r) && !keep(&kernels[last])) {
+			kernels[last].sym = kernels[last].sym && r.sym && kernels[last].size == r.size
+			kernels[last].size = max(kernels[last].size, r.size)
+			kernels[last].count += r.count
+			continue
+		}
+		kernels = append(kernels, r)
+	}
+	if n := len(kernels); n > 1 && kernels[n-1].count < minKernelRun && !keep(&kernels[n-2]) {
+		kernels[n-2].sym = kernels[n-2].sym && kernels[n-1].sym && kernels[n-2].size == kernels[n-1].size
+		kernels[n-2].size = max(kernels[n-2].size, kernels[n-1].size)
+		kernels[n-2].count += kernels[n-1].count
+		kernels = kernels[:n-1]
+	}
+	for i := range kernels {
+		k := &kernels[i]
+		k.coeffs = make([]int16, k.count*k.size)
+		k.offsets = make([]int16, k.count)
+		for j := 0; j < k.count; j++ {
+			src := k.start + j
+			offset := int(offsets[src])
+			// keep every tap inside the source
+			next := min(offset+lefts[src], fsize-k.size)
+			k.offsets[j] = int16(next)
+			for t := 0; t < k.size; t++ {
+				if x := next + t - offset; x >= 0 && x < taps {
+					k.coeffs[j*k.size+t] = coeffs[src*taps+x]
+				}
+			}
+			// pixels clipped on edges may lose their symmetry
+			k.sym = k.sym && isSymmetric(k.coeffs[j*k.size:(j+1)*k.size])
+		}
+	}
+	return kernels
+}
+
+// makeNearKernel returns for every output pixel the index of the source
+// pixel just before its center, in field units when interlaced
+func makeNearKernel(cfg *ResizerConfig, field, idx uint) []int32 {
+	scale := float64(cfg.Output) / float64(cfg.Input)
+	xmid := float64(cfg.Input-cfg.Output) / float64(cfg.Output*2)
+	xstep := 1 / scale
+	size := (cfg.Output + int(field*(1-idx))) >> field
+	fsize := (cfg.Input + int(field*(1-idx))) >> field
+	xmid += xstep * float64(field*idx)
+	near := make([]int32, size)
+	for i := range near {
+		pos := (xmid - float64(field*idx)) / float64(1+field)
+		near[i] = int32(clip(int(math.Floor(pos)), 0, max(0, fsize-2)))
+		xmid += xstep * float64(1+field)
+	}
+	return near
+}
+
+// prepareCoeffs returns coeffs in the layout expected by isa scalers
+func prepareCoeffs(cfg *ResizerConfig, cof []int16, size, taps int, isa ISA) ([]int16, int) {
+	if isa == ISAGo {
 		return cof, 1
 	}
 	if cfg.Vertical {
 		return prepareVerticalCoeffs(cof, size, taps)
 	}
+	if isa == ISAAVX2 {
+		return prepareAvx2HorizontalCoeffs(cof, size*cfg.Pack, taps), 1
+	}
 	return prepareHorizontalCoeffs(cof, size*cfg.Pack, taps), 1
 }
 
+// preparePackedCoeffs repeats every pair of coeffs on each rgba channel
+func preparePackedCoeffs(cof []int16, isa ISA) ([]int16, int) {
+	if isa == ISAGo {
+		return cof, 1
+	}
+	dst := make([]int16, len(cof)*4)
+	for i := 0; i < len(cof); i += 2 {
+		for j := 0; j < 4; j++ {
+			dst[i*4+j*2+0] = cof[i+0]
+			dst[i*4+j*2+1] = cof[i+1]
+		}
+	}
+	return dst, 4
+}
+
 func prepareVerticalCoeffs(cof []int16, size, taps int) ([]int16, int) {
 	xwidth := 16
 	dst := make([]int16, size*taps*xwidth>>1)
@@ -198,6 +562,134 @@ func prepareHorizontalCoeffs(cof []int16, size, taps int) []int16 {
 	return dst
 }
 
+// prepare16Coeffs returns coeffs for wide simd scalers, which bias 16-bit
+// samples to signed words before pmaddwd. Every group of output samples
+// is followed by one dword per sample removing this bias, rounding and
+// biasing 16-bit results again so packssdw can saturate them. Horizontal
+// groups interleave coeffs pairs of 4 pixels, vertical groups repeat pairs
+// 4 times and remaining horizontal pixels keep raw coeffs
+// in & out are set for 16-bit sources & destinations
+func prepare16Coeffs(cof []int16, size, taps int, vertical, in, out bool, isa ISA) []int16 {
+	if isa == ISAGo {
+		return cof
+	}
+	dst := []int16{}
+	shift := wideShift(in, out)
+	bias := func(cof []int16) {
+		sum := 0
+		for _, v := range cof {
+			sum += int(v)
+		}
+		v := int32(1 << (shift - 1))
+		if in {
+			v += int32(sum << 15)
+		}
+		if out {
+			v -= 0x8000 << shift
+		}
+		dst = append(dst, int16(v), int16(v>>16))
+	}
+	if vertical {
+		for i := 0; i < size; i++ {
+			c := cof[i*taps : (i+1)*taps]
+			for j := 0; j < taps; j += 2 {
+				for k := 0; k < 4; k++ {
+					dst = append(dst, c[j], c[j+1])
+				}
+			}
+			for k := 0; k < 4; k++ {
+				bias(c)
+			}
+		}
+		return dst
+	}
+	n := size &^ 3
+	for i := 0; i < n; i += 4 {
+		for j := 0; j < taps; j += 2 {
+			for k := i; k < i+4; k++ {
+				dst = append(dst, cof[k*taps+j], cof[k*taps+j+1])
+			}
+		}
+		for k := i; k < i+4; k++ {
+			bias(cof[k*taps : (k+1)*taps])
+		}
+	}
+	return append(dst, cof[n*taps:]...)
+}
+
+// prepareShuffleCoeffs appends to raw coeffs of every block of 16 pixels the
+// shuffle masks & source window offsets used by shuffle scalers, avx2 blocks
+// are interleaved every 16 bytes like prepareAvx2HorizontalCoeffs
+// Returns false if one window does not fit in one simd lane
+// rowlen = number of bytes per source line
+func prepareShuffleCoeffs(cof, off []int16, size, taps, rowlen int, isa ISA) ([]int16, bool) {
+	xwidth := 16
+	lanes := 1
+	if isa == ISAAVX2 {
+		lanes = 2
+	}
+	group := xwidth / taps
+	block := xwidth*taps + taps*xwidth/2 + xwidth/2
+	loop := size / (xwidth * lanes) * lanes
+	dst := make([]int16, size*taps*2)
+	buf := make([]int16, block)
+	mask := make([]byte, xwidth)
+	di := 0
+	for i := 0; i < loop; i++ {
+		copy(buf, cof[i*xwidth*taps:(i+1)*xwidth*taps])
+		masks := buf[xwidth*taps:]
+		bases := masks[taps*xwidth/2:]
+		for g := 0; g < taps; g++ {
+			first := i*xwidth + g*group
+			// never load past the end of the line
+			base := min(int(off[first]), rowlen-xwidth)
+			for j := 0; j < group; j++ {
+				x := int(off[first+j]) - base
+				if base < 0 || x < 0 || x+taps > xwidth {
+					return nil, false
+				}
+				for t := 0; t < taps; t++ {
+					mask[j*taps+t] = byte(x + t)
+				}
+			}
+			for j := 0; j < xwidth; j += 2 {
+				masks[g*xwidth/2+j/2] = int16(mask[j]) | int16(mask[j+1])<<8
+			}
+			bases[g] = int16(base)
+		}
+		lane := i % lanes
+		for j := 0; j < block; j += 8 {
+			copy(dst[di+j*lanes+lane*8:], buf[j:j+8])
+		}
+		if lane == lanes-1 {
+			di += block * lanes
+		}
+	}
+	copy(dst[di:], cof[loop*xwidth*taps:])
+	return dst, true
+}
+
+// prepareAvx2HorizontalCoeffs interleaves sse2 coeffs of pixels 0-15 &
+// pixels 16-31 every 16 bytes, as avx2 scalers process 16 pixels per lane
+func prepareAvx2HorizontalCoeffs(cof []int16, size, taps int) []int16 {
+	ywidth := 32
+	n := size / ywidth * ywidth
+	sse := prepareHorizontalCoeffs(cof, n, taps)
+	dst := make([]int16, len(cof))
+	half := ywidth / 2 * taps
+	for i := 0; i < n*taps; i += ywidth * taps {
+		lo := sse[i : i+half]
+		hi := sse[i+half : i+2*half]
+		for j := 0; j < half; j += 8 {
+			copy(dst[i+j*2:], lo[j:j+8])
+			copy(dst[i+j*2+8:], hi[j:j+8])
+		}
+	}
+	// remaining pixels use the scalar tail
+	copy(dst[n*taps:], cof[n*taps:])
+	return dst
+}
+
 func unpack(coeffs, offsets []int16, taps, pack int) ([]int16, []int16, int) {
 	cof := make([]int16, len(coeffs)*pack*pack)
 	off := make([]int16, len(offsets)*pack)
@@ -0,0 +1,96 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+const (
+	// planes are halved until they are within this ratio of their output
+	multiStageRatio = 3
+)
+
+// stage is an intermediate area halving of a plane
+type stage struct {
+	hrez   Resizer
+	wrez   Resizer
+	buffer *Plane // vertical output when both dimensions are halved
+	output *Plane
+}
+
+func newPlane(width, height, pack int) *Plane {
+	p := &Plane{
+		Width:  width,
+		Height: height,
+		Pitch:  align(width*pack, 16),
+		Pack:   pack,
+	}
+	p.Data = make([]byte, p.Pitch*(height-1)+width*pack)
+	return p
+}
+
+// planStages returns intermediate stages needed to bring a win*hin plane
+// within multiStageRatio of wout*hout, with the last stage size
+func planStages(cfg *ConverterConfig, win, hin, wout, hout int) ([]stage, int, int, error) {
+	stages := []stage{}
+	pack := cfg.Input.Pack
+	for win > multiStageRatio*wout || hin > multiStageRatio*hout {
+		s := stage{}
+		w, h := win, hin
+		var err error
+		if win > multiStageRatio*wout {
+			w = win >> 1
+		}
+		if hin > multiStageRatio*hout {
+			h = hin >> 1
+		}
+		if h != hin {
+			s.hrez, err = NewResize(&ResizerConfig{
+				Depth:      8,
+				Input:      hin,
+				Output:     h,
+				Vertical:   true,
+				Interlaced: cfg.Output.Interlaced,
+				Pack:       pack,
+				Threads:    cfg.Threads,
+				DisableAsm: cfg.DisableAsm,
+				Sampling:   SamplingArea,
+				ISA:        cfg.ISA,
+			}, nil)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+		}
+		if w != win {
+			s.wrez, err = NewResize(&ResizerConfig{
+				Depth:      8,
+				Input:      win,
+				Output:     w,
+				Pack:       pack,
+				Threads:    cfg.Threads,
+				DisableAsm: cfg.DisableAsm,
+				Sampling:   SamplingArea,
+				ISA:        cfg.ISA,
+			}, nil)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+		}
+		if s.hrez != nil && s.wrez != nil {
+			s.buffer = newPlane(win, h, pack)
+		}
+		s.output = newPlane(w, h, pack)
+		stages = append(stages, s)
+		win, hin = w, h
+	}
+	return stages, win, hin, nil
+}
+
+// runStages applies every stage of plane idx on src and returns the last
+// intermediate plane
+func (ctx *converterContext) runStages(idx int, src *Plane) *Plane {
+	for _, s := range ctx.stages[idx] {
+		scalePlane(s.output, src, s.buffer, s.hrez, s.wrez)
+		src = s.output
+	}
+	return src
+}
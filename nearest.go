@@ -0,0 +1,106 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"sync"
+)
+
+// nearestResizer copies the source pixel closest to every output pixel
+type nearestResizer struct {
+	cfg     ResizerConfig
+	offsets [][]int // per field source offsets, in bytes when horizontal
+}
+
+// getNearestOffsets returns source pixel indices for every output pixel
+// Output pixel x maps to floor((2x + 1) * input / (2 * output)), when
+// interlaced, every field only reads source pixels from the same field
+func getNearestOffsets(input, output int, field, idx uint) []int {
+	size := (output + int(field*(1-idx))) >> field
+	fsize := (input + int(field*(1-idx))) >> field
+	den := 2 * output << field
+	offsets := make([]int, size)
+	for i := range offsets {
+		x := i<<field | int(idx)
+		num := (2*x+1)*input + int(field)*(1-2*int(idx))*output
+		offsets[i] = clip(num/den, 0, fsize-1)
+	}
+	return offsets
+}
+
+func newNearestResizer(cfg *ResizerConfig) Resizer {
+	r := &nearestResizer{cfg: *cfg}
+	field := bin(cfg.Vertical && cfg.Interlaced)
+	for i := uint(0); i < 1+field; i++ {
+		offsets := getNearestOffsets(cfg.Input, cfg.Output, field, i)
+		if !cfg.Vertical {
+			for j := range offsets {
+				offsets[j] *= cfg.Pack
+			}
+		}
+		r.offsets = append(r.offsets, offsets)
+	}
+	return r
+}
+
+func (r *nearestResizer) Scalers(width int) []ScalerInfo {
+	return nil
+}
+
+func (r *nearestResizer) Resize(dst, src []byte, width, height, dp, sp int) {
+	field := bin(r.cfg.Vertical && r.cfg.Interlaced)
+	pk := r.cfg.Pack
+	group := sync.WaitGroup{}
+	for i, offsets := range r.offsets {
+		dheight := height
+		if r.cfg.Vertical {
+			dheight = len(offsets)
+		}
+		offsets := offsets
+		d := dst[dp*i:]
+		if r.cfg.Vertical {
+			s := src[sp*i:]
+			splitTiles(&group, r.cfg.Threads, width, dheight, columnBlock, func(x, y, w, h int) {
+				v8nearestGo(d[(dp<<field)*y+x*pk:], s[x*pk:], offsets[y:y+h], w*pk, dp<<field, sp<<field)
+			})
+			continue
+		}
+		splitTiles(&group, r.cfg.Threads, len(offsets), dheight, columnBlock, func(x, y, w, h int) {
+			h8nearestGo(d[dp*y+x*pk:], src[sp*y:], offsets[x:x+w], pk, h, dp, sp)
+		})
+	}
+	group.Wait()
+}
+
+func h8nearestGo(dst, src []byte, offsets []int, pack, height, dp, sp int) {
+	width := len(offsets) * pack
+	for y := 0; y < height; y++ {
+		d := dst[dp*y : dp*y+width]
+		s := src[sp*y:]
+		switch pack {
+		case 1:
+			for x, off := range offsets {
+				d[x] = s[off]
+			}
+		case 4:
+			for x, off := range offsets {
+				d[x*4+0] = s[off+0]
+				d[x*4+1] = s[off+1]
+				d[x*4+2] = s[off+2]
+				d[x*4+3] = s[off+3]
+			}
+		default:
+			for x, off := range offsets {
+				copy(d[x*pack:x*pack+pack], s[off:off+pack])
+			}
+		}
+	}
+}
+
+func v8nearestGo(dst, src []byte, offsets []int, width, dp, sp int) {
+	for y, off := range offsets {
+		copy(dst[dp*y:dp*y+width], src[sp*off:sp*off+width])
+	}
+}
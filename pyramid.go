@@ -0,0 +1,156 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"image"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// PyramidConfig is a configuration used with NewPyramid
+type PyramidConfig struct {
+	Cascade    bool // resample each level from the previous one
+	Threads    int  // number of allowed "threads"
+	DisableAsm bool // disable asm optimisations
+}
+
+// Pyramid is an interface that generates mipmap chains
+type Pyramid interface {
+	// Generate computes every level of the src mipmap chain
+	// Level 0 is src itself, every next level is half the size of the
+	// previous one, rounded down, until 1x1 is reached
+	// Returned levels are owned by the pyramid and are reused by the next
+	// call with the same input geometry
+	// Returns an error if src format is not supported
+	Generate(src image.Image) ([]image.Image, error)
+}
+
+type pyramidContext struct {
+	PyramidConfig
+	filter     Filter
+	input      Descriptor
+	kind       reflect.Type // input image type, rgba & nrgba share descriptors
+	levels     []image.Image
+	converters []Converter
+}
+
+// NewPyramid returns a Pyramid interface
+// cfg = pyramid configuration
+// filter = filter used for resizing every level
+func NewPyramid(cfg *PyramidConfig, filter Filter) Pyramid {
+	ctx := &pyramidContext{
+		PyramidConfig: *cfg,
+		filter:        filter,
+	}
+	if ctx.Threads == 0 {
+		ctx.Threads = runtime.GOMAXPROCS(0)
+	}
+	return ctx
+}
+
+func getPyramidSizes(width, height int) []image.Point {
+	sizes := []image.Point{{width, height}}
+	for width > 1 || height > 1 {
+		width = max(1, width>>1)
+		height = max(1, height>>1)
+		sizes = append(sizes, image.Point{width, height})
+	}
+	return sizes
+}
+
+// getLevelThreads splits threads between n levels resized at once, larger
+// levels get remaining threads first & every level gets at least one
+func getLevelThreads(threads, n int) []int {
+	list := make([]int, n)
+	for i := range list {
+		list[i] = max(1, threads/n)
+		if threads > n && i < threads%n {
+			list[i]++
+		}
+	}
+	return list
+}
+
+func (ctx *pyramidContext) prepare(src image.Image, d *Descriptor) error {
+	sizes := getPyramidSizes(d.Width, d.Height)
+	levels := []image.Image{src}
+	converters := []Converter{nil}
+	threads := getLevelThreads(ctx.Threads, len(sizes)-1)
+	for i, size := range sizes[1:] {
+		dst, err := newImageLike(src, size.X, size.Y)
+		if err != nil {
+			return err
+		}
+		input := src
+		if ctx.Cascade {
+			input = levels[len(levels)-1]
+		}
+		cfg, err := PrepareConversion(dst, input)
+		if err != nil {
+			return err
+		}
+		cfg.Threads = ctx.Threads
+		if !ctx.Cascade {
+			cfg.Threads = threads[i]
+		}
+		cfg.DisableAsm = ctx.DisableAsm
+		converter, err := NewConverter(cfg, ctx.filter)
+		if err != nil {
+			return err
+		}
+		levels = append(levels, dst)
+		converters = append(converters, converter)
+	}
+	ctx.input = *d
+	ctx.kind = reflect.TypeOf(src)
+	ctx.levels = levels
+	ctx.converters = converters
+	return nil
+}
+
+func (ctx *pyramidContext) Generate(src image.Image) ([]image.Image, error) {
+	d, _, err := inspect(src, false)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.levels == nil || *d != ctx.input || reflect.TypeOf(src) != ctx.kind {
+		err = ctx.prepare(src, d)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ctx.levels[0] = src
+	if ctx.Cascade {
+		for i := 1; i < len(ctx.levels); i++ {
+			err = ctx.converters[i].Convert(ctx.levels[i], ctx.levels[i-1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return ctx.levels, nil
+	}
+	// every level only depends on src, so we can resize all of them at once
+	// with at most one worker per thread
+	group := sync.WaitGroup{}
+	errs := make([]error, len(ctx.levels))
+	workers := min(ctx.Threads, len(ctx.levels)-1)
+	for i := 0; i < workers; i++ {
+		first := i + 1
+		dispatch(&group, workers, func() {
+			for j := first; j < len(ctx.levels); j += workers {
+				errs[j] = ctx.converters[j].Convert(ctx.levels[j], src)
+			}
+		})
+	}
+	group.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ctx.levels, nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FilterFactory returns a filter from a complete set of parameters
+type FilterFactory func(params map[string]float64) (Filter, error)
+
+type filterEntry struct {
+	defaults map[string]float64
+	factory  FilterFactory
+}
+
+var (
+	filterLock     sync.RWMutex
+	filterRegistry = map[string]filterEntry{}
+)
+
+// RegisterFilter registers a filter factory
+// name = filter name, case-insensitive
+// defaults = every accepted parameter with its default value
+// factory = function called by FilterByName with all parameters set
+// Returns an error if name is invalid or already registered
+func RegisterFilter(name string, defaults map[string]float64, factory FilterFactory) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) == 0 || strings.ContainsAny(name, ":,= ") {
+		return fmt.Errorf("invalid filter name %q", name)
+	}
+	if factory == nil {
+		return fmt.Errorf("missing filter %v factory", name)
+	}
+	values := map[string]float64{}
+	for k, v := range defaults {
+		values[k] = v
+	}
+	filterLock.Lock()
+	defer filterLock.Unlock()
+	if _, ok := filterRegistry[name]; ok {
+		return fmt.Errorf("filter %v already registered", name)
+	}
+	filterRegistry[name] = filterEntry{values, factory}
+	return nil
+}
+
+// FilterNames returns every registered filter name, sorted
+func FilterNames() []string {
+	filterLock.RLock()
+	defer filterLock.RUnlock()
+	names := []string{}
+	for name := range filterRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseFilterName(desc string) (string, map[string]float64, error) {
+	desc = strings.ToLower(strings.TrimSpace(desc))
+	name := desc
+	params := map[string]float64{}
+	idx := strings.Index(desc, ":")
+	if idx < 0 {
+		return name, params, nil
+	}
+	name = desc[:idx]
+	for _, it := range strings.Split(desc[idx+1:], ",") {
+		kv := strings.SplitN(it, "=", 2)
+		if len(kv) != 2 {
+			return "", nil, fmt.Errorf("invalid filter parameter %q", it)
+		}
+		key := strings.TrimSpace(kv[0])
+		value, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid filter parameter %q", it)
+		}
+		if _, ok := params[key]; ok {
+			return "", nil, fmt.Errorf("duplicate filter parameter %q", key)
+		}
+		params[key] = value
+	}
+	return name, params, nil
+}
+
+// FilterByName returns a filter from its description
+// desc = "name" or "name:key=value,..." like "lanczos:a=4"
+// Every filter also accepts a "blur" parameter, see NewBlurFilter
+// Returns an error if the filter is unknown or if parameters are invalid
+func FilterByName(desc string) (Filter, error) {
+	name, params, err := parseFilterName(desc)
+	if err != nil {
+		return nil, err
+	}
+	filterLock.RLock()
+	entry, ok := filterRegistry[name]
+	filterLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown filter %q", name)
+	}
+	values := map[string]float64{}
+	for k, v := range entry.defaults {
+		values[k] = v
+	}
+	value, blurred := params["blur"]
+	if _, ok := values["blur"]; ok {
+		blurred = false
+	}
+	for k, v := range params {
+		if _, ok := values[k]; !ok && (k != "blur" || !blurred) {
+			return nil, fmt.Errorf("unknown parameter %q for filter %v", k, name)
+		}
+		values[k] = v
+	}
+	if blurred {
+		delete(values, "blur")
+	}
+	filter, err := entry.factory(values)
+	if err != nil || !blurred {
+		return filter, err
+	}
+	if value <= 0 {
+		return nil, fmt.Errorf("invalid blur %v", value)
+	}
+	return NewBlurFilter(filter, value), nil
+}
+
+// formatFilterName returns a canonical filter name
+// keys = parameter names followed by their values
+func formatFilterName(name string, keys ...interface{}) string {
+	params := []string{}
+	for i := 0; i+1 < len(keys); i += 2 {
+		value := strconv.FormatFloat(keys[i+1].(float64), 'g', -1, 64)
+		params = append(params, fmt.Sprintf("%v=%v", keys[i], value))
+	}
+	if len(params) == 0 {
+		return name
+	}
+	return name + ":" + strings.Join(params, ",")
+}
+
+func mustRegisterFilter(name string, defaults map[string]float64, factory FilterFactory) {
+	err := RegisterFilter(name, defaults, factory)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newLanczosFilterFrom(params map[string]float64) (Filter, error) {
+	alpha := params["a"]
+	if alpha <= 0 {
+		return nil, fmt.Errorf("invalid lanczos size %v", alpha)
+	}
+	return NewCustomLanczosFilter(alpha), nil
+}
+
+func init() {
+	mustRegisterFilter("bilinear", nil, func(map[string]float64) (Filter, error) {
+		return NewBilinearFilter(), nil
+	})
+	mustRegisterFilter("bicubic", map[string]float64{"b": 0, "c": 0.5},
+		func(p map[string]float64) (Filter, error) {
+			return NewCustomBicubicFilter(p["b"], p["c"]), nil
+		})
+	mustRegisterFilter("mitchell", nil, func(map[string]float64) (Filter, error) {
+		return NewMitchellFilter(), nil
+	})
+	mustRegisterFilter("catmull-rom", nil, func(map[string]float64) (Filter, error) {
+		return NewCatmullRomFilter(), nil
+	})
+	mustRegisterFilter("b-spline", nil, func(map[string]float64) (Filter, error) {
+		return NewBSplineFilter(), nil
+	})
+	mustRegisterFilter("hermite", nil, func(map[string]float64) (Filter, error) {
+		return NewHermiteFilter(), nil
+	})
+	mustRegisterFilter("box", nil, func(map[string]float64) (Filter, error) {
+		return NewBoxFilter(), nil
+	})
+	mustRegisterFilter("gaussian", map[string]float64{"sigma": 0.5},
+		func(p map[string]float64) (Filter, error) {
+			if p["sigma"] <= 0 {
+				return nil, fmt.Errorf("invalid gaussian sigma %v", p["sigma"])
+			}
+			return NewGaussianFilter(p["sigma"]), nil
+		})
+	for _, f := range []Filter{NewSpline16Filter(), NewSpline36Filter(), NewSpline64Filter()} {
+		mustRegisterFilter(f.Name(), nil, func(f Filter) FilterFactory {
+			return func(map[string]float64) (Filter, error) {
+				return f, nil
+			}
+		}(f))
+	}
+	for _, w := range []window{hannWindow, hammingWindow, blackmanWindow, kaiserWindow} {
+		defaults := map[string]float64{"a": 3}
+		if w == kaiserWindow {
+			defaults["beta"] = 6.33
+		}
+		mustRegisterFilter(windowNames[w], defaults, func(w window) FilterFactory {
+			return func(p map[string]float64) (Filter, error) {
+				alpha := p["a"]
+				if alpha <= 0 {
+					return nil, fmt.Errorf("invalid %v size %v", windowNames[w], alpha)
+				}
+				return windowedSinc{window: w, alpha: alpha, beta: p["beta"]}, nil
+			}
+		}(w))
+	}
+	mustRegisterFilter("jinc", map[string]float64{"lobes": 3},
+		func(p map[string]float64) (Filter, error) {
+			lobes := int(p["lobes"])
+			if float64(lobes) != p["lobes"] || lobes < 1 || lobes > len(jincZeros) {
+				return nil, fmt.Errorf("invalid jinc lobes %v", p["lobes"])
+			}
+			return NewJincFilter(lobes), nil
+		})
+	mustRegisterFilter("lanczos", map[string]float64{"a": 3}, newLanczosFilterFrom)
+	for _, alpha := range []int{2, 3, 4} {
+		mustRegisterFilter(fmt.Sprintf("lanczos%v", alpha), nil,
+			func(alpha int) FilterFactory {
+				return func(map[string]float64) (Filter, error) {
+					return NewLanczosFilter(alpha), nil
+				}
+			}(alpha))
+	}
+}
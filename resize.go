@@ -5,12 +5,31 @@
 package rez
 
 import (
+	"fmt"
+	"math"
 	"sync"
 )
 
+// Sampling is a method used to compute output pixels
+type Sampling int
+
+const (
+	// SamplingFilter uses separable filter kernels, one dimension at a time
+	SamplingFilter Sampling = iota
+	// SamplingEWA uses elliptical weighted averages where filters are
+	// applied radially on both dimensions at once
+	// It is slower but avoids diagonal aliasing
+	SamplingEWA
+	// SamplingNearest copies the closest source pixel without filtering
+	SamplingNearest
+	// SamplingArea averages source pixels weighted by their coverage
+	// It is well suited to large downscales and ignores filters
+	SamplingArea
+)
+
 // ResizerConfig is a configuration used with NewResizer
 type ResizerConfig struct {
-	Depth      int  // bits per pixel
+	Depth      int  // bits per sample, 8 or 16 [default=8]
 	Input      int  // input size in pixels
 	Output     int  // output size in pixels
 	Vertical   bool // true for vertical resizes
@@ -18,6 +37,27 @@ type ResizerConfig struct {
 	Pack       int  // pixels per pack [default=1]
 	Threads    int  // number of threads, [default=0]
 	DisableAsm bool // disable asm optimisations
+	// Sampling is either SamplingFilter, SamplingNearest or SamplingArea,
+	// EWA is only available with converters [default=SamplingFilter]
+	Sampling Sampling
+	// AntiRing clamps output pixels towards the range of the two source
+	// pixels closest to them, 0 = disabled, 1 = full clamp [default=0]
+	AntiRing float64
+	// Bits is the number of fractional bits used by coeffs, fewer bits allow
+	// larger filter lobes but disable asm, between 1 & 14 [default=Bits]
+	Bits int
+	// WideInput reads 16-bit 8.8 fixed point samples on horizontal resizes
+	// WideOutput writes 16-bit 8.8 fixed point samples
+	// Both keep precision between passes
+	WideInput  bool
+	WideOutput bool
+	// Border is the method used to extend images beyond their edges,
+	// BorderWrap & BorderConstant copy source planes [default=BorderClamp]
+	Border      Border
+	BorderValue [4]byte // BorderConstant value for every packed pixel byte
+	// ISA is the best instruction set allowed, it is lowered to the one
+	// supported by the cpu [default=ISAAuto]
+	ISA ISA
 }
 
 // Resizer is a interface that implements resizes
@@ -27,6 +67,9 @@ type Resizer interface {
 	// width, height = plane dimensions in pixels
 	// dstPitch, srcPitch = destination and source pitchs/strides in bytes
 	Resize(dst, src []byte, width, height, dstPitch, srcPitch int)
+	// Scalers returns scalers used on planes of width pixels, in processing
+	// order, or nil if the resizer does not use scalers
+	Scalers(width int) []ScalerInfo
 }
 
 type scaler func(dst, src []byte, cof, off []int16,
@@ -34,66 +77,108 @@ type scaler func(dst, src []byte, cof, off []int16,
 
 type context struct {
 	cfg     ResizerConfig
-	kernels []kernel
-	scaler  scaler
-}
-
-func getHorizontalScalerGo(taps int) scaler {
-	switch taps {
-	case 2:
-		return h8scale2Go
-	case 4:
-		return h8scale4Go
-	case 6:
-		return h8scale6Go
-	case 8:
-		return h8scale8Go
-	case 10:
-		return h8scale10Go
-	case 12:
-		return h8scale12Go
-	}
-	return h8scaleNGo
-}
-
-func getVerticalScalerGo(taps int) scaler {
-	switch taps {
-	case 2:
-		return v8scale2Go
-	case 4:
-		return v8scale4Go
-	case 6:
-		return v8scale6Go
-	case 8:
-		return v8scale8Go
-	case 10:
-		return v8scale10Go
-	case 12:
-		return v8scale12Go
-	}
-	return v8scaleNGo
+	kernels [][]kernel // reduced kernels for every field
+	near    [][]int32  // anti-ringing source pixels for every field
+	margin  int        // padded border pixels on both sides
+	strips  []strip    // padded source edges
+	padded  sync.Pool  // *[]byte padded strips
 }
 
 // NewResize returns a new resizer
 // cfg = resize configuration
 // filter = filter used for computing weights
-func NewResize(cfg *ResizerConfig, filter Filter) Resizer {
-	ctx := context{
+// Returns an error if filter weights cannot be represented with cfg.Bits
+// or if sizes overflow int16 offsets
+func NewResize(cfg *ResizerConfig, filter Filter) (Resizer, error) {
+	ctx := &context{
 		cfg: *cfg,
 	}
-	ctx.cfg.Depth = 8 // only 8-bit for now
+	if ctx.cfg.Depth == 0 {
+		ctx.cfg.Depth = 8
+	}
+	if ctx.cfg.Depth != 8 && ctx.cfg.Depth != 16 {
+		return nil, fmt.Errorf("invalid depth %v", cfg.Depth)
+	}
 	if ctx.cfg.Pack < 1 {
 		ctx.cfg.Pack = 1
 	}
-	ctx.kernels = []kernel{makeKernel(&ctx.cfg, filter, 0)}
-	ctx.scaler = getHorizontalScaler(ctx.kernels[0].size, !cfg.DisableAsm)
-	if cfg.Vertical {
-		ctx.scaler = getVerticalScaler(ctx.kernels[0].size, !cfg.DisableAsm)
-		if cfg.Interlaced {
-			ctx.kernels = append(ctx.kernels, makeKernel(&ctx.cfg, filter, 1))
+	if ctx.cfg.Bits == 0 {
+		ctx.cfg.Bits = Bits
+	}
+	if ctx.cfg.Bits < 1 || ctx.cfg.Bits > Bits {
+		return nil, fmt.Errorf("invalid bits %v", cfg.Bits)
+	}
+	if cfg.WideInput && cfg.Vertical {
+		return nil, fmt.Errorf("wide inputs are only supported on horizontal resizes")
+	}
+	// 16-bit samples are read & written like 8.8 fixed point ones, with the
+	// same rounding
+	if ctx.cfg.Depth == 16 {
+		ctx.cfg.WideInput = true
+		ctx.cfg.WideOutput = true
+	}
+	wide := ctx.cfg.WideInput || ctx.cfg.WideOutput
+	if cfg.Border < BorderClamp || cfg.Border > BorderConstant {
+		return nil, fmt.Errorf("invalid border %v", cfg.Border)
+	}
+	if cfg.ISA < ISAAuto || cfg.ISA > ISAAVX512BW {
+		return nil, fmt.Errorf("invalid isa %v", cfg.ISA)
+	}
+	if cfg.AntiRing < 0 || cfg.AntiRing > 1 {
+		return nil, fmt.Errorf("invalid anti-ringing %v", cfg.AntiRing)
+	}
+	if err := checkOffsets(&ctx.cfg, 0); err != nil {
+		return nil, err
+	}
+	// asm scalers only support default bits
+	if ctx.cfg.Bits != Bits {
+		ctx.cfg.DisableAsm = true
+	}
+	if cfg.Sampling == SamplingNearest {
+		if wide {
+			return nil, fmt.Errorf("nearest sampling does not support wide samples")
 		}
+		return newNearestResizer(&ctx.cfg), nil
+	}
+	if factor := getAreaFactor(&ctx.cfg); cfg.Sampling == SamplingArea && factor != 0 && !wide {
+		return newAreaResizer(&ctx.cfg, factor), nil
+	}
+	fields := uint(1)
+	if cfg.Vertical && cfg.Interlaced {
+		fields = 2
 	}
-	return &ctx
+	for i := uint(0); i < fields; i++ {
+		kernels, margin, err := makeKernel(&ctx.cfg, filter, i)
+		if err != nil {
+			return nil, err
+		}
+		ctx.margin = margin
+		ctx.kernels = append(ctx.kernels, kernels)
+		if cfg.AntiRing > 0 {
+			ctx.near = append(ctx.near, makeNearKernel(&ctx.cfg, bin(cfg.Interlaced), i))
+		}
+	}
+	if err := checkOffsets(&ctx.cfg, ctx.margin); err != nil {
+		return nil, err
+	}
+	if ctx.margin > 0 {
+		ctx.kernels, ctx.strips = splitBorders(&ctx.cfg, ctx.kernels, cfg.Input, ctx.margin)
+	}
+	return ctx, nil
+}
+
+// checkOffsets returns an error if cfg sizes overflow int16 kernel offsets,
+// which are samples on horizontal resizes & rows on vertical ones
+// margin = padded pixels or rows on both sides
+func checkOffsets(cfg *ResizerConfig, margin int) error {
+	pack := cfg.Pack
+	if cfg.Vertical {
+		pack = 1
+	}
+	if (cfg.Input+2*margin)*pack > math.MaxInt16 || cfg.Output > math.MaxInt16 {
+		return fmt.Errorf("invalid size %v -> %v, offsets overflow", cfg.Input, cfg.Output)
+	}
+	return nil
 }
 
 func dispatch(group *sync.WaitGroup, threads int, job func()) {
@@ -115,45 +200,108 @@ func scaleSlice(group *sync.WaitGroup, threads int, scaler scaler,
 	})
 }
 
-func scaleSlices(group *sync.WaitGroup, scaler scaler,
-	vertical bool, threads, taps, width, height, dp, sp int,
-	dst, src []byte, cof []int16, cofscale int, off []int16) {
+// getTiles splits width*height pixels into rows, which are split again into
+// columns of blocks of block pixels when there are fewer rows than threads
+// Returns the number of rows & columns and the column width, the last row &
+// column keep remaining pixels
+func getTiles(threads, width, height, block int) (int, int, int) {
+	rows := max(1, min(threads, height))
+	// the last column keeps the trailing partial block, so that every
+	// column is at least one block wide on planes wider than a block
+	blocks := max(1, width/block)
+	cols := max(1, min((threads+rows-1)/rows, blocks))
+	per := (blocks + cols - 1) / cols
+	cols = (blocks + per - 1) / per
+	return rows, cols, per * block
+}
+
+// splitTiles dispatches job on every tile of a width*height pixels plane,
+// x & w are in pixels, y & h in rows, see getTiles
+func splitTiles(group *sync.WaitGroup, threads, width, height, block int, job func(x, y, w, h int)) {
+	rows, cols, nw := getTiles(threads, width, height, block)
+	nh := height / rows
+	for i := 0; i < rows; i++ {
+		y := i * nh
+		ih := nh
+		if i+1 == rows {
+			ih = height - y
+		}
+		for j := 0; j < cols; j++ {
+			x := j * nw
+			iw := nw
+			if j+1 == cols {
+				iw = width - x
+			}
+			dispatch(group, threads, func() {
+				job(x, y, iw, ih)
+			})
+		}
+	}
+}
+
+// scaleSlices splits k output into slices of rows, which are split again
+// into columns of k.block pixels when there are fewer rows than threads
+// dpack & spack are bytes per destination & source pixel
+func scaleSlices(group *sync.WaitGroup, k *kernel, vertical bool,
+	threads, width, height, dpack, spack, dp, sp int, dst, src []byte) {
 	dispatch(group, threads, func() {
-		nh := height / threads
-		if nh < 1 {
-			nh = 1
+		rows, cols, nw := getTiles(threads, width, height, k.block)
+		fn := k.scaler
+		if min(nw, width) < k.minWidth {
+			fn = k.narrow
 		}
+		nh := height / rows
 		di := 0
 		si := 0
 		oi := 0
 		ci := 0
-		for i := 0; i < threads; i++ {
-			last := i+1 == threads
+		taps := k.size
+		for i := 0; i < rows; i++ {
+			last := i+1 == rows
 			ih := nh
 			if last {
-				ih = height - nh*(threads-1)
+				ih = height - nh*(rows-1)
 			}
-			if ih == 0 {
-				continue
-			}
-			next := width
+			// vertical offsets are deltas which step back when kernels are
+			// trimmed, so every slice starts on the lowest row it reads
+			ri := si
+			var off []int16
 			if vertical {
-				next = ih
+				off = make([]int16, ih)
+				copy(off, k.offsets[oi:oi+ih])
+				row, low := 0, int(off[0])
+				for _, v := range off {
+					row += int(v)
+					low = min(low, row)
+				}
+				ri += sp * low
+				off[0] -= int16(low)
+			}
+			for j := 0; j < cols; j++ {
+				x := j * nw
+				iw := nw
+				if j+1 == cols {
+					iw = width - x
+				}
+				d := dst[di+x*dpack : di+dp*(ih-1)+(x+iw)*dpack]
+				if vertical {
+					scaleSlice(group, threads, fn, d, src[ri+x*spack:],
+						k.coeffs[ci:ci+ih*k.rowcof], off,
+						taps, iw, ih, dp, sp)
+					continue
+				}
+				scaleSlice(group, threads, fn, d, src[si:],
+					k.coeffs[x/k.block*k.blockcof:], k.offsets[x:x+iw],
+					taps, iw, ih, dp, sp)
 			}
-			scaleSlice(group, threads, scaler,
-				dst[di:di+dp*(ih-1)+width],
-				src[si:],
-				cof[ci:ci+next*taps*cofscale],
-				off[oi:oi+next],
-				taps, width, ih, dp, sp)
 			if last {
 				break
 			}
 			di += ih * dp
 			if vertical {
-				ci += ih * taps * cofscale
+				ci += ih * k.rowcof
 				for j := 0; j < ih; j++ {
-					si += sp * int(off[oi+j])
+					si += sp * int(k.offsets[oi+j])
 				}
 				oi += ih
 			} else {
@@ -163,22 +311,126 @@ func scaleSlices(group *sync.WaitGroup, scaler scaler,
 	})
 }
 
+func (c *context) Scalers(width int) []ScalerInfo {
+	infos := []ScalerInfo{}
+	for _, kernels := range c.kernels {
+		for _, k := range kernels {
+			switch {
+			case k.split:
+				continue
+			case c.cfg.Vertical && width*c.cfg.Pack < k.minWidth:
+				infos = append(infos, k.nInfo)
+			default:
+				infos = append(infos, k.info)
+			}
+		}
+	}
+	return infos
+}
+
 func (c *context) Resize(dst, src []byte, width, height, dp, sp int) {
 	field := bin(c.cfg.Vertical && c.cfg.Interlaced)
-	dwidth := c.cfg.Output
-	dheight := height
+	pk := c.cfg.Pack
+	// kernels near edges read padded strips instead of src
+	pads := make([][]byte, len(c.strips))
+	pitches := make([]int, len(c.strips))
+	if len(c.strips) > 0 {
+		sample := 1 + int(bin(c.cfg.WideInput))
+		size := 0
+		for _, s := range c.strips {
+			size += c.getStripSize(s, width, height, sample)
+		}
+		buf, _ := c.padded.Get().(*[]byte)
+		if buf == nil || len(*buf) < size {
+			b := make([]byte, size)
+			buf = &b
+		}
+		defer c.padded.Put(buf)
+		data := *buf
+		for i, s := range c.strips {
+			pads[i] = data
+			pitches[i] = c.padStrip(data, src, s, width, height, sp, sample)
+			data = data[c.getStripSize(s, width, height, sample):]
+		}
+	}
+	// bytes per destination & source sample
+	ds := 1 + int(bin(c.cfg.WideOutput))
+	ss := 1 + int(bin(c.cfg.WideInput))
+	group := sync.WaitGroup{}
+	for i, kernels := range c.kernels {
+		for j := range kernels {
+			k := &kernels[j]
+			ksrc, ksp := src, sp
+			if len(c.strips) > 0 && k.strip >= 0 {
+				ksrc, ksp = pads[k.strip], pitches[k.strip]
+			}
+			if c.cfg.Vertical {
+				scaleSlices(&group, k, true, c.cfg.Threads,
+					width*pk, k.count, ds, ss, dp<<field, ksp<<field,
+					dst[dp*i+(dp<<field)*k.start:], ksrc[ksp*i:])
+				continue
+			}
+			scaleSlices(&group, k, false, c.cfg.Threads,
+				k.count*pk/k.pack, height, k.pack*ds, k.pack*ss, dp, ksp,
+				dst[k.start*pk*ds:], ksrc)
+		}
+	}
+	group.Wait()
+	if c.cfg.AntiRing > 0 {
+		c.dering(dst, src, width, height, dp, sp)
+	}
+}
+
+// dering applies anti-ringing on dst, which must already be resized
+func (c *context) dering(dst, src []byte, width, height, dp, sp int) {
+	field := bin(c.cfg.Vertical && c.cfg.Interlaced)
+	strength := int(math.Floor(c.cfg.AntiRing*256 + 0.5))
+	size := c.cfg.Output
 	if c.cfg.Vertical {
-		dwidth = width
+		size = width
 	}
 	pk := c.cfg.Pack
+	fast := getDeringer(c.cfg.Vertical, getISA(&c.cfg))
 	group := sync.WaitGroup{}
-	for i, k := range c.kernels[:1+field] {
+	in, out := c.cfg.WideInput, c.cfg.WideOutput
+	ss, ds := 1+int(bin(in)), 1+int(bin(out))
+	for i, near := range c.near {
+		dheight := height
 		if c.cfg.Vertical {
 			dheight = (c.cfg.Output + (1-i)*int(field)) >> field
 		}
-		scaleSlices(&group, c.scaler, c.cfg.Vertical, c.cfg.Threads,
-			k.size, dwidth*pk, dheight, dp<<field, sp<<field,
-			dst[dp*i:], src[sp*i:], k.coeffs, k.cofscale, k.offsets)
+		near := near
+		d := dst[dp*i:]
+		if !c.cfg.Vertical {
+			splitTiles(&group, c.cfg.Threads, size, dheight, columnBlock, func(x, y, w, h int) {
+				d := d[dp*y+x*pk*ds:]
+				s := src[sp*y:]
+				near := near[x : x+w]
+				switch {
+				case in || out:
+					h16deringGo(d, s, near, strength, w, h, dp, sp, pk, in, out)
+				case fast != nil && pk == 1:
+					fast(d, s, near, strength, w, h, dp, sp)
+				default:
+					h8deringGo(d, s, near, strength, w, h, dp, sp, pk)
+				}
+			})
+			continue
+		}
+		s := src[sp*i:]
+		splitTiles(&group, c.cfg.Threads, size, dheight, columnBlock, func(x, y, w, h int) {
+			d := d[(dp<<field)*y+x*pk*ds:]
+			s := s[x*pk*ss:]
+			near := near[y : y+h]
+			switch {
+			case out:
+				v16deringGo(d, s, near, strength, w*pk, h, dp<<field, sp<<field, in)
+			case fast != nil:
+				fast(d, s, near, strength, w*pk, h, dp<<field, sp<<field)
+			default:
+				v8deringGo(d, s, near, strength, w*pk, h, dp<<field, sp<<field)
+			}
+		})
 	}
 	group.Wait()
 }
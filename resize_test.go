@@ -5,15 +5,25 @@
 package rez
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/bamiaux/rez/asm"
+	"github.com/bamiaux/rez/rezgen/gen"
 	"image"
 	"image/draw"
 	_ "image/jpeg"
 	"image/png"
+	"io/ioutil"
 	"math"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -359,3 +369,2395 @@ func TestBigKernels(t *testing.T) {
 		runTestCase(t, tc, 1)
 	}
 }
+
+func testPyramidWith(t *testing.T, src image.Image, cascade bool) []image.Image {
+	pyramid := NewPyramid(&PyramidConfig{Cascade: cascade}, NewBicubicFilter())
+	levels, err := pyramid.Generate(src)
+	expect(t, err, nil)
+	b := src.Bounds()
+	sizes := getPyramidSizes(b.Dx(), b.Dy())
+	expect(t, len(levels), len(sizes))
+	expect(t, levels[0], src)
+	for i, level := range levels {
+		expect(t, level.Bounds().Size(), sizes[i])
+	}
+	last := levels[len(levels)-1].Bounds().Size()
+	expect(t, last, image.Pt(1, 1))
+	// level buffers must be reused between calls
+	again, err := pyramid.Generate(src)
+	expect(t, err, nil)
+	for i := 1; i < len(levels); i++ {
+		expect(t, again[i] == levels[i], true)
+	}
+	return levels
+}
+
+func TestPyramid(t *testing.T) {
+	raw := readImage(t, "testdata/lenna.jpg").(*image.YCbCr)
+	odd := toRgb(raw.SubImage(image.Rect(0, 0, 301, 77)))
+	for _, src := range []image.Image{raw, odd} {
+		direct := testPyramidWith(t, src, false)
+		cascade := testPyramidWith(t, src, true)
+		expect(t, len(direct), len(cascade))
+		// first level is computed identically
+		checkPsnrs(t, direct[1], cascade[1], image.Rectangle{}, []float64{math.Inf(1), math.Inf(1), math.Inf(1)})
+		checkPsnrs(t, direct[2], cascade[2], image.Rectangle{}, []float64{30, 30, 30})
+	}
+	// rgba & nrgba inputs share descriptors but not levels
+	pyramid := NewPyramid(&PyramidConfig{Threads: 3}, NewBilinearFilter())
+	levels, err := pyramid.Generate(odd)
+	expect(t, err, nil)
+	_, ok := levels[1].(*image.RGBA)
+	expect(t, ok, true)
+	nrgba := image.NewNRGBA(odd.Bounds())
+	levels, err = pyramid.Generate(nrgba)
+	expect(t, err, nil)
+	for _, level := range levels {
+		_, ok = level.(*image.NRGBA)
+		expect(t, ok, true)
+	}
+	expect(t, getLevelThreads(8, 3), []int{3, 3, 2})
+	expect(t, getLevelThreads(2, 5), []int{1, 1, 1, 1, 1})
+}
+
+func listTiles(t *testing.T, dir string) map[string][]byte {
+	tiles := map[string][]byte{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		name, _ := filepath.Rel(dir, path)
+		tiles[name] = data
+		return err
+	})
+	expect(t, err, nil)
+	return tiles
+}
+
+func exportTiles(t *testing.T, cfg *TilerConfig, src image.Image) map[string][]byte {
+	dir, err := ioutil.TempDir("", "rez")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+	tiler, err := NewTiler(cfg, NewBicubicFilter())
+	expect(t, err, nil)
+	err = tiler.Export(dir, "lenna", src)
+	expect(t, err, nil)
+	return listTiles(t, dir)
+}
+
+func TestTiler(t *testing.T) {
+	raw := readImage(t, "testdata/lenna.jpg").(*image.YCbCr)
+	src := raw.SubImage(image.Rect(0, 0, 301, 203))
+	cfg := &TilerConfig{TileSize: 64, Overlap: 1}
+	ref := exportTiles(t, cfg, src)
+	// 301x203 needs 10 levels
+	expect(t, len(ref), 1+20+6+2+1+1+1+1+1+1+1)
+	expect(t, ref["lenna.dzi"] != nil, true)
+	tile := decodeTile(t, ref["lenna_files/9/4_3.png"])
+	expect(t, tile.Bounds().Size(), image.Pt(301-4*64+1, 203-3*64+1))
+	tile = decodeTile(t, ref["lenna_files/8/1_1.png"])
+	expect(t, tile.Bounds().Size(), image.Pt(64+2, 102-64+1))
+	tile = decodeTile(t, ref["lenna_files/0/0_0.png"])
+	expect(t, tile.Bounds().Size(), image.Pt(1, 1))
+	// streaming must not change any pixel
+	cfg.Strip = 7
+	expect(t, exportTiles(t, cfg, src), ref)
+
+	cfg = &TilerConfig{TileSize: 64, Layout: LayoutXYZ}
+	xyz := exportTiles(t, cfg, src)
+	expect(t, len(xyz), 20+6+2+1)
+	tile = decodeTile(t, xyz["lenna/3/4/3.png"])
+	expect(t, tile.Bounds().Size(), image.Pt(64, 64))
+	tile = decodeTile(t, xyz["lenna/0/0/0.png"])
+	expect(t, tile.Bounds().Size(), image.Pt(64, 64))
+	cfg.Strip = 13
+	expect(t, exportTiles(t, cfg, src), xyz)
+}
+
+func TestOffsetOverflow(t *testing.T) {
+	for _, it := range []struct {
+		cfg ResizerConfig
+		ok  bool
+	}{
+		{ResizerConfig{Input: math.MaxInt16, Output: 100}, true},
+		{ResizerConfig{Input: math.MaxInt16 + 1, Output: 100}, false},
+		{ResizerConfig{Input: 100, Output: math.MaxInt16 + 1}, false},
+		{ResizerConfig{Input: 9000, Output: 100, Pack: 4}, false},
+		{ResizerConfig{Input: 9000, Output: 100, Pack: 4, Vertical: true}, true},
+		{ResizerConfig{Input: math.MaxInt16, Output: 100, Border: BorderWrap}, false},
+		{ResizerConfig{Input: math.MaxInt16 + 1, Output: 100, Sampling: SamplingArea}, false},
+	} {
+		_, err := NewResize(&it.cfg, NewBicubicFilter())
+		expect(t, err == nil, it.ok)
+	}
+	// tilers must not silently overflow on large levels
+	dir, err := ioutil.TempDir("", "rez")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+	tiler, err := NewTiler(&TilerConfig{TileSize: 1 << 14}, NewBicubicFilter())
+	expect(t, err, nil)
+	src := image.NewGray(image.Rect(0, 0, math.MaxInt16+2, 2))
+	expect(t, tiler.Export(dir, "large", src) != nil, true)
+}
+
+func decodeTile(t *testing.T, data []byte) image.Image {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	expect(t, err, nil)
+	return img
+}
+
+func TestFilterRegistry(t *testing.T) {
+	for _, name := range FilterNames() {
+		f, err := FilterByName(name)
+		expect(t, err, nil)
+		g, err := FilterByName(f.Name())
+		expect(t, err, nil)
+		expect(t, g, f)
+		expect(t, g.Name(), f.Name())
+	}
+	f, err := FilterByName("Lanczos:a=4")
+	expect(t, err, nil)
+	expect(t, f, NewLanczosFilter(4))
+	expect(t, f.Name(), "lanczos:a=4")
+	f, err = FilterByName("bicubic: b=0.333, c=0.333")
+	expect(t, err, nil)
+	expect(t, f, NewCustomBicubicFilter(0.333, 0.333))
+	f, err = FilterByName("catmull-rom")
+	expect(t, err, nil)
+	expect(t, f.Name(), "bicubic:b=0,c=0.5")
+	for _, name := range []string{"unknown", "lanczos:b=2", "lanczos:a", "lanczos:a=x", "lanczos:a=0", "lanczos:a=1,a=2", "box:blur=0"} {
+		_, err = FilterByName(name)
+		expect(t, err != nil, true)
+	}
+	factory := func(map[string]float64) (Filter, error) { return NewBilinearFilter(), nil }
+	RegisterFilter("custom-bilinear", nil, factory)
+	expect(t, RegisterFilter("custom-bilinear", nil, factory) != nil, true)
+	expect(t, RegisterFilter("custom:bilinear", nil, factory) != nil, true)
+	expect(t, RegisterFilter("custom-nil", nil, nil) != nil, true)
+	_, err = FilterByName("custom-nil")
+	expect(t, err != nil, true)
+	f, err = FilterByName("custom-bilinear")
+	expect(t, err, nil)
+	expect(t, f, NewBilinearFilter())
+}
+
+var (
+	extendedFilters = []Filter{
+		NewHannFilter(3),
+		NewHammingFilter(3),
+		NewBlackmanFilter(3),
+		NewKaiserFilter(3, 6.33),
+		NewSpline16Filter(),
+		NewSpline36Filter(),
+		NewSpline64Filter(),
+		NewGaussianFilter(0.5),
+		NewHermiteFilter(),
+		NewBoxFilter(),
+		NewMitchellFilter(),
+		NewCatmullRomFilter(),
+		NewBSplineFilter(),
+	}
+)
+
+// resizeFloat resizes src with floating point weights and returns values
+// for output pixels whose kernel never crosses src borders
+func resizeFloat(src []byte, output int, filter Filter) map[int]float64 {
+	scale := float64(output) / float64(len(src))
+	step := math.Min(1, scale)
+	support := float64(filter.Taps()) / step
+	dst := map[int]float64{}
+	for i := 0; i < output; i++ {
+		x := (float64(i)+0.5)/scale - 0.5
+		left := int(math.Floor(x - support))
+		right := int(math.Ceil(x + support))
+		if left < 0 || right >= len(src) {
+			continue
+		}
+		sum, weights := 0.0, 0.0
+		for j := left; j <= right; j++ {
+			w := filter.Get(math.Abs(x-float64(j)) * step)
+			sum += w * float64(src[j])
+			weights += w
+		}
+		dst[i] = sum / weights
+	}
+	return dst
+}
+
+func TestFilterResponses(t *testing.T) {
+	refs := []struct {
+		filter Filter
+		x, y   float64
+	}{
+		{NewHannFilter(3), 1.5, -0.2122065907891938 * 0.5},
+		{NewHammingFilter(3), 0.5, 0.6366197723675814 * (0.54 + 0.46*math.Cos(math.Pi/6))},
+		{NewBlackmanFilter(2), 1, 0},
+		{NewKaiserFilter(3, 0), 0.5, 0.6366197723675814},
+		{NewSpline16Filter(), 1, 0},
+		{NewSpline36Filter(), 2, 0},
+		{NewSpline64Filter(), 3, 0},
+		{NewSpline64Filter(), 0, 1},
+		{NewGaussianFilter(1), 1, math.Exp(-0.5)},
+		{NewHermiteFilter(), 0.5, 0.5},
+		{NewBoxFilter(), 0.25, 1},
+		{NewBoxFilter(), 0.75, 0},
+		{NewMitchellFilter(), 0, 8.0 / 9},
+		{NewBSplineFilter(), 1, 1.0 / 6},
+	}
+	for _, r := range refs {
+		if y := r.filter.Get(r.x); math.Abs(y-r.y) > 1e-9 {
+			t.Fatalf("%v(%v) = %v, want %v\n", r.filter.Name(), r.x, y, r.y)
+		}
+	}
+	src := make([]byte, 97)
+	for i := range src {
+		src[i] = byte((i * 89) ^ (i * i))
+	}
+	for _, f := range extendedFilters {
+		taps := float64(f.Taps())
+		for _, x := range []float64{taps, taps + 0.25, taps * 2} {
+			expect(t, f.Get(x), float64(0))
+		}
+		for _, output := range []int{41, 97 * 3} {
+			resizer, err := NewResize(&ResizerConfig{
+				Input:      len(src),
+				Output:     output,
+				Threads:    1,
+				DisableAsm: true,
+			}, f)
+			expect(t, err, nil)
+			dst := make([]byte, output)
+			resizer.Resize(dst, src, len(src), 1, output, len(src))
+			ref := resizeFloat(src, output, f)
+			expect(t, len(ref) > output/2, true)
+			for i, v := range ref {
+				if math.Abs(float64(dst[i])-math.Min(255, math.Max(0, v))) > 1 {
+					t.Fatalf("%v: invalid pixel %v %v != %v\n", f.Name(), i, dst[i], v)
+				}
+			}
+		}
+	}
+}
+
+func TestExtendedFilters(t *testing.T) {
+	for _, f := range extendedFilters {
+		for _, rgb := range []bool{false, true} {
+			tc := NewTestCase(200, 150, false)
+			tc.filter = f
+			tc.rgb = rgb
+			runTestCase(t, tc, 1)
+		}
+	}
+}
+
+func TestFractionalSupports(t *testing.T) {
+	f, err := FilterByName("lanczos:a=2.5")
+	expect(t, err, nil)
+	expect(t, f, NewCustomLanczosFilter(2.5))
+	expect(t, f.Taps(), 3)
+	expect(t, f.Get(2.5), float64(0))
+	expect(t, f.Get(2.4) != 0, true)
+	_, _, _, taps, _ := makeDoubleKernel(&ResizerConfig{Input: 100, Output: 50}, f, 0, 0)
+	expect(t, taps, 10)
+	_, _, _, taps, _ = makeDoubleKernel(&ResizerConfig{Input: 100, Output: 200, Vertical: true}, f, 0, 0)
+	expect(t, taps, 6)
+
+	blurred := NewBlurFilter(NewLanczosFilter(3), 1.5)
+	expect(t, blurred.Taps(), 5)
+	expect(t, blurred.Get(3), NewLanczosFilter(3).Get(2))
+	expect(t, blurred.Name(), "lanczos:a=3,blur=1.5")
+	g, err := FilterByName(blurred.Name())
+	expect(t, err, nil)
+	expect(t, g, blurred)
+	sharp := NewBlurFilter(NewBilinearFilter(), 0.75)
+	expect(t, sharp.Taps(), 1)
+	expect(t, sharp.Name(), "bilinear:blur=0.75")
+	g, err = FilterByName(sharp.Name())
+	expect(t, err, nil)
+	expect(t, g, sharp)
+	expect(t, NewBlurFilter(sharp, 1), sharp)
+	_, _, _, taps, _ = makeDoubleKernel(&ResizerConfig{Input: 100, Output: 50}, sharp, 0, 0)
+	expect(t, taps, 4)
+
+	for _, f := range []Filter{f, blurred, sharp} {
+		tc := NewTestCase(200, 150, false)
+		tc.filter = f
+		runTestCase(t, tc, 1)
+	}
+}
+
+func TestEwa(t *testing.T) {
+	expect(t, NewJincFilter(3).Get(0), float64(1))
+	expect(t, NewJincFilter(3).Taps(), 4)
+	if v := NewJincFilter(3).Get(jincZeros[0]); math.Abs(v) > 1e-9 {
+		t.Fatalf("invalid jinc zero %v\n", v)
+	}
+	for _, f := range []Filter{NewJincFilter(3), NewLanczosFilter(3), NewGaussianFilter(0.75)} {
+		for _, ii := range []bool{false, true} {
+			for _, rgb := range []bool{false, true} {
+				raw := readImage(t, "testdata/lenna.jpg").(*image.YCbCr)
+				var src, ref, dst image.Image
+				src = raw.SubImage(image.Rect(0, 0, 256, 256))
+				dst = image.NewYCbCr(image.Rect(0, 0, 200, 300), raw.SubsampleRatio)
+				if rgb {
+					src = toRgb(src)
+					dst = toRgb(dst)
+				}
+				ref, err := newImageLike(src, 256, 256)
+				expect(t, err, nil)
+				expect(t, Convert(ref, src, nil), nil)
+				cfg, err := PrepareConversion(dst, src)
+				expect(t, err, nil)
+				cfg.Sampling = SamplingEWA
+				cfg.Input.Interlaced = ii
+				cfg.Output.Interlaced = ii
+				fwd, err := NewConverter(cfg, f)
+				expect(t, err, nil)
+				cfg, err = PrepareConversion(src, dst)
+				expect(t, err, nil)
+				cfg.Sampling = SamplingEWA
+				cfg.Input.Interlaced = ii
+				cfg.Output.Interlaced = ii
+				bwd, err := NewConverter(cfg, f)
+				expect(t, err, nil)
+				expect(t, fwd.Convert(dst, src), nil)
+				expect(t, bwd.Convert(src, dst), nil)
+				psnrs := []float64{28, 36, 36}
+				if ii {
+					psnrs = []float64{27, 35, 35}
+				}
+				checkPsnrs(t, ref, src, image.Rectangle{}, psnrs)
+			}
+		}
+	}
+}
+
+func testAntiRing(t *testing.T, vertical, interlaced bool, pack int, asm bool, strength float64) (byte, byte) {
+	win, wout, height := 40, 97, 6
+	if vertical {
+		win, wout, height = 40, 98, 32
+	}
+	cfg := &ResizerConfig{
+		Depth:      8,
+		Input:      win,
+		Output:     wout,
+		Vertical:   vertical,
+		Interlaced: interlaced,
+		Pack:       pack,
+		Threads:    2,
+		DisableAsm: !asm,
+		AntiRing:   strength,
+	}
+	// src is a 64 to 192 step edge
+	sw, sh, dw, dh := win*pack, height, wout*pack, height
+	if vertical {
+		sw, sh, dw, dh = height, win, height, wout
+	}
+	src := make([]byte, sw*sh)
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			v := x / pack
+			if vertical {
+				v = y
+			}
+			src[y*sw+x] = byte(64 + 128*bin(v >= win/2))
+		}
+	}
+	dst := make([]byte, dw*dh)
+	resizer, err := NewResize(cfg, NewLanczosFilter(3))
+	expect(t, err, nil)
+	resizer.Resize(dst, src, sw/pack, sh, dw, sw)
+	lo, hi := byte(255), byte(0)
+	for _, v := range dst {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+func TestAntiRing(t *testing.T) {
+	for _, asm := range []bool{false, true} {
+		for _, it := range []struct {
+			vertical, interlaced bool
+			pack                 int
+		}{
+			{false, false, 1},
+			{false, false, 4},
+			{true, false, 1},
+			{true, true, 1},
+		} {
+			lo, hi := testAntiRing(t, it.vertical, it.interlaced, it.pack, asm, 0)
+			expect(t, lo < 64 && hi > 192, true)
+			half, _ := testAntiRing(t, it.vertical, it.interlaced, it.pack, asm, 0.5)
+			expect(t, half > lo && half < 64, true)
+			lo, hi = testAntiRing(t, it.vertical, it.interlaced, it.pack, asm, 1)
+			expect(t, lo, byte(64))
+			expect(t, hi, byte(192))
+		}
+	}
+	for _, strength := range []float64{-0.5, 1.5} {
+		_, err := NewResize(&ResizerConfig{Input: 40, Output: 97, AntiRing: strength}, NewBicubicFilter())
+		expect(t, err != nil, true)
+	}
+}
+
+// TestDeringers compares the best anti-ringing passes with Go ones on
+// widths not filling simd registers
+func TestDeringers(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, vertical := range []bool{false, true} {
+		fn := getDeringer(vertical, supportedISA)
+		if fn == nil {
+			continue
+		}
+		for width := 1; width < 40; width++ {
+			height, srcw := 4, width+7
+			near := make([]int32, width)
+			for i := range near {
+				near[i] = int32(r.Intn(srcw - 1))
+			}
+			if vertical {
+				srcw = width
+				near = []int32{2, 0, 3, 1}
+			}
+			sp, dp := srcw+3, width+5
+			src := randomBytes(r, sp*4+srcw)
+			dst := randomBytes(r, dp*(height-1)+width)
+			want := append([]byte{}, dst...)
+			strength := r.Intn(257)
+			if vertical {
+				v8deringGo(want, src, near, strength, width, height, dp, sp)
+			} else {
+				h8deringGo(want, src, near, strength, width, height, dp, sp, 1)
+			}
+			fn(dst, src, near, strength, width, height, dp, sp)
+			expect(t, dst, want)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	expect(t, getNearestOffsets(4, 8, 0, 0), []int{0, 0, 1, 1, 2, 2, 3, 3})
+	expect(t, getNearestOffsets(8, 4, 0, 0), []int{1, 3, 5, 7})
+	expect(t, getNearestOffsets(8, 8, 1, 0), []int{0, 1, 2, 3})
+	expect(t, getNearestOffsets(8, 8, 1, 1), []int{0, 1, 2, 3})
+	expect(t, getNearestOffsets(5, 10, 1, 0), []int{0, 0, 1, 1, 2})
+	expect(t, getNearestOffsets(5, 10, 1, 1), []int{0, 0, 1, 1, 1})
+
+	for _, it := range []struct {
+		win, wout, pack int
+		vertical        bool
+		interlaced      bool
+	}{
+		{17, 40, 1, false, false},
+		{40, 17, 4, false, false},
+		{33, 33, 3, false, false},
+		{17, 40, 1, true, false},
+		{40, 18, 1, true, true},
+		{30, 30, 1, true, true},
+	} {
+		height := 7
+		sw, sh, dw, dh := it.win*it.pack, height, it.wout*it.pack, height
+		if it.vertical {
+			sw, sh, dw, dh = height, it.win, height, it.wout
+		}
+		src := make([]byte, sw*sh)
+		for i := range src {
+			src[i] = byte(i*7 + i/sw*13)
+		}
+		dst := make([]byte, dw*dh)
+		resizer, err := NewResize(&ResizerConfig{
+			Depth:      8,
+			Input:      it.win,
+			Output:     it.wout,
+			Vertical:   it.vertical,
+			Interlaced: it.interlaced,
+			Pack:       it.pack,
+			Threads:    3,
+			Sampling:   SamplingNearest,
+		}, nil)
+		expect(t, err, nil)
+		resizer.Resize(dst, src, sw/it.pack, sh, dw, sw)
+		for y := 0; y < dh; y++ {
+			for x := 0; x < dw; x++ {
+				sx, sy := x, y
+				if !it.vertical {
+					sx = x%it.pack + int((float64(x/it.pack)+0.5)*float64(it.win)/float64(it.wout))*it.pack
+				} else if !it.interlaced {
+					sy = int((float64(y) + 0.5) * float64(it.win) / float64(it.wout))
+				} else if it.win == it.wout {
+					sy = y
+				} else {
+					// only check pixels come from the same field
+					found := false
+					for j := y & 1; j < sh && !found; j += 2 {
+						found = src[j*sw+x] == dst[y*dw+x]
+					}
+					expect(t, found, true)
+					continue
+				}
+				expect(t, dst[y*dw+x], src[sy*sw+sx])
+			}
+		}
+	}
+
+	src := toRgb(readImage(t, "testdata/lenna.jpg"))
+	dst := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx()*2, src.Bounds().Dy()*2))
+	cfg, err := PrepareConversion(dst, src)
+	expect(t, err, nil)
+	cfg.Sampling = SamplingNearest
+	converter, err := NewConverter(cfg, nil)
+	expect(t, err, nil)
+	expect(t, converter.Convert(dst, src), nil)
+	for y := 0; y < dst.Rect.Dy(); y++ {
+		for x := 0; x < dst.Rect.Dx(); x++ {
+			expect(t, dst.RGBAAt(x, y), src.RGBAAt(x/2, y/2))
+		}
+	}
+}
+
+func TestAreaReciprocals(t *testing.T) {
+	for _, it := range []struct{ factor, recip int }{
+		{2, area2Recip},
+		{3, area3Recip},
+		{4, area4Recip},
+	} {
+		for sum := 0; sum <= 255*it.factor; sum++ {
+			expect(t, (sum+it.factor>>1)*it.recip>>16, (2*sum+it.factor)/(2*it.factor))
+		}
+	}
+}
+
+func testArea(t *testing.T, win, wout, pack int, vertical, interlaced, asm bool) {
+	height := 5
+	sw, sh, dw, dh := win*pack, height, wout*pack, height
+	if vertical {
+		sw, sh, dw, dh = 32, win, 32, wout
+	}
+	src := make([]byte, sw*sh)
+	for i := range src {
+		src[i] = byte(i*37 + i/sw*11)
+	}
+	dst := make([]byte, dw*dh)
+	cfg := &ResizerConfig{
+		Depth:      8,
+		Input:      win,
+		Output:     wout,
+		Vertical:   vertical,
+		Interlaced: interlaced,
+		Pack:       pack,
+		Threads:    2,
+		DisableAsm: !asm,
+		Sampling:   SamplingArea,
+	}
+	resizer, err := NewResize(cfg, nil)
+	expect(t, err, nil)
+	resizer.Resize(dst, src, sw/pack, sh, dw, sw)
+	field := bin(vertical && interlaced)
+	scale := float64(win) / float64(wout)
+	exact := getAreaFactor(cfg) != 0
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			i, c, idx := x/pack, x%pack, uint(0)
+			if vertical {
+				i, c, idx = y>>field, x, uint(y)&field
+			}
+			fsize := (win + int(field*(1-idx))) >> field
+			lo, hi := getAreaBounds(i, scale, field, idx)
+			sum, total := float64(0), float64(0)
+			for j := int(math.Floor(lo)); float64(j) < hi; j++ {
+				w := math.Min(hi, float64(j+1)) - math.Max(lo, float64(j))
+				k := clip(j, 0, fsize-1)
+				si := y*sw + k*pack + c
+				if vertical {
+					si = (k<<field|int(idx))*sw + c
+				}
+				sum += w * float64(src[si])
+				total += w
+			}
+			ref := int(math.Floor(sum/total + 0.5))
+			got := int(dst[y*dw+x])
+			if exact {
+				expect(t, got, ref)
+			} else if got < ref-1 || got > ref+1 {
+				t.Fatalf("invalid area pixel %v,%v: %v != %v\n", x, y, got, ref)
+			}
+		}
+	}
+}
+
+func TestArea(t *testing.T) {
+	_, _, _, taps, _ := makeAreaKernel(&ResizerConfig{Input: 2000, Output: 100, Vertical: true}, 0, 0)
+	expect(t, taps, 20)
+	_, _, _, taps, _ = makeAreaKernel(&ResizerConfig{Input: 100, Output: 40, Vertical: true}, 0, 0)
+	expect(t, taps, 4)
+	for _, asm := range []bool{false, true} {
+		for _, pack := range []int{1, 3, 4} {
+			for _, factor := range []int{2, 3, 4} {
+				testArea(t, 40*factor, 40, pack, false, false, asm)
+			}
+			testArea(t, 100, 37, pack, false, false, asm)
+			testArea(t, 400, 20, pack, false, false, asm)
+			testArea(t, 20, 50, pack, false, false, asm)
+		}
+		for _, ii := range []bool{false, true} {
+			for _, factor := range []int{2, 3, 4} {
+				testArea(t, 40*factor, 40, 1, true, ii, asm)
+			}
+			testArea(t, 100, 38, 1, true, ii, asm)
+			testArea(t, 400, 20, 1, true, ii, asm)
+			testArea(t, 20, 50, 1, true, ii, asm)
+		}
+	}
+	src := readImage(t, "testdata/lenna.jpg")
+	dst := image.NewYCbCr(image.Rect(0, 0, 100, 70), image.YCbCrSubsampleRatio420)
+	cfg, err := PrepareConversion(dst, src)
+	expect(t, err, nil)
+	cfg.Sampling = SamplingArea
+	converter, err := NewConverter(cfg, nil)
+	expect(t, err, nil)
+	expect(t, converter.Convert(dst, src), nil)
+}
+
+func TestMultiStage(t *testing.T) {
+	stages, w, h, err := planStages(&ConverterConfig{Input: Descriptor{Pack: 1}}, 512, 512, 10, 60)
+	expect(t, err, nil)
+	expect(t, len(stages), 5)
+	expect(t, w, 16)
+	expect(t, h, 128)
+	for _, ii := range []bool{false, true} {
+		for _, rgb := range []bool{false, true} {
+			var src, one, multi image.Image
+			src = readImage(t, "testdata/lenna.jpg")
+			one = image.NewYCbCr(image.Rect(0, 0, 24, 20), image.YCbCrSubsampleRatio420)
+			multi = image.NewYCbCr(image.Rect(0, 0, 24, 20), image.YCbCrSubsampleRatio420)
+			if rgb {
+				src, one, multi = toRgb(src), toRgb(one), toRgb(multi)
+			}
+			for _, dst := range []image.Image{one, multi} {
+				cfg, err := PrepareConversion(dst, src)
+				expect(t, err, nil)
+				cfg.Input.Interlaced = ii
+				cfg.Output.Interlaced = ii
+				cfg.MultiStage = dst == multi
+				converter, err := NewConverter(cfg, NewLanczosFilter(3))
+				expect(t, err, nil)
+				expect(t, len(converter.(*converterContext).stages[0]) > 0, cfg.MultiStage)
+				expect(t, converter.Convert(dst, src), nil)
+			}
+			checkPsnrs(t, one, multi, image.Rectangle{}, []float64{38, 38, 38})
+		}
+	}
+}
+
+func TestReduceKernel(t *testing.T) {
+	left, taps := getKernelTaps([]int16{0, 0, 3, 0, 5, 0})
+	expect(t, left, 2)
+	expect(t, taps, 4)
+	left, taps = getKernelTaps([]int16{0, 0, 0, 1 << Bits, 0, 0})
+	expect(t, left, 3)
+	expect(t, taps, 2)
+	coeffs := []int16{}
+	offsets := []int16{}
+	for i := 0; i < 40; i++ {
+		if i < 20 {
+			coeffs = append(coeffs, 0, 0, 1, 2, 0, 0)
+		} else {
+			coeffs = append(coeffs, 1, 0, 2, 0, 0, 3)
+		}
+		offsets = append(offsets, int16(i))
+	}
+	kernels := reduceKernel(coeffs, offsets, 6, 40, 45)
+	expect(t, len(kernels), 2)
+	expect(t, kernels[0].size, 2)
+	expect(t, kernels[0].start, 0)
+	expect(t, kernels[0].count, 20)
+	expect(t, kernels[0].offsets[5], int16(7))
+	expect(t, kernels[0].coeffs[:2], []int16{1, 2})
+	expect(t, kernels[1].size, 6)
+	expect(t, kernels[1].start, 20)
+	expect(t, kernels[1].count, 20)
+	// short runs are merged & taps stay inside the source
+	for i := 0; i < 22; i++ {
+		offsets[i] = int16(min(i, 17))
+	}
+	kernels = reduceKernel(coeffs[:6*22], offsets[:22], 6, 22, 23)
+	expect(t, len(kernels), 1)
+	expect(t, kernels[0].size, 6)
+	expect(t, kernels[0].offsets[0], int16(2))
+	expect(t, kernels[0].coeffs[:6], []int16{1, 2, 0, 0, 0, 0})
+	expect(t, kernels[0].offsets[16], int16(17))
+	expect(t, kernels[0].coeffs[16*6:17*6], []int16{0, 1, 2, 0, 0, 0})
+	expect(t, kernels[0].offsets[21], int16(17))
+	expect(t, kernels[0].coeffs[21*6:], []int16{1, 0, 2, 0, 0, 3})
+	// 6 taps trimmed from 8 taps kernels keep their own scalers
+	coeffs = coeffs[:0]
+	for i := 0; i < 40; i++ {
+		if i < 20 {
+			coeffs = append(coeffs, 0, 0, 1, 2, 0, 0, 0, 0)
+		} else {
+			coeffs = append(coeffs, 0, 1, 0, 2, 0, 0, 3, 0)
+		}
+	}
+	kernels = reduceKernel(coeffs, offsets, 8, 40, 48)
+	expect(t, kernels[0].size, 2)
+	expect(t, kernels[1].size, 6)
+	// symmetric runs are split from asymmetric ones
+	coeffs = coeffs[:0]
+	for i := 0; i < 40; i++ {
+		if i < 20 {
+			coeffs = append(coeffs, 0, 1, 3, 3, 1, 0)
+		} else {
+			coeffs = append(coeffs, 0, 1, 3, 2, 1, 0)
+		}
+		offsets[i] = int16(i)
+	}
+	kernels = reduceKernel(coeffs, offsets[:40], 6, 40, 45)
+	expect(t, len(kernels), 2)
+	expect(t, kernels[0].sym, true)
+	expect(t, kernels[1].sym, false)
+	expect(t, kernels[0].size, kernels[1].size)
+	// clipped windows lose their symmetry
+	for i := 0; i < 20; i++ {
+		offsets[i] = int16(min(i, 16))
+	}
+	kernels = reduceKernel(coeffs[:6*20], offsets[:20], 6, 20, 20)
+	expect(t, len(kernels), 1)
+	expect(t, kernels[0].sym, false)
+}
+
+// testReducedResize checks reduced kernels against unreduced weights
+func testReducedResize(t *testing.T, cfg ResizerConfig, filter Filter) {
+	height := 24
+	sw, sh, dw, dh := cfg.Input*cfg.Pack, height, cfg.Output*cfg.Pack, height
+	if cfg.Vertical {
+		sw, sh, dw, dh = 32, cfg.Input, 32, cfg.Output
+	}
+	src := make([]byte, sw*sh)
+	for i := range src {
+		src[i] = byte(i*29 + i/sw*7)
+	}
+	dst := make([]byte, dw*dh)
+	cfg.Threads = 3
+	resizer, err := NewResize(&cfg, filter)
+	expect(t, err, nil)
+	resizer.Resize(dst, src, sw/cfg.Pack, sh, dw, sw)
+	field := bin(cfg.Vertical && cfg.Interlaced)
+	bits := uint(Bits)
+	if cfg.Bits != 0 {
+		bits = uint(cfg.Bits)
+		cfg.DisableAsm = cfg.DisableAsm || bits != Bits
+	}
+	for idx := uint(0); idx < 1+field; idx++ {
+		pos, sums, cof, taps, size := makeDoubleKernel(&cfg, filter, field, idx)
+		coeffs, offsets, err := makeIntegerKernel(taps, size, cof, sums, pos, field, idx, bits)
+		expect(t, err, nil)
+		for i := 0; i < size; i++ {
+			// j is a column when vertical, a row & a channel otherwise
+			for j := 0; j < dw*dh/(size<<field); j++ {
+				pix := 0
+				for k := 0; k < taps; k++ {
+					si := (int(offsets[i])+k)*cfg.Pack + j/cfg.Pack*sw + j%cfg.Pack
+					if cfg.Vertical {
+						si = ((int(offsets[i])+k)<<field|int(idx))*sw + j
+					}
+					pix += int(src[si]) * int(coeffs[i*taps+k])
+				}
+				di := j/cfg.Pack*dw + i*cfg.Pack + j%cfg.Pack
+				if cfg.Vertical {
+					di = (i<<field|int(idx))*dw + j
+				}
+				expect(t, dst[di], u8((pix+1<<(bits-1))>>bits))
+			}
+		}
+	}
+}
+
+func TestReducedResizes(t *testing.T) {
+	for _, asm := range []bool{false, true} {
+		for _, f := range []Filter{NewBicubicFilter(), NewLanczosFilter(3), NewGaussianFilter(0.5)} {
+			for _, it := range []struct {
+				input, output, pack int
+				vertical, ii        bool
+			}{
+				{64, 1000, 1, false, false},
+				{64, 1000, 4, false, false},
+				{64, 1000, 1, true, false},
+				{64, 1000, 1, true, true},
+				{100, 90, 1, true, true},
+				{100, 150, 4, false, false},
+			} {
+				testReducedResize(t, ResizerConfig{
+					Depth:      8,
+					Input:      it.input,
+					Output:     it.output,
+					Vertical:   it.vertical,
+					Interlaced: it.ii,
+					Pack:       it.pack,
+					DisableAsm: !asm,
+				}, f)
+			}
+		}
+	}
+}
+
+type lobedFilter struct {
+	center, lobe float64
+}
+
+func (f lobedFilter) Taps() int    { return 2 }
+func (f lobedFilter) Name() string { return "lobed" }
+func (f lobedFilter) Get(x float64) float64 {
+	if math.Abs(x) < 0.5 {
+		return f.center
+	}
+	return f.lobe
+}
+
+func TestBitsAndOverflow(t *testing.T) {
+	for _, bits := range []int{-1, 15} {
+		_, err := NewResize(&ResizerConfig{Input: 64, Output: 100, Bits: bits}, NewBicubicFilter())
+		expect(t, err != nil, true)
+	}
+	// center coefficient is 2.5x the sum, which overflows int16 with 14 bits
+	lobed := lobedFilter{5, -1}
+	for _, vertical := range []bool{false, true} {
+		cfg := ResizerConfig{Depth: 8, Input: 64, Output: 100, Vertical: vertical, Pack: 1}
+		_, err := NewResize(&cfg, lobed)
+		expect(t, err != nil, true)
+		for _, bits := range []int{12, 8} {
+			cfg.Bits = bits
+			testReducedResize(t, cfg, lobed)
+		}
+	}
+	zero := lobedFilter{0, 0}
+	_, err := NewResize(&ResizerConfig{Input: 64, Output: 100}, zero)
+	expect(t, err != nil, true)
+	src := image.NewGray(image.Rect(0, 0, 64, 64))
+	dst := image.NewGray(image.Rect(0, 0, 100, 100))
+	cfg, err := PrepareConversion(dst, src)
+	expect(t, err, nil)
+	_, err = NewConverter(cfg, zero)
+	expect(t, err != nil, true)
+}
+
+func TestPackedScalers(t *testing.T) {
+	for _, asm := range []bool{false, true} {
+		for _, f := range []Filter{NewBilinearFilter(), NewBicubicFilter(), NewLanczosFilter(3), NewLanczosFilter(4)} {
+			for _, size := range [][2]int{{37, 101}, {101, 37}, {64, 64 * 3}} {
+				win, wout, height := size[0], size[1], 5
+				cfg := ResizerConfig{
+					Depth:      8,
+					Input:      win,
+					Output:     wout,
+					Pack:       4,
+					Threads:    2,
+					DisableAsm: !asm,
+				}
+				resizer, err := NewResize(&cfg, f)
+				expect(t, err, nil)
+				rgba := resizer.(*context)
+				expect(t, rgba.kernels[0][0].pack, 4)
+				cfg.Pack = 1
+				gray, err := NewResize(&cfg, f)
+				expect(t, err, nil)
+				src := make([]byte, win*height*4)
+				for i := range src {
+					src[i] = byte(i*23 + i/7)
+				}
+				dst := make([]byte, wout*height*4)
+				rgba.Resize(dst, src, win, height, wout*4, win*4)
+				for c := 0; c < 4; c++ {
+					plane := make([]byte, win*height)
+					for i := range plane {
+						plane[i] = src[i*4+c]
+					}
+					ref := make([]byte, wout*height)
+					gray.Resize(ref, plane, win, height, wout, win)
+					for i, v := range ref {
+						expect(t, dst[i*4+c], v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// floatResize resizes src with double precision kernels
+func floatResize(src *image.Gray, w, h int, filter Filter) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, w, h))
+	for i, v := range floatResizeValues(src, w, h, filter) {
+		dst.Pix[i] = u8(int(math.Floor(v + 0.5)))
+	}
+	return dst
+}
+
+// floatResizeValues returns unquantized src resized with double precision
+// kernels
+func floatResizeValues(src *image.Gray, w, h int, filter Filter) []float64 {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	vpos, vsums, vcof, vtaps, _ := makeDoubleKernel(&ResizerConfig{
+		Input: sh, Output: h, Vertical: true, DisableAsm: true}, filter, 0, 0)
+	hpos, hsums, hcof, htaps, _ := makeDoubleKernel(&ResizerConfig{
+		Input: sw, Output: w, DisableAsm: true}, filter, 0, 0)
+	buf := make([]float64, sw*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < sw; x++ {
+			for k := 0; k < vtaps; k++ {
+				buf[y*sw+x] += float64(src.GrayAt(x, int(vpos[y])+k).Y) * vcof[y*vtaps+k] / vsums[y]
+			}
+		}
+	}
+	dst := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			for k := 0; k < htaps; k++ {
+				dst[y*w+x] += buf[y*sw+int(hpos[x])+k] * hcof[x*htaps+k] / hsums[x]
+			}
+		}
+	}
+	return dst
+}
+
+func testPrecise(t *testing.T, precise bool, filter Filter) float64 {
+	src := image.NewGray(image.Rect(0, 0, 24, 24))
+	for y := 0; y < 24; y++ {
+		for x := 0; x < 24; x++ {
+			src.Pix[y*src.Stride+x] = byte(100 + (x*3+y*2)/4)
+		}
+	}
+	dst := image.NewGray(image.Rect(0, 0, 250, 170))
+	cfg, err := PrepareConversion(dst, src)
+	expect(t, err, nil)
+	cfg.Precise = precise
+	converter, err := NewConverter(cfg, filter)
+	expect(t, err, nil)
+	expect(t, converter.Convert(dst, src), nil)
+	psnrs, err := Psnr(floatResize(src, 250, 170, filter), dst)
+	expect(t, err, nil)
+	return psnrs[0]
+}
+
+func TestPrecise(t *testing.T) {
+	for _, f := range []Filter{NewBilinearFilter(), NewBicubicFilter(), NewLanczosFilter(3)} {
+		ref := testPrecise(t, false, f)
+		psnr := testPrecise(t, true, f)
+		// skipping the intermediate rounding must gain at least 15dB
+		if psnr < ref+15 {
+			t.Fatalf("invalid psnr %v < %v\n", psnr, ref+15)
+		}
+	}
+	for _, ii := range []bool{false, true} {
+		for _, rgb := range []bool{false, true} {
+			for _, antiring := range []float64{0, 1} {
+				var src, dst image.Image
+				src = readImage(t, "testdata/lenna.jpg")
+				dst = image.NewYCbCr(image.Rect(0, 0, 300, 200), image.YCbCrSubsampleRatio420)
+				if rgb {
+					src = toRgb(src)
+					dst = toRgb(dst)
+				}
+				ref, err := newImageLike(dst, 300, 200)
+				expect(t, err, nil)
+				images := []image.Image{ref, dst}
+				for i, precise := range []bool{false, true} {
+					cfg, err := PrepareConversion(images[i], src)
+					expect(t, err, nil)
+					cfg.Input.Interlaced = ii
+					cfg.Output.Interlaced = ii
+					cfg.AntiRing = antiring
+					cfg.Precise = precise
+					converter, err := NewConverter(cfg, NewLanczosFilter(3))
+					expect(t, err, nil)
+					expect(t, converter.Convert(images[i], src), nil)
+				}
+				checkPsnrs(t, ref, dst, image.Rectangle{}, []float64{50, 50, 50})
+			}
+		}
+	}
+}
+
+func TestDitherPatterns(t *testing.T) {
+	w, h := 64, 64
+	src := make([]byte, w*h*2)
+	for i := 0; i < w*h; i++ {
+		src[i*2] = 0x40 // 100.25
+		src[i*2+1] = 100
+	}
+	for _, dither := range []func(dst []byte){
+		func(dst []byte) { ditherOrderedGo(dst, src, 0, w, h, w, w*2, 1) },
+		func(dst []byte) { ditherDiffusionGo(dst, src, w, h, w, w*2, 1) },
+	} {
+		dst := make([]byte, w*h)
+		dither(dst)
+		sum := 0
+		for _, v := range dst {
+			if v != 100 && v != 101 {
+				t.Fatalf("invalid dithered value %v", v)
+			}
+			sum += int(v)
+		}
+		if mean := float64(sum) / float64(w*h); math.Abs(mean-100.25) > 0.01 {
+			t.Fatalf("invalid dithered mean %v", mean)
+		}
+	}
+}
+
+// blockError returns the rms of the mean error on every 8x8 block
+func blockError(ref []float64, img *image.Gray) float64 {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	sum := 0.0
+	for y := 0; y+8 <= h; y += 8 {
+		for x := 0; x+8 <= w; x += 8 {
+			e := 0.0
+			for j := y; j < y+8; j++ {
+				for i := x; i < x+8; i++ {
+					e += float64(img.Pix[j*img.Stride+i]) - ref[j*w+i]
+				}
+			}
+			sum += e * e / 64 / 64
+		}
+	}
+	return math.Sqrt(sum / float64(w/8*h/8))
+}
+
+func TestDither(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 24, 24))
+	for y := 0; y < 24; y++ {
+		for x := 0; x < 24; x++ {
+			src.Pix[y*src.Stride+x] = byte(100 + (x*3+y*2)/4)
+		}
+	}
+	ref := floatResizeValues(src, 256, 192, NewBicubicFilter())
+	errs := []float64{}
+	for _, dither := range []Dither{DitherNone, DitherOrdered, DitherDiffusion} {
+		outputs := []*image.Gray{}
+		for _, threads := range []int{1, 5} {
+			dst := image.NewGray(image.Rect(0, 0, 256, 192))
+			cfg, err := PrepareConversion(dst, src)
+			expect(t, err, nil)
+			cfg.Threads = threads
+			cfg.Precise = true
+			cfg.Dither[0] = dither
+			converter, err := NewConverter(cfg, NewBicubicFilter())
+			expect(t, err, nil)
+			expect(t, converter.Convert(dst, src), nil)
+			outputs = append(outputs, dst)
+		}
+		checkPsnrs(t, outputs[0], outputs[1], image.Rectangle{}, []float64{math.Inf(1)})
+		errs = append(errs, blockError(ref, outputs[0]))
+	}
+	// dithering must preserve local averages better than rounding
+	for _, v := range errs[1:] {
+		if v*2 > errs[0] {
+			t.Fatalf("invalid dithered error %v, rounded %v", v, errs[0])
+		}
+	}
+	// chroma planes can skip dithering
+	for _, ii := range []bool{false, true} {
+		yuv := readImage(t, "testdata/lenna.jpg")
+		images := []image.Image{}
+		for _, dither := range []Dither{DitherNone, DitherOrdered, DitherDiffusion} {
+			dst := image.NewYCbCr(image.Rect(0, 0, 300, 200), image.YCbCrSubsampleRatio420)
+			cfg, err := PrepareConversion(dst, yuv)
+			expect(t, err, nil)
+			cfg.Input.Interlaced = ii
+			cfg.Output.Interlaced = ii
+			cfg.Dither[0] = dither
+			converter, err := NewConverter(cfg, NewBicubicFilter())
+			expect(t, err, nil)
+			expect(t, converter.Convert(dst, yuv), nil)
+			images = append(images, dst)
+		}
+		for _, img := range images[1:] {
+			checkPsnrs(t, images[0], img, image.Rectangle{}, []float64{40, math.Inf(1), math.Inf(1)})
+		}
+	}
+}
+
+func TestBorderIndex(t *testing.T) {
+	for _, it := range []struct {
+		border Border
+		field  uint
+		n      int
+		index  []int // for -3 to n+2
+	}{
+		{BorderClamp, 0, 4, []int{0, 0, 0, 0, 1, 2, 3, 3, 3, 3}},
+		{BorderMirror, 0, 4, []int{3, 2, 1, 0, 1, 2, 3, 2, 1, 0}},
+		{BorderWrap, 0, 4, []int{1, 2, 3, 0, 1, 2, 3, 0, 1, 2}},
+		{BorderConstant, 0, 4, []int{-1, -1, -1, 0, 1, 2, 3, -1, -1, -1}},
+		{BorderMirror, 0, 1, []int{0, 0, 0, 0, 0, 0, 0}},
+		{BorderClamp, 1, 6, []int{1, 0, 1, 0, 1, 2, 3, 4, 5, 4, 5, 4}},
+		{BorderMirror, 1, 6, []int{5, 2, 3, 0, 1, 2, 3, 4, 5, 2, 3, 0}},
+		{BorderWrap, 1, 6, []int{3, 4, 5, 0, 1, 2, 3, 4, 5, 0, 1, 2}},
+	} {
+		for i, v := range it.index {
+			expect(t, getFieldIndex(i-3, it.n, it.border, it.field), v)
+		}
+	}
+}
+
+// testBorderKernel checks double kernels against brute-force borders
+func testBorderKernel(t *testing.T, cfg ResizerConfig, filter Filter) {
+	field := bin(cfg.Vertical && cfg.Interlaced)
+	scale := float64(cfg.Output) / float64(cfg.Input)
+	step := math.Min(1, scale) / float64(1+field)
+	for idx := uint(0); idx < 1+field; idx++ {
+		pos, sums, cof, taps, size := makeDoubleKernel(&cfg, filter, field, idx)
+		for i := 0; i < size; i++ {
+			ref := map[int]float64{}
+			xmid := float64(cfg.Input-cfg.Output)/float64(cfg.Output*2) + float64(i<<field|int(idx))/scale
+			left := int(math.Ceil(xmid)) - taps<<field>>1
+			sum := 0.0
+			for j := left; j < left+taps<<field; j++ {
+				if field != 0 && idx^uint(j&1) != 0 {
+					continue
+				}
+				w := filter.Get(math.Abs(xmid-float64(j)) * step)
+				src := j
+				if cfg.Border == BorderClamp || cfg.Border == BorderMirror {
+					src = getFieldIndex(j, cfg.Input, cfg.Border, field)
+				}
+				ref[src] += w
+				sum += w
+			}
+			expect(t, math.Abs(sums[i]-sum) < 1e-9, true)
+			for k := 0; k < taps; k++ {
+				src := int(pos[i]) + k<<field
+				if field != 0 {
+					src = (int(pos[i])+int(field-idx))&^1 + k<<field | int(idx)
+				}
+				if math.Abs(ref[src]-cof[i*taps+k]) > 1e-9 {
+					t.Fatalf("invalid weight at %v:%v, %v != %v", i, src, cof[i*taps+k], ref[src])
+				}
+				delete(ref, src)
+			}
+			for src, w := range ref {
+				if w != 0 {
+					t.Fatalf("missing weight %v at %v:%v", w, i, src)
+				}
+			}
+		}
+	}
+}
+
+func TestBorderKernels(t *testing.T) {
+	for _, border := range []Border{BorderClamp, BorderMirror, BorderWrap, BorderConstant} {
+		for _, it := range []struct {
+			input, output int
+			vertical, ii  bool
+		}{
+			{32, 100, false, false},
+			{100, 32, false, false},
+			{32, 100, true, true},
+			{100, 32, true, true},
+		} {
+			testBorderKernel(t, ResizerConfig{
+				Input:      it.input,
+				Output:     it.output,
+				Vertical:   it.vertical,
+				Interlaced: it.ii,
+				Border:     border,
+				DisableAsm: true,
+			}, NewLanczosFilter(3))
+		}
+	}
+}
+
+func resizeBorder(t *testing.T, cfg ResizerConfig, src []byte, width, height int) []byte {
+	resizer, err := NewResize(&cfg, NewLanczosFilter(3))
+	expect(t, err, nil)
+	dw, dh := cfg.Output, height
+	if cfg.Vertical {
+		dw, dh = width, cfg.Output
+	}
+	dst := make([]byte, dw*dh*cfg.Pack)
+	resizer.Resize(dst, src, width, height, dw*cfg.Pack, width*cfg.Pack)
+	return dst
+}
+
+func TestBorders(t *testing.T) {
+	for _, border := range []Border{BorderClamp, BorderMirror, BorderWrap, BorderConstant} {
+		for _, it := range []struct {
+			pack         int
+			vertical, ii bool
+		}{
+			{1, false, false},
+			{4, false, false},
+			{1, true, false},
+			{1, true, true},
+		} {
+			width, height := 32*it.pack, 40
+			if it.vertical {
+				width, height = 40, 32
+			}
+			cfg := ResizerConfig{
+				Input:       32,
+				Output:      64,
+				Vertical:    it.vertical,
+				Interlaced:  it.ii,
+				Pack:        it.pack,
+				Threads:     3,
+				Border:      border,
+				BorderValue: [4]byte{1, 2, 3, 4},
+			}
+			src := make([]byte, width*height)
+			for i := range src {
+				src[i] = byte(i*37 + i/width*11)
+			}
+			w := width / it.pack
+			ref := resizeBorder(t, cfg, src, w, height)
+			cfg.DisableAsm = true
+			expect(t, bytes.Equal(ref, resizeBorder(t, cfg, src, w, height)), true)
+			if border != BorderWrap {
+				continue
+			}
+			// shifting tiled sources by 4 pixels shifts outputs by 8 pixels
+			shifted := make([]byte, len(src))
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					sx, sy := (x+4*it.pack)%width, y
+					if it.vertical {
+						sx, sy = x, (y+4)%height
+					}
+					shifted[y*width+x] = src[sy*width+sx]
+				}
+			}
+			dst := resizeBorder(t, cfg, shifted, w, height)
+			dw, dh := 64*it.pack, height
+			if it.vertical {
+				dw, dh = width, 64
+			}
+			for y := 0; y < dh; y++ {
+				for x := 0; x < dw; x++ {
+					sx, sy := (x+8*it.pack)%dw, y
+					if it.vertical {
+						sx, sy = x, (y+8)%dh
+					}
+					expect(t, dst[y*dw+x], ref[sy*dw+sx])
+				}
+			}
+		}
+	}
+	// constant borders matching the image do not change it
+	cfg := ResizerConfig{Input: 64, Output: 20, Pack: 1, Threads: 1, Border: BorderConstant, BorderValue: [4]byte{77}}
+	src := bytes.Repeat([]byte{77}, 64*4)
+	for _, v := range resizeBorder(t, cfg, src, 64, 4) {
+		expect(t, v, byte(77))
+	}
+	cfg.BorderValue[0] = 0
+	dst := resizeBorder(t, cfg, src, 64, 4)
+	expect(t, dst[0] < 77, true)
+	expect(t, dst[10], byte(77))
+	for _, sampling := range []Sampling{SamplingFilter, SamplingEWA} {
+		src := image.NewGray(image.Rect(0, 0, 32, 32))
+		for i := range src.Pix {
+			src.Pix[i] = 77
+		}
+		dst := image.NewGray(image.Rect(0, 0, 50, 20))
+		cfg, err := PrepareConversion(dst, src)
+		expect(t, err, nil)
+		cfg.Sampling = sampling
+		cfg.Border = BorderConstant
+		cfg.BorderValue[0] = 77
+		converter, err := NewConverter(cfg, NewLanczosFilter(3))
+		expect(t, err, nil)
+		expect(t, converter.Convert(dst, src), nil)
+		for _, v := range dst.Pix {
+			expect(t, v, byte(77))
+		}
+	}
+}
+
+func resizeScalers(t *testing.T, cfg ResizerConfig, filter Filter, width, height int) []byte {
+	sw, sh, dw, dh := cfg.Input, height, cfg.Output, height
+	if cfg.Vertical {
+		sw, sh, dw, dh = width, cfg.Input, width, cfg.Output
+	}
+	src := make([]byte, sw*sh)
+	for i := range src {
+		src[i] = byte(i*37 + i/sw*11)
+	}
+	dst := make([]byte, dw*dh)
+	cfg.Depth = 8
+	cfg.Pack = 1
+	cfg.Threads = 2
+	resizer, err := NewResize(&cfg, filter)
+	expect(t, err, nil)
+	resizer.Resize(dst, src, sw, sh, dw, sw)
+	return dst
+}
+
+func TestAvx2Scalers(t *testing.T) {
+	if SupportedISA() < ISAAVX2 {
+		t.Skip("avx2 unavailable")
+	}
+	filters := []Filter{
+		NewBilinearFilter(),
+		NewBicubicFilter(),
+		NewLanczosFilter(3),
+		NewLanczosFilter(4),
+		NewLanczosFilter(5),
+		NewLanczosFilter(6),
+		NewLanczosFilter(8),
+	}
+	for _, f := range filters {
+		for _, vertical := range []bool{false, true} {
+			for _, size := range [][2]int{{100, 64}, {64, 127}, {77, 31}, {40, 16}} {
+				for _, width := range []int{16, 31, 32, 67, 96} {
+					cfg := ResizerConfig{
+						Input:    size[0],
+						Output:   size[1],
+						Vertical: vertical,
+						ISA:      ISASSE2,
+					}
+					sse := resizeScalers(t, cfg, f, width, 7)
+					cfg.ISA = ISAAVX2
+					avx := resizeScalers(t, cfg, f, width, 7)
+					cfg.DisableAsm = true
+					ref := resizeScalers(t, cfg, f, width, 7)
+					expect(t, avx, ref)
+					expect(t, sse, ref)
+				}
+			}
+		}
+	}
+}
+
+func TestISA(t *testing.T) {
+	for i := ISAAuto; i <= ISAAVX512BW; i++ {
+		isa, err := ParseISA(strings.ToUpper(i.String()))
+		expect(t, err, nil)
+		expect(t, isa, i)
+	}
+	_, err := ParseISA("mmx")
+	expect(t, err != nil, true)
+	expect(t, limitISA(ISAAVX2, ""), ISAAVX2)
+	expect(t, limitISA(ISAAVX2, "sse2"), ISASSE2)
+	expect(t, limitISA(ISASSE2, "avx2"), ISASSE2)
+	expect(t, limitISA(ISAAVX2, "bogus"), ISAAVX2)
+	expect(t, getISA(&ResizerConfig{DisableAsm: true}), ISAGo)
+	expect(t, getISA(&ResizerConfig{}), SupportedISA())
+	expect(t, getISA(&ResizerConfig{ISA: ISAGo}), ISAGo)
+	_, info := getScaler(horizontalScaler, 6, ISAGo)
+	expect(t, info.Name, "h8scale6Go")
+	_, info = getScaler(verticalScaler, 14, ISAGo)
+	expect(t, info.Name, "v8scaleNGo")
+	if hasAsm() {
+		_, info = getScaler(horizontalScaler, 18, ISASSE41)
+		expect(t, info.Name, "h8scaleNAmd64")
+		_, info = getScaler(horizontalScaler, 6, ISASSE2)
+		expect(t, info.Name, "h8scale6Amd64")
+		_, info = getScaler(verticalScaler, 4, ISASSE2)
+		expect(t, info.Name, "v8scale4Amd64")
+		_, info = getScaler(packedScaler, 4, ISAAVX512BW)
+		expect(t, info.Name, "h8p4scale4Amd64")
+	}
+	_, err = NewResize(&ResizerConfig{Input: 8, Output: 4, ISA: -1}, NewBilinearFilter())
+	expect(t, err != nil, true)
+	src := image.NewYCbCr(image.Rect(0, 0, 256, 128), image.YCbCrSubsampleRatio420)
+	dst := image.NewYCbCr(image.Rect(0, 0, 96, 64), image.YCbCrSubsampleRatio420)
+	for _, isa := range []ISA{ISAAuto, ISAGo, ISASSE2, ISAAVX2} {
+		cfg, err := PrepareConversion(dst, src)
+		expect(t, err, nil)
+		cfg.ISA = isa
+		converter, err := NewConverter(cfg, NewBicubicFilter())
+		expect(t, err, nil)
+		infos := converter.Scalers()
+		// 2 passes on 3 planes
+		expect(t, len(infos) >= 6, true)
+		best := ISAGo
+		for _, info := range infos {
+			if info.ISA > best {
+				best = info.ISA
+			}
+		}
+		limit := getISA(&ResizerConfig{ISA: isa})
+		expect(t, best <= limit, true)
+		if isa == ISAGo || isa == ISASSE2 && SupportedISA() >= ISASSE2 {
+			expect(t, best, isa)
+		}
+		expect(t, converter.Convert(dst, src), nil)
+	}
+}
+
+func TestNarrowScalers(t *testing.T) {
+	isas := []ISA{ISASSE2, ISAAVX2}
+	filters := []Filter{
+		NewBicubicFilter(),
+		NewLanczosFilter(3),
+		NewLanczosFilter(7),
+		NewLanczosFilter(8),
+	}
+	names := map[string]bool{}
+	for _, isa := range isas {
+		for _, f := range filters {
+			for _, vertical := range []bool{false, true} {
+				for _, size := range [][2]int{{40, 3}, {20, 15}, {19, 33}, {33, 17}} {
+					for _, width := range []int{1, 2, 7, 15, 17, 31, 33} {
+						cfg := ResizerConfig{
+							Input:    size[0],
+							Output:   size[1],
+							Vertical: vertical,
+							ISA:      isa,
+						}
+						r, err := NewResize(&cfg, f)
+						expect(t, err, nil)
+						for _, info := range r.Scalers(width) {
+							names[info.Name] = true
+							// avx2 vertical scalers hand narrow planes to sse2
+							if vertical && width < 32 {
+								expect(t, info.ISA <= ISASSE2, true)
+							}
+						}
+						got := resizeScalers(t, cfg, f, width, 5)
+						cfg.DisableAsm = true
+						ref := resizeScalers(t, cfg, f, width, 5)
+						expect(t, got, ref)
+					}
+				}
+			}
+		}
+	}
+	if SupportedISA() >= ISASSE2 {
+		for _, name := range []string{"h8scale6Amd64", "h8scale14Amd64", "h8scale16Amd64", "v8scale14Amd64", "v8scale16Amd64"} {
+			expect(t, names[name], true)
+		}
+	}
+	// tiny planes use simd too
+	fill := func(w, h int, rgb bool) image.Image {
+		img := image.NewYCbCr(image.Rect(0, 0, w, h), image.YCbCrSubsampleRatio420)
+		for i := range img.Y {
+			img.Y[i] = byte(i*7 + i/w*3)
+		}
+		for i := range img.Cb {
+			img.Cb[i] = byte(i * 11)
+			img.Cr[i] = byte(i * 13)
+		}
+		if rgb {
+			return toRgb(img)
+		}
+		return img
+	}
+	for _, rgb := range []bool{false, true} {
+		for _, size := range [][2]int{{9, 6}, {3, 30}, {30, 4}} {
+			small := fill(size[0], size[1], rgb)
+			big := fill(37, 29, rgb)
+			for _, it := range [][2]image.Image{{small, big}, {big, small}} {
+				dst, src := it[0], it[1]
+				ref := fill(dst.Bounds().Dx(), dst.Bounds().Dy(), rgb)
+				convert(t, dst, src, true, false, NewBicubicFilter())
+				convert(t, ref, src, false, false, NewBicubicFilter())
+				expect(t, dst, ref)
+			}
+		}
+	}
+}
+
+func TestFoldedScalers(t *testing.T) {
+	filters := []Filter{
+		NewBilinearFilter(),
+		NewBicubicFilter(),
+		NewLanczosFilter(3),
+		NewLanczosFilter(5),
+		NewLanczosFilter(8),
+	}
+	names := map[string]bool{}
+	for _, isa := range []ISA{ISASSE2, ISAAVX2} {
+		for _, f := range filters {
+			for _, vertical := range []bool{false, true} {
+				for _, size := range [][2]int{{64, 32}, {66, 33}, {48, 32}, {32, 48}, {160, 40}} {
+					for _, width := range []int{1, 7, 16, 33, 64} {
+						cfg := ResizerConfig{
+							Input:    size[0],
+							Output:   size[1],
+							Vertical: vertical,
+							ISA:      isa,
+						}
+						r, err := NewResize(&cfg, f)
+						expect(t, err, nil)
+						for _, info := range r.Scalers(width) {
+							names[info.Name] = true
+						}
+						got := resizeScalers(t, cfg, f, width, 5)
+						cfg.DisableAsm = true
+						ref := resizeScalers(t, cfg, f, width, 5)
+						expect(t, got, ref)
+					}
+				}
+			}
+		}
+	}
+	if SupportedISA() >= ISASSE2 {
+		for _, name := range []string{"v8fold4Amd64", "v8fold8Amd64", "v8fold12Amd64", "v8fold16Amd64"} {
+			expect(t, names[name], true)
+		}
+	}
+	if SupportedISA() >= ISAAVX2 {
+		expect(t, names["v8fold12Avx2"], true)
+	}
+}
+
+func TestShuffleScalers(t *testing.T) {
+	// windows wider than 16 bytes or lines shorter than 16 bytes
+	_, ok := prepareShuffleCoeffs(make([]int16, 16*2), make([]int16, 16), 16, 2, 15, ISASSSE3)
+	expect(t, ok, false)
+	off := make([]int16, 16)
+	for i := range off {
+		off[i] = int16(i * 3)
+	}
+	_, ok = prepareShuffleCoeffs(make([]int16, 16*2), off, 16, 2, 64, ISASSSE3)
+	expect(t, ok, false)
+	// windows are moved back inside lines
+	for i := range off {
+		off[i] = int16(i + 10)
+	}
+	cof, ok := prepareShuffleCoeffs(make([]int16, 16*2), off, 16, 2, 27, ISASSSE3)
+	expect(t, ok, true)
+	expect(t, cof[16*2+16:16*2+18], []int16{10, 11})
+	expect(t, cof[16*2], int16(0x0100))
+	expect(t, cof[16*2+8], int16(0x0807))
+	filters := []Filter{
+		NewBilinearFilter(),
+		NewBicubicFilter(),
+	}
+	names := map[string]bool{}
+	for _, isa := range []ISA{ISASSSE3, ISAAVX2} {
+		for _, f := range filters {
+			for _, border := range []Border{BorderClamp, BorderMirror} {
+				for _, size := range [][2]int{{40, 80}, {17, 61}, {64, 32}, {66, 40}, {100, 20}} {
+					for _, width := range []int{1, 7, 16, 33} {
+						cfg := ResizerConfig{
+							Input:  size[0],
+							Output: size[1],
+							Border: border,
+							ISA:    isa,
+						}
+						r, err := NewResize(&cfg, f)
+						expect(t, err, nil)
+						for _, info := range r.Scalers(width) {
+							names[info.Name] = true
+						}
+						got := resizeScalers(t, cfg, f, width, 5)
+						cfg.DisableAsm = true
+						ref := resizeScalers(t, cfg, f, width, 5)
+						expect(t, got, ref)
+					}
+				}
+			}
+		}
+	}
+	if SupportedISA() >= ISASSSE3 {
+		expect(t, names["h8shuffle2Ssse3"], true)
+		expect(t, names["h8shuffle4Ssse3"], true)
+	}
+	if SupportedISA() >= ISAAVX2 {
+		expect(t, names["h8shuffle2Avx2"], true)
+		expect(t, names["h8shuffle4Avx2"], true)
+	}
+}
+
+func TestDepth16Scalers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	names := map[string]bool{}
+	for _, vertical := range []bool{false, true} {
+		kind, ref := horizontal16Scaler, h16scaleNGo
+		if vertical {
+			kind, ref = vertical16Scaler, v16scaleNGo
+		}
+		for taps := 2; taps <= 16; taps += 2 {
+			for _, isa := range []ISA{ISAGo, SupportedISA()} {
+				fn, info := getScaler(kind, taps, isa)
+				names[info.Name] = true
+				for _, width := range []int{1, 5, 8, 13, 64} {
+					height, rows := 3, 3
+					off := make([]int16, width)
+					for i := range off {
+						off[i] = int16(i * 3 / 2)
+					}
+					srcw := int(off[width-1]) + taps
+					size := width
+					if vertical {
+						height, rows, srcw, size = 4, 4+taps, width, 4
+						off = []int16{1, 0, 2, 1}
+					}
+					sp, dp := srcw*2+6, width*2+4
+					src := make([]byte, sp*(rows-1)+srcw*2)
+					for i := 0; i < len(src); i += 2 {
+						// saturate samples often
+						v := []int{0, 0xFFFF, rnd.Intn(0x10000)}[rnd.Intn(3)]
+						src[i], src[i+1] = byte(v), byte(v>>8)
+					}
+					// sums of samples times coeffs fit in 32 bits
+					cof := make([]int16, size*taps)
+					for i := range cof {
+						cof[i] = int16(rnd.Intn(30000/taps) - 6000/taps)
+					}
+					want := make([]byte, dp*(height-1)+width*2)
+					got := make([]byte, len(want))
+					ref(want, src, cof, off, taps, width, height, dp, sp)
+					if isa == ISAGo {
+						// wide scalers compute the same samples
+						wide := newHorizontalWideScaler(Bits, true, true)
+						if vertical {
+							wide = newVerticalWideScaler(Bits, true)
+						}
+						wide(got, src, cof, off, taps, width, height, dp, sp)
+						expect(t, got, want)
+					}
+					fn(got, src, prepare16Coeffs(cof, size, taps, vertical, true, true, info.ISA), off, taps, width, height, dp, sp)
+					expect(t, got, want)
+				}
+			}
+		}
+	}
+	expect(t, names["h16scale12Go"], true)
+	expect(t, names["v16scale12Go"], true)
+	if SupportedISA() >= ISASSE2 {
+		expect(t, names["h16scale16Amd64"], true)
+		expect(t, names["v16scale16Amd64"], true)
+	}
+}
+
+func TestDepth16(t *testing.T) {
+	_, err := NewResize(&ResizerConfig{Depth: 12, Input: 64, Output: 100}, NewBicubicFilter())
+	expect(t, err != nil, true)
+	rnd := rand.New(rand.NewSource(0))
+	for _, vertical := range []bool{false, true} {
+		for _, border := range []Border{BorderClamp, BorderMirror, BorderWrap, BorderConstant} {
+			for _, size := range [][2]int{{37, 101}, {101, 37}, {64, 128}} {
+				for _, threads := range []int{1, 3} {
+					win, wout, pack := size[0], size[1], 1+int(bin(!vertical && threads > 1))
+					width, height := 45, win
+					dstw, dsth := width, wout
+					if !vertical {
+						width, height, dstw, dsth = win, 7, wout, 7
+					}
+					sp, dp := width*pack*2+6, dstw*pack*2+4
+					src := make([]byte, sp*(height-1)+width*pack*2)
+					for i := range src {
+						src[i] = byte(rnd.Intn(256))
+					}
+					var outputs [2][]byte
+					for i, asm := range []bool{false, true} {
+						cfg := ResizerConfig{
+							Depth:      16,
+							Input:      win,
+							Output:     wout,
+							Vertical:   vertical,
+							Pack:       pack,
+							Threads:    threads,
+							Border:     border,
+							AntiRing:   float64(threads-1) / 2,
+							DisableAsm: !asm,
+						}
+						r, err := NewResize(&cfg, NewLanczosFilter(3))
+						expect(t, err, nil)
+						for _, info := range r.Scalers(width) {
+							expect(t, info.Name[1:3], "16")
+							// 16-bit asm scalers stop at 16 taps
+							if asm && hasAsm() && getISA(&cfg) > ISAGo && info.Taps > 0 {
+								expect(t, info.ISA != ISAGo, true)
+							}
+						}
+						outputs[i] = make([]byte, dp*(dsth-1)+dstw*pack*2)
+						r.Resize(outputs[i], src, width, height, dp, sp)
+					}
+					expect(t, outputs[1], outputs[0])
+				}
+			}
+		}
+	}
+	// 16-bit samples keep 8-bit ones shifted by 8 bits within rounding
+	for _, vertical := range []bool{false, true} {
+		cfg := ResizerConfig{Input: 64, Output: 100, Vertical: vertical}
+		r8, err := NewResize(&cfg, NewBicubicFilter())
+		expect(t, err, nil)
+		cfg.Depth = 16
+		r16, err := NewResize(&cfg, NewBicubicFilter())
+		expect(t, err, nil)
+		width, height, dstw, dsth := 64, 8, 100, 8
+		if vertical {
+			width, height, dstw, dsth = 8, 64, 8, 100
+		}
+		src8 := make([]byte, width*height)
+		src16 := make([]byte, width*height*2)
+		for i := range src8 {
+			src8[i] = byte(rnd.Intn(256))
+			src16[i*2+1] = src8[i]
+		}
+		dst8 := make([]byte, dstw*dsth)
+		dst16 := make([]byte, dstw*dsth*2)
+		r8.Resize(dst8, src8, width, height, dstw, width)
+		r16.Resize(dst16, src16, width, height, dstw*2, width*2)
+		for i, v := range dst8 {
+			w := int(dst16[i*2+1]) + int(dst16[i*2]>>7)
+			if w > 255 {
+				w = 255
+			}
+			expect(t, math.Abs(float64(w-int(v))) <= 1, true)
+		}
+	}
+}
+
+func TestWideScalers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	for _, it := range []struct {
+		vertical, in, out bool
+		name              string
+	}{
+		{false, false, true, "h8to16scale"},
+		{false, true, false, "h16to8scale"},
+		{true, false, true, "v8to16scale"},
+	} {
+		for _, border := range []Border{BorderClamp, BorderWrap} {
+			for _, size := range [][2]int{{37, 101}, {101, 37}} {
+				for _, pack := range []int{1, 2} {
+					if it.vertical && pack > 1 {
+						continue
+					}
+					ss, ds := 1+int(bin(it.in)), 1+int(bin(it.out))
+					win, wout := size[0], size[1]
+					width, height, dstw, dsth := win, 7, wout, 7
+					if it.vertical {
+						width, height, dstw, dsth = 45, win, 45, wout
+					}
+					sp, dp := width*pack*ss+6, dstw*pack*ds+4
+					src := randomBytes(rnd, sp*(height-1)+width*pack*ss)
+					var outputs [2][]byte
+					for i, asm := range []bool{false, true} {
+						cfg := ResizerConfig{
+							Input:      win,
+							Output:     wout,
+							Vertical:   it.vertical,
+							Pack:       pack,
+							Threads:    2,
+							Border:     border,
+							WideInput:  it.in,
+							WideOutput: it.out,
+							DisableAsm: !asm,
+						}
+						r, err := NewResize(&cfg, NewLanczosFilter(3))
+						expect(t, err, nil)
+						// upscales fit in asm scalers taps
+						for _, info := range r.Scalers(width) {
+							if asm && hasAsm() && getISA(&cfg) > ISAGo && win < wout {
+								expect(t, strings.HasPrefix(info.Name, it.name), true)
+							}
+						}
+						outputs[i] = make([]byte, dp*(dsth-1)+dstw*pack*ds)
+						r.Resize(outputs[i], src, width, height, dp, sp)
+					}
+					expect(t, outputs[1], outputs[0])
+				}
+			}
+		}
+	}
+}
+
+// generatedScaler matches scalers generated by rezgen
+var generatedScaler = regexp.MustCompile(`^(h8scale|h8shuffle|h8p4scale|v8scale|v8fold|h16scale|v16scale|h8to16scale|h16to8scale|v8to16scale|h8dering|v8dering)(\d*|N)(Amd64|Ssse3|Avx2)$`)
+
+var generatedISAs = map[string]ISA{"Amd64": ISASSE2, "Ssse3": ISASSSE3, "Avx2": ISAAVX2}
+
+func generatedScalers() []*asm.Function {
+	fns := []*asm.Function{}
+	for _, generate := range []func(*asm.Asm){gen.Horizontal, gen.Vertical} {
+		a := asm.NewAsm(ioutil.Discard)
+		generate(a)
+		fns = append(fns, a.Functions()...)
+	}
+	return fns
+}
+
+func randomBytes(r *rand.Rand, size int) []byte {
+	buf := make([]byte, size)
+	for i := range buf {
+		buf[i] = byte(r.Intn(256))
+	}
+	return buf
+}
+
+// randomCoeffs returns coeffs keeping 8-bit sums within 32 bits, mirrored
+// on every pixel if sym is set
+func randomCoeffs(r *rand.Rand, size, taps int, sym bool) []int16 {
+	cof := make([]int16, size*taps)
+	for i := range cof {
+		cof[i] = int16(r.Intn(1<<14) - 1<<12)
+	}
+	for i := 0; sym && i < size; i++ {
+		for j := 0; j < taps/2; j++ {
+			cof[i*taps+taps-1-j] = cof[i*taps+j]
+		}
+	}
+	return cof
+}
+
+// runGenerated runs fn in m on dst and compares it with ref applied on a
+// copy of dst with raw coeffs
+func runGenerated(t *testing.T, m *asm.Machine, fn *asm.Function, ref scaler,
+	dst, src []byte, raw, cof, off []int16, taps, width, height, dp, sp int) {
+	want := append([]byte{}, dst...)
+	ref(want, src, raw, off, taps, width, height, dp, sp)
+	err := m.Call(fn, dst, src, cof, off, taps, width, height, dp, sp)
+	if err != nil {
+		t.Fatalf("width %v: %v", width, err)
+	}
+	if !bytes.Equal(dst, want) {
+		t.Fatalf("width %v: got %v, want %v", width, dst, want)
+	}
+}
+
+func testGeneratedHorizontal(t *testing.T, m *asm.Machine, r *rand.Rand, fn *asm.Function, kind string, taps int, isa ISA) {
+	pack, ref := 1, scaler(h8scaleNGo)
+	if kind == "h8p4scale" {
+		pack, ref = 4, h8p4scaleNGo
+	}
+	for _, width := range []int{1, 7, 16, 17, 33, 64, 71} {
+		for _, step := range []int{1, 2, 3} {
+			off := make([]int16, width)
+			for i := range off {
+				off[i] = int16(i * step / 2 * pack)
+			}
+			srcw := int(off[width-1]) + taps*pack
+			height, sp, dp := 3, srcw+5, width*pack+3
+			src := randomBytes(r, sp*(height-1)+srcw)
+			dst := randomBytes(r, dp*(height-1)+width*pack)
+			raw := randomCoeffs(r, width, taps, false)
+			var cof []int16
+			switch kind {
+			case "h8p4scale":
+				cof, _ = preparePackedCoeffs(raw, isa)
+			case "h8shuffle":
+				var ok bool
+				if cof, ok = prepareShuffleCoeffs(raw, off, width, taps, srcw, isa); !ok {
+					continue
+				}
+			default:
+				cof, _ = prepareCoeffs(&ResizerConfig{Pack: 1}, raw, width, taps, isa)
+			}
+			runGenerated(t, m, fn, ref, dst, src, raw, cof, off, taps, width, height, dp, sp)
+		}
+	}
+}
+
+func testGeneratedVertical(t *testing.T, m *asm.Machine, r *rand.Rand, fn *asm.Function, taps int, sym bool, isa ISA) {
+	for _, width := range []int{1, 7, 15, 16, 17, 31, 32, 33, 64, 70} {
+		height := 5
+		off := make([]int16, height)
+		rows, cur := taps, 0
+		for i := range off {
+			off[i] = int16(r.Intn(4) - 1)
+			if cur+int(off[i]) < 0 {
+				off[i] = 0
+			}
+			cur += int(off[i])
+			rows = max(rows, cur+taps)
+		}
+		sp, dp := width+5, width+3
+		src := randomBytes(r, sp*(rows-1)+width)
+		dst := randomBytes(r, dp*(height-1)+width)
+		raw := randomCoeffs(r, height, taps, sym)
+		cof, _ := prepareCoeffs(&ResizerConfig{Pack: 1, Vertical: true}, raw, height, taps, isa)
+		runGenerated(t, m, fn, v8scaleNGo, dst, src, raw, cof, off, taps, width, height, dp, sp)
+	}
+}
+
+// testGeneratedDepth16 tests scalers on 16-bit sources if in is set and
+// 16-bit destinations if out is set
+func testGeneratedDepth16(t *testing.T, m *asm.Machine, r *rand.Rand, fn *asm.Function, taps int, vertical, in, out bool, isa ISA) {
+	ss, ds := 1+int(bin(in)), 1+int(bin(out))
+	for _, width := range []int{1, 3, 4, 5, 7, 8, 9, 16, 17, 33} {
+		height, rows := 3, 3
+		off := make([]int16, width)
+		for i := range off {
+			off[i] = int16(i * 3 / 2)
+		}
+		srcw := int(off[width-1]) + taps
+		size, ref := width, newHorizontalWideScaler(Bits, in, out)
+		if vertical {
+			height, rows, srcw = 4, 4+taps, width
+			off = []int16{1, 0, 2, 1}
+			size, ref = height, newVerticalWideScaler(Bits, in)
+		}
+		sp, dp := srcw*ss+6, width*ds+4
+		// many minimal & maximal samples
+		src := randomBytes(r, sp*(rows-1)+srcw*ss)
+		for i := 0; i+ss-1 < len(src); i += ss {
+			switch r.Intn(4) {
+			case 0:
+				copy(src[i:i+ss], []byte{0, 0})
+			case 1:
+				copy(src[i:i+ss], []byte{0xFF, 0xFF})
+			}
+		}
+		dst := randomBytes(r, dp*(height-1)+width*ds)
+		// keep 16-bit sums within 32 bits
+		raw := make([]int16, size*taps)
+		limit := 24000 / taps
+		for i := range raw {
+			raw[i] = int16(r.Intn(limit+limit/4) - limit/4)
+		}
+		cof := prepare16Coeffs(raw, size, taps, vertical, in, out, isa)
+		runGenerated(t, m, fn, ref, dst, src, raw, cof, off, taps, width, height, dp, sp)
+	}
+}
+
+// testGeneratedDering tests anti-ringing passes on whole registers only,
+// with near samples & strengths covering every clamp
+func testGeneratedDering(t *testing.T, m *asm.Machine, r *rand.Rand, fn *asm.Function, vertical bool) {
+	step := 8
+	if vertical {
+		step = 16
+	}
+	for _, strength := range []int{0, 1, 128, 255, 256} {
+		for width := step; width <= 4*step; width += step {
+			height, srcw, rows := 3, width+9, 3
+			near := make([]int32, width)
+			for i := range near {
+				near[i] = int32(r.Intn(srcw - 1))
+			}
+			if vertical {
+				srcw, rows = width, 6
+				near = []int32{0, 3, 1, 4, 2, 0}[:height]
+			}
+			sp, dp := srcw+5, width+3
+			src := randomBytes(r, sp*(rows-1)+srcw)
+			dst := randomBytes(r, dp*(height-1)+width)
+			want := append([]byte{}, dst...)
+			if vertical {
+				v8deringGo(want, src, near, strength, width, height, dp, sp)
+			} else {
+				h8deringGo(want, src, near, strength, width, height, dp, sp, 1)
+			}
+			err := m.Call(fn, dst, src, near, strength, width, height, dp, sp)
+			expect(t, err, nil)
+			for i := range dst {
+				if dst[i] != want[i] {
+					t.Fatalf("strength %v width %v: invalid sample %v: %v != %v", strength, width, i, dst[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratedScalers(t *testing.T) {
+	m := &asm.Machine{}
+	r := rand.New(rand.NewSource(0))
+	fns := generatedScalers()
+	count := 0
+	for _, fn := range fns {
+		match := generatedScaler.FindStringSubmatch(fn.Name)
+		if match == nil {
+			t.Errorf("%v: unknown scaler", fn.Name)
+			continue
+		}
+		kind, isa := match[1], generatedISAs[match[3]]
+		taps, err := strconv.Atoi(match[2])
+		if err != nil {
+			taps = 18
+			if kind == "h8p4scale" {
+				taps = 10
+			}
+		}
+		t.Run(fn.Name, func(t *testing.T) {
+			switch kind {
+			case "h16scale", "v16scale":
+				testGeneratedDepth16(t, m, r, fn, taps, kind[0] == 'v', true, true, isa)
+			case "h8to16scale", "v8to16scale":
+				testGeneratedDepth16(t, m, r, fn, taps, kind[0] == 'v', false, true, isa)
+			case "h16to8scale":
+				testGeneratedDepth16(t, m, r, fn, taps, false, true, false, isa)
+			case "h8dering", "v8dering":
+				testGeneratedDering(t, m, r, fn, kind[0] == 'v')
+			case "v8scale", "v8fold":
+				testGeneratedVertical(t, m, r, fn, taps, kind == "v8fold", isa)
+			default:
+				testGeneratedHorizontal(t, m, r, fn, kind, taps, isa)
+			}
+		})
+		count++
+	}
+	expect(t, count > 0, true)
+	expect(t, count, len(fns))
+}
+
+func TestAutotune(t *testing.T) {
+	src := image.NewYCbCr(image.Rect(0, 0, 128, 96), image.YCbCrSubsampleRatio420)
+	for i := range src.Y {
+		src.Y[i] = byte(i * 13)
+	}
+	dst := image.NewYCbCr(image.Rect(0, 0, 80, 40), image.YCbCrSubsampleRatio420)
+	ref := image.NewYCbCr(dst.Rect, dst.SubsampleRatio)
+	for _, asm := range []bool{false, true} {
+		cfg, err := PrepareConversion(dst, src)
+		expect(t, err, nil)
+		cfg.Threads = 3
+		cfg.DisableAsm = !asm
+		cfg.Autotune = true
+		converter, err := NewConverter(cfg, NewBicubicFilter())
+		expect(t, err, nil)
+		tunings := converter.Tunings()
+		// every plane is resized in both directions
+		expect(t, len(tunings), 6)
+		for i, tuning := range tunings {
+			expect(t, tuning.Plane, i/2)
+			expect(t, tuning.Vertical, i%2 == 1)
+			expect(t, strings.HasPrefix(fmt.Sprint(tuning), fmt.Sprintf("plane %v ", i/2)), true)
+			isas := map[ISA]bool{}
+			threads := []int{}
+			picked := false
+			for _, it := range tuning.Candidates {
+				isas[it.ISA] = true
+				expect(t, it.ISA <= SupportedISA(), true)
+				expect(t, it.Plane, tuning.Plane)
+				expect(t, it.Vertical, tuning.Vertical)
+				if it.ISA == ISAGo {
+					threads = append(threads, it.Threads)
+				}
+				expect(t, it.Duration >= tuning.Duration, true)
+				picked = picked || it.ISA == tuning.ISA && it.Threads == tuning.Threads
+			}
+			expect(t, picked, true)
+			expect(t, threads, []int{1, 2, 3})
+			// instruction sets without dedicated scalers are skipped
+			expect(t, isas[ISASSE2], asm && SupportedISA() >= ISASSE2)
+			expect(t, isas[ISASSE41] || isas[ISAAVX512BW], false)
+			expect(t, len(isas) == 1, !asm || SupportedISA() == ISAGo)
+		}
+		expect(t, converter.Convert(dst, src), nil)
+		// tuned conversions match untuned ones
+		cfg.Autotune = false
+		fixed, err := NewConverter(cfg, NewBicubicFilter())
+		expect(t, err, nil)
+		expect(t, fixed.Tunings() == nil, true)
+		expect(t, fixed.Convert(ref, src), nil)
+		expect(t, dst.Y, ref.Y)
+		expect(t, dst.Cb, ref.Cb)
+	}
+	expect(t, getTuningThreads(1), []int{1})
+	expect(t, getTuningThreads(8), []int{1, 2, 4, 8})
+	expect(t, hasScalers(ISAGo), true)
+	expect(t, hasScalers(ISASSE41), false)
+	expect(t, hasScalers(ISAAVX512BW), false)
+}
+
+func TestColumnSlices(t *testing.T) {
+	width, height := 100, 2
+	for threads, slices := range map[int]int{0: 1, 1: 1, 3: 4, 8: 8, 64: 24} {
+		for _, vertical := range []bool{false, true} {
+			k := &kernel{size: 2, cofscale: 1, block: 8, blockcof: 16, rowcof: 2}
+			k.coeffs = make([]int16, width*height*2)
+			k.offsets = make([]int16, width*height)
+			for i := range k.coeffs {
+				k.coeffs[i] = int16(i)
+			}
+			for i := range k.offsets {
+				k.offsets[i] = int16(i)
+			}
+			dst := make([]byte, width*height)
+			seen := make([]int, width*height)
+			calls := 0
+			lock := sync.Mutex{}
+			k.scaler = func(dst, src []byte, cof, off []int16, taps, w, h, dp, sp int) {
+				lock.Lock()
+				defer lock.Unlock()
+				calls++
+				start := width*height - cap(dst)
+				x, y := start%width, start/width
+				if vertical {
+					expect(t, int(cof[0]), y*taps)
+				} else {
+					expect(t, int(cof[0]), x*taps)
+					expect(t, int(off[0]), x)
+				}
+				expect(t, x%k.block, 0)
+				expect(t, w >= k.block, true)
+				for j := 0; j < h; j++ {
+					for i := 0; i < w; i++ {
+						seen[(y+j)*width+x+i]++
+					}
+				}
+			}
+			group := sync.WaitGroup{}
+			scaleSlices(&group, k, vertical, threads, width, height, 1, 1, width, 0,
+				dst, make([]byte, width*height))
+			group.Wait()
+			for _, v := range seen {
+				expect(t, v, 1)
+			}
+			expect(t, calls, slices)
+		}
+	}
+}
+
+func TestShortPlaneThreads(t *testing.T) {
+	raw := readImage(t, "testdata/lenna.jpg")
+	sizes := []struct{ w, h int }{{301, 2}, {512, 4}, {16, 8}, {77, 6}, {1200, 2}}
+	for _, s := range sizes {
+		for _, rgb := range []bool{false, true} {
+			for _, asm := range []bool{false, true} {
+				for _, interlaced := range []bool{false, true} {
+					h := s.h << bin(interlaced)
+					src := raw
+					var dst, ref image.Image
+					dst = image.NewYCbCr(image.Rect(0, 0, s.w, h), image.YCbCrSubsampleRatio420)
+					ref = image.NewYCbCr(image.Rect(0, 0, s.w, h), image.YCbCrSubsampleRatio420)
+					if rgb {
+						src, dst, ref = toRgb(src), toRgb(dst), toRgb(ref)
+					}
+					err := prepare(t, ref, src, asm, interlaced, NewBicubicFilter(), 1).Convert(ref, src)
+					expect(t, err, nil)
+					err = prepare(t, dst, src, asm, interlaced, NewBicubicFilter(), 16).Convert(dst, src)
+					expect(t, err, nil)
+					expect(t, dst, ref)
+				}
+			}
+		}
+	}
+}
+
+func TestTrimmedUpscaleThreads(t *testing.T) {
+	// trimmed kernels step back between rows, slices must not read before src
+	rnd := rand.New(rand.NewSource(0))
+	for _, depth := range []int{8, 16} {
+		for _, asm := range []bool{false, true} {
+			for _, size := range []struct{ in, out int }{{5, 15}, {8, 40}, {10, 22}, {19, 33}, {33, 17}} {
+				width := 40
+				sp, dp := width*depth/8, width*depth/8
+				src := make([]byte, sp*size.in)
+				for i := range src {
+					src[i] = byte(rnd.Intn(256))
+				}
+				var outputs [2][]byte
+				for i, threads := range []int{1, 8} {
+					cfg := ResizerConfig{
+						Depth:      depth,
+						Input:      size.in,
+						Output:     size.out,
+						Vertical:   true,
+						Pack:       1,
+						Threads:    threads,
+						DisableAsm: !asm,
+					}
+					r, err := NewResize(&cfg, NewBicubicFilter())
+					expect(t, err, nil)
+					outputs[i] = make([]byte, dp*size.out)
+					r.Resize(outputs[i], src, width, size.in, dp, sp)
+					// asm scalers would silently read before src
+					lock := sync.Mutex{}
+					first := 0
+					for _, k := range r.(*context).kernels[0] {
+						k.scaler = func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+							row := 0
+							lock.Lock()
+							defer lock.Unlock()
+							for _, v := range off[:height] {
+								row += int(v)
+								first = min(first, row)
+							}
+						}
+						k.narrow = k.scaler
+						group := sync.WaitGroup{}
+						scaleSlices(&group, &k, true, threads, width*depth/8, k.count, 1, 1, dp, sp,
+							make([]byte, dp*k.count), src)
+						group.Wait()
+					}
+					expect(t, first, 0)
+				}
+				expect(t, outputs[1], outputs[0])
+			}
+		}
+	}
+}
+
+func TestSplitTiles(t *testing.T) {
+	width, height := 100, 2
+	for threads, tiles := range map[int]int{0: 1, 1: 1, 3: 4, 8: 8, 64: 24} {
+		seen := make([]int, width*height)
+		calls := 0
+		lock := sync.Mutex{}
+		group := sync.WaitGroup{}
+		splitTiles(&group, threads, width, height, 8, func(x, y, w, h int) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls++
+			expect(t, x%8, 0)
+			expect(t, w >= 8, true)
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					seen[(y+j)*width+x+i]++
+				}
+			}
+		})
+		group.Wait()
+		for _, v := range seen {
+			expect(t, v, 1)
+		}
+		expect(t, calls, tiles)
+	}
+}
+
+// TestShortPlaneSamplings checks that every sampling, dithering &
+// anti-ringing split into columns on short planes match one thread
+func TestShortPlaneSamplings(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	src := image.NewYCbCr(image.Rect(0, 0, 1200, 8), image.YCbCrSubsampleRatio420)
+	for _, plane := range [][]byte{src.Y, src.Cb, src.Cr} {
+		copy(plane, randomBytes(r, len(plane)))
+	}
+	for _, it := range []struct {
+		name  string
+		w, h  int
+		setup func(cfg *ConverterConfig)
+	}{
+		{"area", 600, 4, func(cfg *ConverterConfig) { cfg.Sampling = SamplingArea }},
+		{"nearest", 901, 6, func(cfg *ConverterConfig) { cfg.Sampling = SamplingNearest }},
+		{"ewa", 500, 6, func(cfg *ConverterConfig) { cfg.Sampling = SamplingEWA }},
+		{"dither", 901, 6, func(cfg *ConverterConfig) {
+			cfg.Dither = [maxPlanes]Dither{DitherOrdered, DitherOrdered, DitherOrdered}
+		}},
+		{"antiring", 1777, 6, func(cfg *ConverterConfig) { cfg.AntiRing = 1 }},
+		{"multistage", 100, 2, func(cfg *ConverterConfig) { cfg.MultiStage = true }},
+	} {
+		var outputs [2]*image.YCbCr
+		for i, threads := range []int{1, 16} {
+			dst := image.NewYCbCr(image.Rect(0, 0, it.w, it.h), image.YCbCrSubsampleRatio420)
+			cfg, err := PrepareConversion(dst, src)
+			expect(t, err, nil)
+			cfg.Threads = threads
+			it.setup(cfg)
+			converter, err := NewConverter(cfg, NewBicubicFilter())
+			expect(t, err, nil)
+			expect(t, converter.Convert(dst, src), nil)
+			outputs[i] = dst
+		}
+		for i, planes := range [][2][]byte{
+			{outputs[0].Y, outputs[1].Y},
+			{outputs[0].Cb, outputs[1].Cb},
+			{outputs[0].Cr, outputs[1].Cr},
+		} {
+			if !bytes.Equal(planes[0], planes[1]) {
+				t.Fatalf("%v: plane %v differs with threads", it.name, i)
+			}
+		}
+	}
+}
+
+// padBorderRef resizes src with unsplit kernels on a fully padded plane
+func padBorderRef(t *testing.T, cfg ResizerConfig, src []byte, width, height int) []byte {
+	cfg.DisableAsm = true
+	if cfg.Bits == 0 {
+		cfg.Bits = Bits
+	}
+	field := bin(cfg.Vertical && cfg.Interlaced)
+	pk := cfg.Pack
+	dw, dh := cfg.Output, height
+	if cfg.Vertical {
+		dw, dh = width, cfg.Output
+	}
+	dst := make([]byte, dw*dh*pk)
+	dp := dw * pk
+	for i := uint(0); i <= field; i++ {
+		kernels, m, err := makeKernel(&cfg, NewLanczosFilter(3), i)
+		expect(t, err, nil)
+		pw, ph := width+2*m, height
+		if cfg.Vertical {
+			pw, ph = width, height+2*m
+		}
+		pad := make([]byte, pw*ph*pk)
+		pp := pw * pk
+		for y := 0; y < ph; y++ {
+			for x := 0; x < pw; x++ {
+				sx, sy := getBorderIndex(x-m, width, cfg.Border), y
+				if cfg.Vertical {
+					sx, sy = x, getFieldIndex(y-m, height, cfg.Border, field)
+				}
+				for c := 0; c < pk; c++ {
+					v := cfg.BorderValue[c]
+					if sx >= 0 && sy >= 0 {
+						v = src[sy*width*pk+sx*pk+c]
+					}
+					pad[y*pp+x*pk+c] = v
+				}
+			}
+		}
+		for _, k := range kernels {
+			if cfg.Vertical {
+				k.scaler(dst[dp*int(i)+(dp<<field)*k.start:], pad[pp*int(i):], k.coeffs, k.offsets,
+					k.size, width*pk, k.count, dp<<field, pp<<field)
+				continue
+			}
+			k.scaler(dst[k.start*pk:], pad, k.coeffs, k.offsets,
+				k.size, k.count*pk/k.pack, height, dp, pp)
+		}
+	}
+	return dst
+}
+
+func TestBorderStrips(t *testing.T) {
+	for _, border := range []Border{BorderWrap, BorderConstant} {
+		for _, it := range []struct {
+			pack, in, out int
+			vertical, ii  bool
+		}{
+			{1, 200, 333, false, false},
+			{1, 333, 120, false, false},
+			{3, 150, 97, false, false},
+			{4, 130, 260, false, false},
+			{1, 9, 20, false, false},
+			{1, 200, 333, true, false},
+			{1, 301, 80, true, true},
+			{1, 12, 30, true, true},
+			// trimmed upscale kernels step back between outputs
+			{1, 8, 40, false, false},
+			{1, 10, 22, false, false},
+			{1, 5, 25, false, false},
+			{1, 8, 40, true, false},
+			{1, 10, 22, true, false},
+			{1, 5, 25, true, false},
+			{1, 8, 40, true, true},
+			{1, 10, 22, true, true},
+		} {
+			for _, asm := range []bool{false, true} {
+				width, height := it.in, 5
+				if it.vertical {
+					width, height = 37, it.in
+				}
+				cfg := ResizerConfig{
+					Input:       it.in,
+					Output:      it.out,
+					Vertical:    it.vertical,
+					Interlaced:  it.ii,
+					Pack:        it.pack,
+					Threads:     3,
+					DisableAsm:  !asm,
+					Border:      border,
+					BorderValue: [4]byte{1, 2, 3, 4},
+				}
+				// guard bytes before src must never be read
+				guard := width * it.pack * 8
+				buf := make([]byte, guard+width*height*it.pack)
+				for i := range buf[:guard] {
+					buf[i] = byte(255 - i)
+				}
+				src := buf[guard:]
+				for i := range src {
+					src[i] = byte(i*37 + i/width*11)
+				}
+				dst := resizeBorder(t, cfg, src, width, height)
+				expect(t, dst, padBorderRef(t, cfg, src, width, height))
+				r, err := NewResize(&cfg, NewLanczosFilter(3))
+				expect(t, err, nil)
+				strips := r.(*context).strips
+				// large planes only pad their edges
+				expect(t, len(strips) == 2, it.in > 100)
+			}
+		}
+	}
+}
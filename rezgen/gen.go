@@ -7,6 +7,7 @@ package main
 import (
 	"flag"
 	. "github.com/bamiaux/rez/asm"
+	"github.com/bamiaux/rez/rezgen/gen"
 	"io"
 	"log"
 	"os"
@@ -29,9 +30,9 @@ func main() {
 	a := NewAsm(os.Stdout)
 	switch *mode {
 	case "horizontal":
-		hgen(a)
+		gen.Horizontal(a)
 	case "vertical":
-		vgen(a)
+		gen.Vertical(a)
 	}
 	err := a.Flush()
 	if err != nil {
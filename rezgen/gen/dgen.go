@@ -0,0 +1,165 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"bytes"
+	. "github.com/bamiaux/rez/asm"
+)
+
+const (
+	gshift = 3 // 8 gathered horizontal samples per simd register
+	gwidth = 1 << gshift
+)
+
+// dering generates sse2 anti-ringing passes on 8-bit samples, which pull
+// every destination sample back towards the range of its two nearest
+// source samples by strength/256
+// callers handle the last width%16 (vertical) or width%8 (horizontal)
+// samples, a pass is not idempotent so they cannot be overlapped
+type dering struct {
+	// global data
+	round Operand
+	low   Operand
+	// arguments
+	dst      []Operand
+	src      []Operand
+	near     []Operand
+	strength Operand
+	width    Operand
+	height   Operand
+	dp       Operand
+	sp       Operand
+}
+
+func genDering(a *Asm, vertical bool) {
+	d := dering{}
+	d.round = a.Data("round", bytes.Repeat([]byte{0x00, 0x80}, 8))
+	d.low = a.Data("low", bytes.Repeat([]byte{0x00, 0xFF}, 8))
+	name := "h8deringAmd64"
+	if vertical {
+		name = "v8deringAmd64"
+	}
+	a.NewFunction(name)
+	d.dst = a.SliceArgument("dst")
+	d.src = a.SliceArgument("src")
+	d.near = a.SliceArgument("near")
+	d.strength = a.Argument("strength")
+	d.width = a.Argument("width")
+	d.height = a.Argument("height")
+	d.dp = a.Argument("dp")
+	d.sp = a.Argument("sp")
+	a.Start()
+	a.Movq(SI, d.src[0])
+	a.Movq(DI, d.dst[0])
+	a.Pxor(X13, X13)
+	a.Movo(X14, d.round)
+	// broadcast strength to every word
+	a.Movq(AX, d.strength)
+	a.Movq(X15, AX)
+	a.Punpcklwd(X15, X15)
+	a.Punpckldq(X15, X15)
+	a.Punpcklqdq(X15, X15)
+	if vertical {
+		d.vframe(a)
+	} else {
+		d.hframe(a)
+	}
+	a.Ret()
+}
+
+func (d *dering) vframe(a *Asm) {
+	a.Movq(BX, d.sp)
+	a.Movq(R10, d.near[0])
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movd(AX, Address(R10))
+	a.Imulq(BX)
+	a.Leaq(R8, Address(SI, AX, SX1))
+	a.Leaq(R9, Address(R8, BX, SX1))
+	a.Movq(R11, DI)
+	a.Movq(CX, d.width)
+	a.Shrq(CX, Constant(xshift))
+	xloop := a.NewLabel("xloop")
+	a.Label(xloop)
+	a.Movou(X0, Address(R8))
+	a.Movou(X1, Address(R9))
+	a.Movou(X2, Address(R11))
+	d.clamp(a, X2, X0, X1)
+	a.Movou(Address(R11), X2)
+	a.Addq(R8, Constant(xwidth))
+	a.Addq(R9, Constant(xwidth))
+	a.Addq(R11, Constant(xwidth))
+	a.Subq(CX, Constant(1))
+	a.Jne(xloop)
+	a.Addq(DI, d.dp)
+	a.Addq(R10, Constant(4))
+	a.Subq(d.height, Constant(1))
+	a.Jne(yloop)
+}
+
+func (d *dering) hframe(a *Asm) {
+	a.Movo(X12, d.low)
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movq(BX, d.near[0])
+	a.Movq(R11, DI)
+	a.Movq(CX, d.width)
+	a.Shrq(CX, Constant(gshift))
+	xloop := a.NewLabel("xloop")
+	a.Label(xloop)
+	// gather both nearest source samples as one word per destination
+	regs := []Register{R8, R9, R12, R13}
+	for i := 0; i < gwidth; i++ {
+		r := regs[i%len(regs)]
+		a.Movd(r, Address(BX, i*4))
+		a.Pinsrw(X0, Address(SI, r, SX1), Constant(i))
+	}
+	a.Movo(X1, X0)
+	a.Pand(X1, X12)
+	a.Psrlw(X0, Constant(8))
+	a.Packuswb(X1, X1)
+	a.Packuswb(X0, X0)
+	a.Movq(X2, Address(R11))
+	d.clamp(a, X2, X1, X0)
+	a.Movq(Address(R11), X2)
+	a.Addq(BX, Constant(gwidth*4))
+	a.Addq(R11, Constant(gwidth))
+	a.Subq(CX, Constant(1))
+	a.Jne(xloop)
+	a.Addq(DI, d.dp)
+	a.Addq(SI, d.sp)
+	a.Subq(d.height, Constant(1))
+	a.Jne(yloop)
+}
+
+// clamp deranges 16 samples in v with nearest samples in xa & xb,
+// overwriting xa
+func (d *dering) clamp(a *Asm, v, xa, xb SimdRegister) {
+	a.Movo(X3, xa)
+	a.Pminub(X3, xb)
+	a.Pmaxub(xa, xb)
+	a.Psubusb(X3, v)
+	a.Movo(X4, v)
+	a.Psubusb(X4, xa)
+	d.scale(a, X3, X5)
+	d.scale(a, X4, X6)
+	a.Paddb(v, X3)
+	a.Psubb(v, X4)
+}
+
+// scale rounds every byte in x times strength/256, using tmp
+func (d *dering) scale(a *Asm, x, tmp SimdRegister) {
+	a.Movo(tmp, x)
+	a.Punpcklbw(x, X13)
+	a.Punpckhbw(tmp, X13)
+	a.Pmullw(x, X15)
+	a.Pmullw(tmp, X15)
+	a.Paddw(x, X14)
+	a.Paddw(tmp, X14)
+	a.Psrlw(x, Constant(8))
+	a.Psrlw(tmp, Constant(8))
+	a.Packuswb(x, tmp)
+}
@@ -0,0 +1,369 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	. "github.com/bamiaux/rez/asm"
+)
+
+const (
+	dshift = 2 // 4 horizontal output samples per simd register
+	dwidth = 1 << dshift
+	dbias  = xwidth // 1 bias dword per output sample after coeffs
+)
+
+// depth16 generates sse2 scalers on 16-bit 8.8 fixed point samples, read
+// from 8 or 16-bit sources & written to 8 or 16-bit destinations, R14
+// walks coeffs
+// pmaddwd only multiplies signed words, so 16-bit samples are biased by
+// -32768 and a dword per output sample stored after coeffs removes this
+// bias, rounds & biases results again to saturate them with packssdw
+type depth16 struct {
+	s     simd
+	xtaps int
+	in    int // bytes per source sample
+	out   int // bytes per destination sample
+	shift int // fractional bits removed from sums
+	// global data
+	sign   Operand
+	zero   Operand
+	u8max  Operand
+	u16max Operand
+	// arguments
+	dst    []Operand
+	src    []Operand
+	cof    []Operand
+	off    []Operand
+	taps   Operand
+	width  Operand
+	height Operand
+	dp     Operand
+	sp     Operand
+}
+
+// depths16 lists generated source & destination sample sizes, vertical
+// scalers always write 16-bit samples
+var depths16 = []struct {
+	name    string
+	in, out int
+}{
+	{"16scale", 2, 2},
+	{"8to16scale", 1, 2},
+	{"16to8scale", 2, 1},
+}
+
+func gen16(a *Asm, vertical bool) {
+	d := depth16{}
+	d.sign = a.Data("sign", bytes.Repeat([]byte{0x80, 0x00}, 8))
+	d.zero = a.Data("zero", bytes.Repeat([]byte{0x00}, 8))
+	d.u8max = a.Data("u8max", []byte{0, 0, 0, 0, 0, 0, 0, 0xFF})
+	d.u16max = a.Data("u16max", []byte{0, 0, 0, 0, 0, 0, 0xFF, 0xFF})
+	for _, it := range depths16 {
+		if vertical && it.out != 2 {
+			continue
+		}
+		d.in, d.out = it.in, it.out
+		d.shift = 14 + 8*(it.in-1) - 8*(it.out-1)
+		for taps := 2; taps <= 16; taps += 2 {
+			d.genscale(a, it.name, taps, vertical)
+		}
+	}
+}
+
+func (d *depth16) genscale(a *Asm, name string, taps int, vertical bool) {
+	d.s.Asm = a
+	d.xtaps = taps
+	dir := "h"
+	if vertical {
+		dir = "v"
+	}
+	a.NewFunction(fmt.Sprintf("%v%v%vAmd64", dir, name, taps))
+	// arguments
+	d.dst = a.SliceArgument("dst")
+	d.src = a.SliceArgument("src")
+	d.cof = a.SliceArgument("cof")
+	d.off = a.SliceArgument("off")
+	d.taps = a.Argument("taps")
+	d.width = a.Argument("width")
+	d.height = a.Argument("height")
+	d.dp = a.Argument("dp")
+	d.sp = a.Argument("sp")
+	a.Start()
+	a.Movq(SI, d.src[0])
+	a.Movq(DI, d.dst[0])
+	d.s.movo(X15, d.sign)
+	if d.in == 1 {
+		d.s.pxor(X14, X14)
+	}
+	if vertical {
+		d.vframe(a)
+	} else {
+		d.hframe(a)
+	}
+	d.s.ret()
+}
+
+// load reads the source sample at src into dst
+func (d *depth16) load(a *Asm, dst Register, src Operand) {
+	if d.in == 2 {
+		a.Movwqzx(dst, src)
+		return
+	}
+	a.Movbqzx(dst, src)
+}
+
+// clamp stores the destination sample of sum rounded & saturated into DI
+func (d *depth16) clamp(a *Asm, sum Register) {
+	max := d.u16max
+	if d.out == 1 {
+		max = d.u8max
+	}
+	a.Addq(sum, Constant(1<<uint(d.shift-1)))
+	a.Cmovql(sum, d.zero)
+	a.Shrq(sum, Constant(d.shift))
+	a.Cmpq(sum, max)
+	a.Cmovql(sum, max)
+	if d.out == 1 {
+		a.Movb(Address(DI), sum)
+		return
+	}
+	a.Movw(Address(DI), sum)
+}
+
+// unbias converts dwords in xa to saturated destination samples, where
+// bias points to bias dwords
+func (d *depth16) unbias(a *Asm, xa, xb SimdRegister, bias Operand) {
+	d.s.paddd(xa, bias)
+	d.s.psrad(xa, Constant(d.shift))
+	if xb != xa {
+		d.s.paddd(xb, bias)
+		d.s.psrad(xb, Constant(d.shift))
+	}
+	d.s.packssdw(xa, xb)
+	if d.out == 1 {
+		d.s.packuswb(xa, xa)
+		return
+	}
+	d.s.pxor(xa, X15)
+}
+
+func (d *depth16) hframe(a *Asm) {
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movq(BX, d.off[0])
+	a.Movq(R14, d.cof[0])
+	a.Movq(R13, DI)
+	nosimdloop := a.NewLabel("nosimdloop")
+	a.Movq(CX, d.width)
+	a.Shrq(CX, Constant(dshift))
+	a.Orq(CX, CX)
+	a.Je(nosimdloop)
+	simdloop := a.NewLabel("simdloop")
+	a.Label(simdloop)
+	d.htaps(a)
+	a.Subq(CX, Constant(1))
+	a.Jne(simdloop)
+	a.Label(nosimdloop)
+	end := a.NewLabel("end")
+	a.Movq(CX, d.width)
+	a.Andq(CX, Constant(dwidth-1))
+	a.Je(end)
+	asmloop := a.NewLabel("asmloop")
+	a.Label(asmloop)
+	d.htap1(a)
+	a.Subq(CX, Constant(1))
+	a.Jne(asmloop)
+	a.Label(end)
+	a.Movq(DI, R13)
+	a.Addq(DI, d.dp)
+	a.Addq(SI, d.sp)
+	a.Subq(d.height, Constant(1))
+	a.Jne(yloop)
+}
+
+// htaps computes 4 output samples, each pair of taps gathers one dword per
+// output sample
+func (d *depth16) htaps(a *Asm) {
+	a.Movwqsx(R8, Address(BX, 0*xoffset))
+	a.Movwqsx(R9, Address(BX, 1*xoffset))
+	a.Movwqsx(R10, Address(BX, 2*xoffset))
+	a.Movwqsx(R11, Address(BX, 3*xoffset))
+	for i := 0; i < d.xtaps>>1; i++ {
+		if d.in == 2 {
+			d.s.movd(X0, Address(SI, R8, SX2, i*4))
+			d.s.movd(X1, Address(SI, R9, SX2, i*4))
+			d.s.movd(X2, Address(SI, R10, SX2, i*4))
+			d.s.movd(X3, Address(SI, R11, SX2, i*4))
+			d.s.punpckldq(X0, X1)
+			d.s.punpckldq(X2, X3)
+			d.s.punpcklqdq(X0, X2)
+			d.s.pxor(X0, X15)
+		} else {
+			// 8-bit pairs are gathered as words then zero-extended
+			d.s.pinsrw(X0, Address(SI, R8, i*2), Constant(0))
+			d.s.pinsrw(X0, Address(SI, R9, i*2), Constant(1))
+			d.s.pinsrw(X0, Address(SI, R10, i*2), Constant(2))
+			d.s.pinsrw(X0, Address(SI, R11, i*2), Constant(3))
+			d.s.punpcklbw(X0, X14)
+		}
+		d.s.pmaddwd(X0, Address(R14, i*xwidth))
+		if i == 0 {
+			d.s.movo(X4, X0)
+		} else {
+			d.s.paddd(X4, X0)
+		}
+	}
+	d.unbias(a, X4, X4, Address(R14, d.xtaps>>1*xwidth))
+	if d.out == 1 {
+		d.s.movd(Address(DI), X4)
+	} else {
+		d.s.movq(Address(DI), X4)
+	}
+	a.Addq(BX, Constant(dwidth*xoffset))
+	a.Addq(R14, Constant(d.xtaps>>1*xwidth+dbias))
+	a.Addq(DI, Constant(dwidth*d.out))
+}
+
+// htap1 computes one output sample from raw coeffs
+func (d *depth16) htap1(a *Asm) {
+	a.Movwqsx(R8, Address(BX))
+	a.Movq(R12, Constant(0))
+	for i := 0; i < d.xtaps; i++ {
+		if d.in == 2 {
+			d.load(a, AX, Address(SI, R8, SX2, i*2))
+		} else {
+			d.load(a, AX, Address(SI, R8, i))
+		}
+		a.Movwqsx(DX, Address(R14, i*2))
+		a.Imulq(DX)
+		a.Addq(R12, AX)
+	}
+	d.clamp(a, R12)
+	a.Addq(BX, Constant(xoffset))
+	a.Addq(R14, Constant(d.xtaps*2))
+	a.Addq(DI, Constant(d.out))
+}
+
+func (d *depth16) vframe(a *Asm) {
+	a.Movq(R14, d.cof[0])
+	a.Movq(BX, d.sp)
+	a.Movq(R10, d.off[0])
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movwqsx(AX, Address(R10))
+	a.Imulq(BX)
+	a.Addq(SI, AX)
+	a.Movq(R11, SI)
+	a.Movq(R13, DI)
+	d.vline(a)
+	a.Movq(SI, R11)
+	a.Movq(DI, R13)
+	a.Addq(DI, d.dp)
+	a.Addq(R14, Constant((d.xtaps>>1+1)*xwidth))
+	a.Addq(R10, Constant(xoffset))
+	a.Subq(d.height, Constant(1))
+	a.Jne(yloop)
+}
+
+func (d *depth16) vline(a *Asm) {
+	// lines narrower than one register are computed one sample at a time
+	narrow := a.NewLabel("narrow")
+	end := a.NewLabel("end")
+	a.Movq(CX, d.width)
+	a.Cmpq(Constant(xwidth>>1), CX)
+	a.Jlt(narrow)
+	a.Shrq(CX, Constant(xshift-1))
+	simdloop := a.NewLabel("simdloop")
+	a.Label(simdloop)
+	d.vtaps(a)
+	a.Subq(CX, Constant(1))
+	a.Jne(simdloop)
+	// the last samples overlap previous ones, destinations are 16-bit
+	a.Movq(CX, d.width)
+	a.Andq(CX, Constant(xwidth>>1-1))
+	a.Je(end)
+	a.Subq(CX, Constant(xwidth>>1))
+	if d.in == 1 {
+		a.Addq(SI, CX)
+	}
+	a.Shlq(CX, Constant(1))
+	if d.in == 2 {
+		a.Addq(SI, CX)
+	}
+	a.Addq(DI, CX)
+	d.vtaps(a)
+	a.Jmp(end)
+	a.Label(narrow)
+	d.vnarrow(a)
+	a.Label(end)
+}
+
+// vtaps computes 8 output samples
+func (d *depth16) vtaps(a *Asm) {
+	a.Movq(AX, SI)
+	pairs := d.xtaps >> 1
+	for i := 0; i < pairs; i++ {
+		if d.in == 2 {
+			d.s.movou(X0, Address(AX, BX, SX0))
+			d.s.movou(X1, Address(AX, BX, SX1))
+		} else {
+			d.s.movq(X0, Address(AX, BX, SX0))
+			d.s.movq(X1, Address(AX, BX, SX1))
+		}
+		if i+1 < pairs {
+			a.Leaq(AX, Address(AX, BX, SX2))
+		}
+		if d.in == 2 {
+			d.s.pxor(X0, X15)
+			d.s.pxor(X1, X15)
+			d.s.movo(X2, X0)
+			d.s.punpcklwd(X0, X1)
+			d.s.punpckhwd(X2, X1)
+		} else {
+			// interleaved 8-bit rows are zero-extended to pairs of words
+			d.s.punpcklbw(X0, X1)
+			d.s.movo(X2, X0)
+			d.s.punpcklbw(X0, X14)
+			d.s.punpckhbw(X2, X14)
+		}
+		d.s.pmaddwd(X0, Address(R14, i*xwidth))
+		d.s.pmaddwd(X2, Address(R14, i*xwidth))
+		if i == 0 {
+			d.s.movo(X4, X0)
+			d.s.movo(X5, X2)
+		} else {
+			d.s.paddd(X4, X0)
+			d.s.paddd(X5, X2)
+		}
+	}
+	d.unbias(a, X4, X5, Address(R14, pairs*xwidth))
+	d.s.movou(Address(DI), X4)
+	a.Addq(SI, Constant(xwidth>>1*d.in))
+	a.Addq(DI, Constant(xwidth))
+}
+
+// vnarrow computes CX samples without simd
+func (d *depth16) vnarrow(a *Asm) {
+	xloop := a.NewLabel("xloop")
+	a.Label(xloop)
+	a.Movq(R8, SI)
+	a.Movq(R12, Constant(0))
+	for i := 0; i < d.xtaps; i++ {
+		d.load(a, AX, Address(R8))
+		a.Movwqsx(DX, Address(R14, i>>1*xwidth+i&1*xoffset))
+		a.Imulq(DX)
+		a.Addq(R12, AX)
+		if i+1 < d.xtaps {
+			a.Addq(R8, BX)
+		}
+	}
+	d.clamp(a, R12)
+	a.Addq(SI, Constant(d.in))
+	a.Addq(DI, Constant(2))
+	a.Subq(CX, Constant(1))
+	a.Jne(xloop)
+}
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a MIT-style
 // license that can be found in the LICENSE file.
 
-package main
+package gen
 
 import (
 	"bytes"
@@ -17,7 +17,9 @@ const (
 )
 
 type horizontal struct {
-	xtaps int
+	s       simd
+	xtaps   int
+	shuffle bool // ssse3 gathers
 	// global data
 	zero  Operand
 	hbits Operand
@@ -43,26 +45,57 @@ type horizontal struct {
 	inner    Operand
 }
 
-func hgen(a *Asm) {
+// Horizontal generates every horizontal & packed scaler into a
+func Horizontal(a *Asm) {
 	h := horizontal{}
 	h.zero = a.Data("zero", bytes.Repeat([]byte{0x00}, 16))
 	h.hbits = a.Data("hbits", bytes.Repeat([]byte{0x00, 0x00, 0x20, 0x00}, 4))
 	h.u8max = a.Data("u8max", bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF}, 2))
-	h.genscale(a, 2)
-	h.genscale(a, 4)
-	h.genscale(a, 8)
-	h.genscale(a, 10)
-	h.genscale(a, 12)
-	h.genscale(a, 0)
+	for _, taps := range []int{2, 4, 6, 8, 10, 12, 14, 16, 0} {
+		h.genscale(a, taps)
+	}
+	h.genpacked(a, 2)
+	h.genpacked(a, 4)
+	h.genpacked(a, 6)
+	h.genpacked(a, 8)
+	h.genpacked(a, 0)
+	h.s.avx = true
+	for _, taps := range []int{2, 4, 6, 8, 10, 12, 14, 16, 0} {
+		h.genscale(a, taps)
+	}
+	// ssse3 & avx2 gathers with one shuffle per register
+	h.shuffle = true
+	for _, avx := range []bool{false, true} {
+		h.s.avx = avx
+		h.genscale(a, 2)
+		h.genscale(a, 4)
+	}
+	h.shuffle = false
+	// 16-bit samples
+	gen16(a, false)
+	// anti-ringing on 8-bit samples
+	genDering(a, false)
 }
 
 func (h *horizontal) genscale(a *Asm, taps int) {
+	h.s.Asm = a
 	h.xtaps = taps
 	suffix := "N"
 	if taps > 0 {
 		suffix = fmt.Sprintf("%v", taps)
 	}
-	a.NewFunction("h8scale" + suffix + "Amd64")
+	isa := "Amd64"
+	if h.s.avx {
+		isa = "Avx2"
+	}
+	if h.shuffle && !h.s.avx {
+		isa = "Ssse3"
+	}
+	name := "h8scale"
+	if h.shuffle {
+		name = "h8shuffle"
+	}
+	a.NewFunction(name + suffix + isa)
 	// arguments
 	h.dst = a.SliceArgument("dst")
 	h.src = a.SliceArgument("src")
@@ -86,7 +119,7 @@ func (h *horizontal) genscale(a *Asm, taps int) {
 	}
 	a.Start()
 	h.frame(a)
-	a.Ret()
+	h.s.ret()
 }
 
 func (h *horizontal) setup(a *Asm) {
@@ -94,8 +127,8 @@ func (h *horizontal) setup(a *Asm) {
 	a.Movq(CX, h.width)
 	a.Movq(DX, CX)
 	a.Subq(BX, CX)
-	a.Shrq(CX, Constant(xshift))
-	a.Andq(DX, Constant(1<<xshift-1))
+	a.Shrq(CX, Constant(h.s.shift()))
+	a.Andq(DX, Constant(h.s.width()-1))
 	a.Movq(h.dstoff, BX)
 	a.Movq(h.simdroll, CX)
 	a.Movq(h.asmroll, DX)
@@ -113,8 +146,8 @@ func (h *horizontal) setup(a *Asm) {
 	if h.xtaps == 0 {
 		a.Movq(h.inner, DX)
 	}
-	a.Pxor(X15, X15)
-	a.Movo(X14, h.hbits)
+	h.s.pxor(X15, X15)
+	h.s.movo(X14, h.hbits)
 }
 
 func (h *horizontal) frame(a *Asm) {
@@ -151,14 +184,22 @@ func (h *horizontal) line(a *Asm) {
 
 	// apply simd loops
 	a.Label(simdloop)
-	switch h.xtaps {
-	case 2:
+	switch {
+	case h.shuffle:
+		h.tapsshuffle(a)
+	case h.s.avx && h.xtaps == 2:
+		h.avxtaps2(a)
+	case h.s.avx && h.xtaps == 4:
+		h.avxtaps4(a)
+	case h.s.avx && h.xtaps == 8:
+		h.avxtaps8(a)
+	case h.xtaps == 2:
 		h.taps2(a)
-	case 4:
+	case h.xtaps == 4:
 		h.taps4(a)
-	case 8:
+	case h.xtaps == 8:
 		h.taps8(a)
-	case 10, 12, 0:
+	default:
 		h.tapsn(a)
 	}
 	a.Subq(CX, Constant(1))
@@ -228,21 +269,23 @@ func (h *horizontal) load2(a *Asm, op Operand, idx uint) {
 	a.Movwqsx(R9, Address(BX, (idx*4+1)*xoffset))
 	a.Movwqsx(R10, Address(BX, (idx*4+2)*xoffset))
 	a.Movwqsx(R11, Address(BX, (idx*4+3)*xoffset))
-	a.Pinsrw(op, Address(SI, R8), Constant(0))
-	a.Pinsrw(op, Address(SI, R9), Constant(1))
-	a.Pinsrw(op, Address(SI, R10), Constant(2))
-	a.Pinsrw(op, Address(SI, R11), Constant(3))
+	x := h.s.xmm()
+	x.pinsrw(op, Address(SI, R8), Constant(0))
+	x.pinsrw(op, Address(SI, R9), Constant(1))
+	x.pinsrw(op, Address(SI, R10), Constant(2))
+	x.pinsrw(op, Address(SI, R11), Constant(3))
 }
 
 func (h *horizontal) madd(a *Asm, xa, xb, xc, xd SimdRegister, idx uint) {
-	a.Punpcklbw(xa, X15)
-	a.Pmaddwd(xa, Address(BP, (idx*4+0)*xwidth))
-	a.Punpcklbw(xb, X15)
-	a.Pmaddwd(xb, Address(BP, (idx*4+1)*xwidth))
-	a.Punpcklbw(xc, X15)
-	a.Pmaddwd(xc, Address(BP, (idx*4+2)*xwidth))
-	a.Punpcklbw(xd, X15)
-	a.Pmaddwd(xd, Address(BP, (idx*4+3)*xwidth))
+	w := uint(h.s.width())
+	h.s.punpcklbw(xa, X15)
+	h.s.pmaddwd(xa, Address(BP, (idx*4+0)*w))
+	h.s.punpcklbw(xb, X15)
+	h.s.pmaddwd(xb, Address(BP, (idx*4+1)*w))
+	h.s.punpcklbw(xc, X15)
+	h.s.pmaddwd(xc, Address(BP, (idx*4+2)*w))
+	h.s.punpcklbw(xd, X15)
+	h.s.pmaddwd(xd, Address(BP, (idx*4+3)*w))
 }
 
 func (h *horizontal) taps2(a *Asm) {
@@ -256,45 +299,51 @@ func (h *horizontal) taps2(a *Asm) {
 }
 
 func (h *horizontal) flush(a *Asm, xa, xb, xc, xd SimdRegister, op Register, count uint) {
-	a.Addq(op, Constant(xwidth*count))
-	a.Paddd(xa, X14)
-	a.Paddd(xb, X14)
-	a.Paddd(xc, X14)
-	a.Paddd(xd, X14)
-	a.Psrad(xa, Constant(14))
-	a.Psrad(xb, Constant(14))
-	a.Psrad(xc, Constant(14))
-	a.Psrad(xd, Constant(14))
-	a.Packssdw(xa, xb)
-	a.Packssdw(xc, xd)
-	a.Packuswb(xa, xc)
-	a.Movou(Address(DI), xa)
-	a.Addq(DI, Constant(xwidth))
+	a.Addq(op, Constant(uint(h.s.width())*count))
+	h.s.paddd(xa, X14)
+	h.s.paddd(xb, X14)
+	h.s.paddd(xc, X14)
+	h.s.paddd(xd, X14)
+	h.s.psrad(xa, Constant(14))
+	h.s.psrad(xb, Constant(14))
+	h.s.psrad(xc, Constant(14))
+	h.s.psrad(xd, Constant(14))
+	h.s.packssdw(xa, xb)
+	h.s.packssdw(xc, xd)
+	h.s.packuswb(xa, xc)
+	h.s.movou(Address(DI), xa)
+	a.Addq(DI, Constant(h.s.width()))
 }
 
 func (h *horizontal) load4(a *Asm, xa, xb SimdRegister, idx uint, tmpa, tmpb SimdRegister) {
 	a.Movwqsx(AX, Address(BX, (idx*4+0)*xoffset))
 	a.Movwqsx(DX, Address(BX, (idx*4+1)*xoffset))
-	a.Movd(xa, Address(SI, AX))
-	a.Movd(tmpa, Address(SI, DX))
+	x := h.s.xmm()
+	x.movd(xa, Address(SI, AX))
+	x.movd(tmpa, Address(SI, DX))
 	a.Movwqsx(AX, Address(BX, (idx*4+2)*xoffset))
 	a.Movwqsx(DX, Address(BX, (idx*4+3)*xoffset))
-	a.Movd(xb, Address(SI, AX))
-	a.Movd(tmpb, Address(SI, DX))
-	a.Punpckldq(xa, tmpa)
-	a.Punpckldq(xb, tmpb)
+	x.movd(xb, Address(SI, AX))
+	x.movd(tmpb, Address(SI, DX))
+	x.punpckldq(xa, tmpa)
+	x.punpckldq(xb, tmpb)
 }
 
 func (h *horizontal) madd4(a *Asm, xa, xb, xc, xd SimdRegister, idx uint, tmpa, tmpb SimdRegister) {
 	h.madd(a, xa, xb, xc, xd, idx)
-	a.Movo(tmpa, xa)
-	a.Movo(tmpb, xc)
-	a.Shufps(tmpa, xb, Constant(0xDD))
-	a.Shufps(tmpb, xd, Constant(0xDD))
-	a.Shufps(xa, xb, Constant(0x88))
-	a.Shufps(xc, xd, Constant(0x88))
-	a.Paddd(xa, tmpa)
-	a.Paddd(xc, tmpb)
+	h.hadd4(a, xa, xb, xc, xd, tmpa, tmpb)
+}
+
+// hadd4 sums pairs of dwords so xa & xc hold one dword per pixel
+func (h *horizontal) hadd4(a *Asm, xa, xb, xc, xd SimdRegister, tmpa, tmpb SimdRegister) {
+	h.s.movo(tmpa, xa)
+	h.s.movo(tmpb, xc)
+	h.s.shufps(tmpa, xb, Constant(0xDD))
+	h.s.shufps(tmpb, xd, Constant(0xDD))
+	h.s.shufps(xa, xb, Constant(0x88))
+	h.s.shufps(xc, xd, Constant(0x88))
+	h.s.paddd(xa, tmpa)
+	h.s.paddd(xc, tmpb)
 }
 
 func (h *horizontal) taps4(a *Asm) {
@@ -309,29 +358,30 @@ func (h *horizontal) taps4(a *Asm) {
 }
 
 func (h *horizontal) load8(a *Asm, xa, xb SimdRegister, idx uint, xc, xd SimdRegister) {
+	x := h.s.xmm()
 	a.Movwqsx(AX, Address(BX, (idx*4+0)*xoffset))
-	a.Movq(xa, Address(SI, AX))
+	x.movq(xa, Address(SI, AX))
 	a.Movwqsx(DX, Address(BX, (idx*4+1)*xoffset))
-	a.Movq(xb, Address(SI, DX))
+	x.movq(xb, Address(SI, DX))
 	a.Movwqsx(AX, Address(BX, (idx*4+2)*xoffset))
-	a.Movq(xc, Address(SI, AX))
+	x.movq(xc, Address(SI, AX))
 	a.Movwqsx(DX, Address(BX, (idx*4+3)*xoffset))
-	a.Movq(xd, Address(SI, DX))
+	x.movq(xd, Address(SI, DX))
 }
 
 func (h *horizontal) padd8(a *Asm, xa, xb, xc, xd, tmpa, tmpb SimdRegister) {
-	a.Movo(tmpa, xa)
-	a.Movo(tmpb, xc)
-	a.Punpcklqdq(xa, xb)
-	a.Punpckhqdq(tmpa, xb)
-	a.Paddd(xa, tmpa)
-	a.Punpcklqdq(xc, xd)
-	a.Punpckhqdq(tmpb, xd)
-	a.Paddd(xc, tmpb)
-	a.Movo(tmpa, xa)
-	a.Shufps(xa, xc, Constant(0x88))
-	a.Shufps(tmpa, xc, Constant(0xDD))
-	a.Paddd(xa, tmpa)
+	h.s.movo(tmpa, xa)
+	h.s.movo(tmpb, xc)
+	h.s.punpcklqdq(xa, xb)
+	h.s.punpckhqdq(tmpa, xb)
+	h.s.paddd(xa, tmpa)
+	h.s.punpcklqdq(xc, xd)
+	h.s.punpckhqdq(tmpb, xd)
+	h.s.paddd(xc, tmpb)
+	h.s.movo(tmpa, xa)
+	h.s.shufps(xa, xc, Constant(0x88))
+	h.s.shufps(tmpa, xc, Constant(0xDD))
+	h.s.paddd(xa, tmpa)
 }
 
 func (h *horizontal) madd8(a *Asm, xa, xb, xc, xd SimdRegister, idx uint, tmpa, tmpb SimdRegister) {
@@ -357,12 +407,23 @@ func (h *horizontal) loadn(a *Asm, xa, xb, xc, xd SimdRegister) {
 	h.load2(a, xb, 1)
 	h.load2(a, xc, 2)
 	h.load2(a, xd, 3)
+	if h.s.avx {
+		// high lanes process the next 16 pixels
+		h.load2(a, X8, 4)
+		h.load2(a, X9, 5)
+		h.load2(a, X10, 6)
+		h.load2(a, X11, 7)
+		h.s.insert(xa, X8)
+		h.s.insert(xb, X9)
+		h.s.insert(xc, X10)
+		h.s.insert(xd, X11)
+	}
 	a.Addq(SI, Constant(2))
 }
 
 func (h *horizontal) maddn(a *Asm, xa, xb, xc, xd SimdRegister) {
 	h.madd(a, xa, xb, xc, xd, 0)
-	a.Addq(BP, Constant(xwidth*4))
+	a.Addq(BP, Constant(h.s.width()*4))
 }
 
 func (h *horizontal) tapsn(a *Asm) {
@@ -372,10 +433,10 @@ func (h *horizontal) tapsn(a *Asm) {
 	for i := 1; i*2 < h.xtaps; i++ {
 		h.loadn(a, X4, X5, X6, X7)
 		h.maddn(a, X4, X5, X6, X7)
-		a.Paddd(X0, X4)
-		a.Paddd(X1, X5)
-		a.Paddd(X2, X6)
-		a.Paddd(X3, X7)
+		h.s.paddd(X0, X4)
+		h.s.paddd(X1, X5)
+		h.s.paddd(X2, X6)
+		h.s.paddd(X3, X7)
 	}
 	if h.xtaps == 0 {
 		a.Movq(h.dstref, DI)
@@ -384,10 +445,10 @@ func (h *horizontal) tapsn(a *Asm) {
 		a.Label(loop)
 		h.loadn(a, X4, X5, X6, X7)
 		h.maddn(a, X4, X5, X6, X7)
-		a.Paddd(X0, X4)
-		a.Paddd(X1, X5)
-		a.Paddd(X2, X6)
-		a.Paddd(X3, X7)
+		h.s.paddd(X0, X4)
+		h.s.paddd(X1, X5)
+		h.s.paddd(X2, X6)
+		h.s.paddd(X3, X7)
 		a.Subq(DI, Constant(2))
 		a.Jne(loop)
 		a.Movq(DI, h.dstref)
@@ -396,3 +457,99 @@ func (h *horizontal) tapsn(a *Asm) {
 	a.Subq(SI, AX)
 	h.flush(a, X0, X1, X2, X3, BX, xoffset)
 }
+
+// tapsshuffle loads one source window per group of 16/taps pixels, then
+// shuffles it in taps order. Shuffle masks & window offsets are stored
+// after coeffs of every block, avx2 high lanes load their own window
+func (h *horizontal) tapsshuffle(a *Asm) {
+	w := uint(h.s.width())
+	groups := uint(h.xtaps)
+	masks := groups * 2 * w
+	bases := masks + groups*w
+	regs := []SimdRegister{X0, X1, X2, X3, X4, X5, X6, X7}
+	for g := uint(0); g < groups; g++ {
+		xa, xb := regs[g*2], regs[g*2+1]
+		a.Movwqsx(AX, Address(BP, bases+g*xoffset))
+		h.s.xmm().movou(xa, Address(SI, AX))
+		if h.s.avx {
+			a.Movwqsx(DX, Address(BP, bases+xwidth+g*xoffset))
+			h.s.Vinserti128(xa.Y(), xa.Y(), Address(SI, DX), Constant(1))
+		}
+		h.s.pshufb(xa, Address(BP, masks+g*w))
+		h.s.movo(xb, xa)
+		h.s.punpcklbw(xa, X15)
+		h.s.punpckhbw(xb, X15)
+		h.s.pmaddwd(xa, Address(BP, (g*2+0)*w))
+		h.s.pmaddwd(xb, Address(BP, (g*2+1)*w))
+	}
+	a.Addq(BX, Constant(w*xoffset))
+	stride := bases/w + 1
+	if h.xtaps == 2 {
+		h.flush(a, X0, X1, X2, X3, BP, stride)
+		return
+	}
+	h.hadd4(a, X0, X1, X2, X3, X8, X9)
+	h.hadd4(a, X4, X5, X6, X7, X10, X11)
+	h.flush(a, X0, X2, X4, X6, BP, stride)
+}
+
+// avx2 scalers process 32 pixels per loop, the low lane of every ymm
+// register computes pixels 0-15 exactly like sse2 scalers while its high
+// lane computes pixels 16-31, coeffs for both lanes are interleaved
+// every 16 bytes so in-lane packs output pixels in order
+
+func (h *horizontal) avxtaps2(a *Asm) {
+	h.load2(a, X0, 0)
+	h.load2(a, X1, 1)
+	h.load2(a, X2, 2)
+	h.load2(a, X3, 3)
+	h.load2(a, X4, 4)
+	h.load2(a, X5, 5)
+	h.load2(a, X6, 6)
+	h.load2(a, X7, 7)
+	h.s.insert(X0, X4)
+	h.s.insert(X1, X5)
+	h.s.insert(X2, X6)
+	h.s.insert(X3, X7)
+	a.Addq(BX, Constant(ywidth*xoffset))
+	h.madd(a, X0, X1, X2, X3, 0)
+	h.flush(a, X0, X1, X2, X3, BP, 4)
+}
+
+func (h *horizontal) avxtaps4(a *Asm) {
+	h.load4(a, X0, X1, 0, X8, X9)
+	h.load4(a, X2, X3, 1, X8, X9)
+	h.load4(a, X4, X5, 4, X8, X9)
+	h.load4(a, X6, X7, 5, X8, X9)
+	h.s.insert(X0, X4)
+	h.s.insert(X1, X5)
+	h.s.insert(X2, X6)
+	h.s.insert(X3, X7)
+	h.madd4(a, X0, X1, X2, X3, 0, X10, X11)
+	h.load4(a, X4, X5, 2, X8, X9)
+	h.load4(a, X6, X7, 3, X8, X9)
+	h.load4(a, X10, X11, 6, X8, X9)
+	h.load4(a, X12, X13, 7, X8, X9)
+	h.s.insert(X4, X10)
+	h.s.insert(X5, X11)
+	h.s.insert(X6, X12)
+	h.s.insert(X7, X13)
+	a.Addq(BX, Constant(ywidth*xoffset))
+	h.madd4(a, X4, X5, X6, X7, 1, X10, X11)
+	h.flush(a, X0, X2, X4, X6, BP, 8)
+}
+
+func (h *horizontal) avxtaps8(a *Asm) {
+	for i, xa := range []SimdRegister{X0, X1, X2, X3} {
+		idx := uint(i)
+		h.load8(a, xa, X4, idx, X5, X6)
+		h.load8(a, X8, X9, idx+4, X10, X11)
+		h.s.insert(xa, X8)
+		h.s.insert(X4, X9)
+		h.s.insert(X5, X10)
+		h.s.insert(X6, X11)
+		h.madd8(a, xa, X4, X5, X6, idx, X12, X13)
+	}
+	a.Addq(BX, Constant(ywidth*xoffset))
+	h.flush(a, X0, X1, X2, X3, BP, 16)
+}
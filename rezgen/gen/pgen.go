@@ -0,0 +1,119 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"fmt"
+	. "github.com/bamiaux/rez/asm"
+)
+
+const (
+	pshift = 2      // 4 bytes per packed pixel
+	pwidth = 1 << 3 // 2 packed pixels per tap pair
+	pcoeff = xwidth // 1 coeff pair repeated on every channel
+)
+
+// genpacked generates horizontal scalers for 4-bytes packed pixels
+// every output pixel loads its source pixels once, interleaves 2 taps
+// per simd register and applies one coeff pair on all 4 channels
+func (h *horizontal) genpacked(a *Asm, taps int) {
+	h.xtaps = taps
+	suffix := "N"
+	if taps > 0 {
+		suffix = fmt.Sprintf("%v", taps)
+	}
+	a.NewFunction("h8p4scale" + suffix + "Amd64")
+	// arguments
+	h.dst = a.SliceArgument("dst")
+	h.src = a.SliceArgument("src")
+	h.cof = a.SliceArgument("cof")
+	h.off = a.SliceArgument("off")
+	h.taps = a.Argument("taps")
+	h.width = a.Argument("width")
+	h.height = a.Argument("height")
+	h.dp = a.Argument("dp")
+	h.sp = a.Argument("sp")
+	// stack
+	h.srcref = a.PushStack("srcref")
+	h.dstoff = a.PushStack("dstoff")
+	a.Start()
+	h.pframe(a)
+	a.Ret()
+}
+
+func (h *horizontal) pframe(a *Asm) {
+	end := a.NewLabel("end")
+	a.Movq(CX, h.width)
+	a.Orq(CX, CX)
+	a.Je(end)
+	a.Movq(BX, h.dp)
+	a.Shlq(CX, Constant(pshift))
+	a.Subq(BX, CX)
+	a.Movq(h.dstoff, BX)
+	a.Movq(SI, h.src[0])
+	a.Movq(h.srcref, SI)
+	a.Movq(DI, h.dst[0])
+	a.Pxor(X15, X15)
+	a.Movo(X14, h.hbits)
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movq(BX, h.off[0])
+	a.Movq(BP, h.cof[0])
+	a.Movq(CX, h.width)
+	xloop := a.NewLabel("xloop")
+	a.Label(xloop)
+	a.Movwqsx(AX, Address(BX))
+	a.Addq(AX, SI)
+	h.ptaps(a)
+	a.Addq(BX, Constant(xoffset))
+	a.Paddd(X0, X14)
+	a.Psrad(X0, Constant(14))
+	a.Packssdw(X0, X0)
+	a.Packuswb(X0, X0)
+	a.Movd(Address(DI), X0)
+	a.Addq(DI, Constant(1<<pshift))
+	a.Subq(CX, Constant(1))
+	a.Jne(xloop)
+	a.Movq(SI, h.srcref)
+	a.Addq(DI, h.dstoff)
+	a.Addq(SI, h.sp)
+	a.Movq(h.srcref, SI)
+	a.Subq(h.height, Constant(1))
+	a.Jne(yloop)
+	a.Label(end)
+}
+
+// pload2 multiplies a pair of packed pixels at AX+idx*pwidth with the
+// coeff pair at BP+idx*pcoeff
+func (h *horizontal) pload2(a *Asm, xa, xb SimdRegister, idx int) {
+	a.Movd(xa, Address(AX, idx*pwidth))
+	a.Movd(xb, Address(AX, idx*pwidth+pwidth/2))
+	a.Punpcklbw(xa, xb)
+	a.Punpcklbw(xa, X15)
+	a.Pmaddwd(xa, Address(BP, idx*pcoeff))
+}
+
+func (h *horizontal) ptaps(a *Asm) {
+	if h.xtaps > 0 {
+		h.pload2(a, X0, X1, 0)
+		for i := 1; i*2 < h.xtaps; i++ {
+			h.pload2(a, X2, X3, i)
+			a.Paddd(X0, X2)
+		}
+		a.Addq(BP, Constant(h.xtaps/2*pcoeff))
+		return
+	}
+	a.Pxor(X0, X0)
+	a.Movq(DX, h.taps)
+	a.Shrq(DX, Constant(1))
+	loop := a.NewLabel("loop")
+	a.Label(loop)
+	h.pload2(a, X2, X3, 0)
+	a.Paddd(X0, X2)
+	a.Addq(AX, Constant(pwidth))
+	a.Addq(BP, Constant(pcoeff))
+	a.Subq(DX, Constant(1))
+	a.Jne(loop)
+}
@@ -0,0 +1,142 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	. "github.com/bamiaux/rez/asm"
+)
+
+const (
+	yshift = 5
+	ywidth = 1 << yshift // 256-bits per avx2 register
+)
+
+// simd emits sse2 instructions, or their vex-encoded avx2 equivalents
+// where xmm registers are promoted to ymm registers unless lane is set
+type simd struct {
+	*Asm
+	avx  bool
+	lane bool
+}
+
+// xmm returns a simd emitting vex-encoded instructions on xmm registers
+func (s simd) xmm() simd {
+	s.lane = true
+	return s
+}
+
+// width returns the number of bytes per simd register
+func (s simd) width() int {
+	if s.avx {
+		return ywidth
+	}
+	return xwidth
+}
+
+// shift returns log2 of width
+func (s simd) shift() int {
+	if s.avx {
+		return yshift
+	}
+	return xshift
+}
+
+func (s simd) reg(op Operand) Operand {
+	if r, ok := op.(SimdRegister); ok && s.avx && !s.lane {
+		return r.Y()
+	}
+	return op
+}
+
+// binary emits dst = dst op src
+func (s simd) binary(sse func(a, b Operand), avx func(a, b, c Operand), dst, src Operand) {
+	if !s.avx {
+		sse(dst, src)
+		return
+	}
+	avx(s.reg(dst), s.reg(dst), s.reg(src))
+}
+
+func (s simd) movo(dst, src Operand) {
+	if !s.avx {
+		s.Movo(dst, src)
+		return
+	}
+	if _, ok := src.(SimdRegister); ok {
+		s.Vmovdqa(s.reg(dst), s.reg(src))
+		return
+	}
+	// 128-bits constants are repeated on both lanes
+	s.Vbroadcasti128(s.reg(dst), src)
+}
+
+func (s simd) movou(dst, src Operand) {
+	if !s.avx {
+		s.Movou(dst, src)
+		return
+	}
+	s.Vmovdqu(s.reg(dst), s.reg(src))
+}
+
+func (s simd) movd(dst, src Operand) {
+	if !s.avx {
+		s.Movd(dst, src)
+		return
+	}
+	s.Vmovd(dst, src)
+}
+
+func (s simd) movq(dst, src Operand) {
+	if !s.avx {
+		s.Movq(dst, src)
+		return
+	}
+	s.Vmovq(dst, src)
+}
+
+func (s simd) pinsrw(dst, src, idx Operand) {
+	if !s.avx {
+		s.Pinsrw(dst, src, idx)
+		return
+	}
+	s.Vpinsrw(dst, dst, src, idx)
+}
+
+func (s simd) shufps(dst, src, imm Operand) {
+	if !s.avx {
+		s.Shufps(dst, src, imm)
+		return
+	}
+	s.Vshufps(s.reg(dst), s.reg(dst), s.reg(src), imm)
+}
+
+func (s simd) pshufb(dst, src Operand)     { s.binary(s.Pshufb, s.Vpshufb, dst, src) }
+func (s simd) pxor(dst, src Operand)       { s.binary(s.Pxor, s.Vpxor, dst, src) }
+func (s simd) packssdw(dst, src Operand)   { s.binary(s.Packssdw, s.Vpackssdw, dst, src) }
+func (s simd) packuswb(dst, src Operand)   { s.binary(s.Packuswb, s.Vpackuswb, dst, src) }
+func (s simd) paddd(dst, src Operand)      { s.binary(s.Paddd, s.Vpaddd, dst, src) }
+func (s simd) paddw(dst, src Operand)      { s.binary(s.Paddw, s.Vpaddw, dst, src) }
+func (s simd) pmaddwd(dst, src Operand)    { s.binary(s.Pmaddwd, s.Vpmaddwd, dst, src) }
+func (s simd) psrad(dst, src Operand)      { s.binary(s.Psrad, s.Vpsrad, dst, src) }
+func (s simd) punpckhbw(dst, src Operand)  { s.binary(s.Punpckhbw, s.Vpunpckhbw, dst, src) }
+func (s simd) punpckhqdq(dst, src Operand) { s.binary(s.Punpckhqdq, s.Vpunpckhqdq, dst, src) }
+func (s simd) punpcklbw(dst, src Operand)  { s.binary(s.Punpcklbw, s.Vpunpcklbw, dst, src) }
+func (s simd) punpckldq(dst, src Operand)  { s.binary(s.Punpckldq, s.Vpunpckldq, dst, src) }
+func (s simd) punpcklqdq(dst, src Operand) { s.binary(s.Punpcklqdq, s.Vpunpcklqdq, dst, src) }
+func (s simd) punpckhwd(dst, src Operand)  { s.binary(s.Punpckhwd, s.Vpunpckhwd, dst, src) }
+func (s simd) punpcklwd(dst, src Operand)  { s.binary(s.Punpcklwd, s.Vpunpcklwd, dst, src) }
+
+// insert copies the low lane of src into the high lane of dst
+func (s simd) insert(dst, src SimdRegister) {
+	s.Vinserti128(dst.Y(), dst.Y(), src.X(), Constant(1))
+}
+
+// ret clears upper ymm halves on avx2 to avoid sse transition penalties
+func (s simd) ret() {
+	if s.avx {
+		s.Vzeroupper()
+	}
+	s.Ret()
+}
@@ -0,0 +1,482 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	. "github.com/bamiaux/rez/asm"
+)
+
+type vertical struct {
+	s     simd
+	xtaps int
+	fold  bool // symmetric coeffs
+	// global data
+	zero  Operand
+	hbits Operand
+	u8max Operand
+	// arguments
+	dst    []Operand
+	src    []Operand
+	cof    []Operand
+	off    []Operand
+	taps   Operand
+	width  Operand
+	height Operand
+	dp     Operand
+	sp     Operand
+	// stack
+	srcref   Operand
+	offref   Operand
+	dstoff   Operand
+	maxroll  Operand
+	backroll Operand
+	inner    Operand
+}
+
+// Vertical generates every vertical scaler into a
+func Vertical(a *Asm) {
+	v := vertical{}
+	v.zero = a.Data("zero", bytes.Repeat([]byte{0x00}, 16))
+	v.hbits = a.Data("hbits", bytes.Repeat([]byte{0x00, 0x00, 0x20, 0x00}, 4))
+	v.u8max = a.Data("u8max", bytes.Repeat([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF}, 2))
+	for _, taps := range []int{2, 4, 6, 8, 10, 12, 14, 16, 0} {
+		v.genscale(a, taps)
+	}
+	v.s.avx = true
+	for _, taps := range []int{2, 4, 6, 8, 10, 12, 14, 16, 0} {
+		v.genscale(a, taps)
+	}
+	v.fold = true
+	for _, avx := range []bool{false, true} {
+		v.s.avx = avx
+		for taps := 4; taps <= 16; taps += 2 {
+			v.genscale(a, taps)
+		}
+	}
+	// 16-bit samples
+	gen16(a, true)
+	// anti-ringing on 8-bit samples
+	genDering(a, true)
+}
+
+func (v *vertical) genscale(a *Asm, taps int) {
+	v.s.Asm = a
+	v.xtaps = taps
+	suffix := "N"
+	if taps > 0 {
+		suffix = fmt.Sprintf("%v", taps)
+	}
+	isa := "Amd64"
+	if v.s.avx {
+		isa = "Avx2"
+	}
+	name := "v8scale"
+	if v.fold {
+		name = "v8fold"
+	}
+	a.NewFunction(name + suffix + isa)
+	// arguments
+	v.dst = a.SliceArgument("dst")
+	v.src = a.SliceArgument("src")
+	v.cof = a.SliceArgument("cof")
+	v.off = a.SliceArgument("off")
+	v.taps = a.Argument("taps")
+	v.width = a.Argument("width")
+	v.height = a.Argument("height")
+	v.dp = a.Argument("dp")
+	v.sp = a.Argument("sp")
+	// stack
+	v.srcref = R9
+	v.offref = R10
+	v.dstoff = R11
+	v.maxroll = R12
+	v.backroll = R13
+	if v.xtaps == 0 {
+		v.inner = R14
+	}
+	a.Start()
+	v.frame(a)
+	v.s.ret()
+}
+
+func (v *vertical) frame(a *Asm) {
+	v.setup(a)
+	a.Movq(SI, v.src[0])
+	a.Movq(v.srcref, SI)
+	a.Movq(DI, v.dst[0])
+	a.Movq(R8, v.cof[0])
+	a.Movq(BX, v.sp)
+	if v.fold {
+		// offset of the last pair of source lines
+		a.Imul3q(R14, BX, Constant(v.xtaps-2))
+	}
+	yloop := a.NewLabel("yloop")
+	a.Label(yloop)
+	a.Movq(SI, v.srcref)
+	a.Movq(DX, v.offref)
+	a.Movwqsx(AX, Address(DX))
+	a.Mulq(BX)
+	a.Addq(SI, AX)
+	a.Movq(v.srcref, SI)
+	v.line(a)
+	v.nextline(a)
+	a.Subq(v.height, Constant(1))
+	a.Jne(yloop)
+}
+
+func (v *vertical) setup(a *Asm) {
+	a.Movq(BX, v.dp)
+	a.Movq(CX, v.width)
+	a.Movq(DX, CX)
+	a.Subq(BX, CX)
+	a.Andq(DX, Constant(v.s.width()-1))
+	a.Shrq(CX, Constant(v.s.shift()))
+	a.Movq(v.dstoff, BX)
+	a.Movq(v.maxroll, CX)
+	norollback := a.NewLabel("norollback")
+	a.Movq(AX, DX)
+	a.Orq(AX, AX)
+	a.Je(norollback)
+	a.Subq(DX, Constant(v.s.width()))
+	a.Neg(DX)
+	a.Label(norollback)
+	a.Movq(v.backroll, DX)
+	a.Movq(CX, v.off[0])
+	a.Movq(v.offref, CX)
+	v.s.movo(X14, v.zero)
+	v.s.movo(X13, v.hbits)
+	if v.xtaps == 0 {
+		a.Movq(DX, v.taps)
+		a.Subq(DX, Constant(4))
+		a.Shrq(DX, Constant(1))
+		a.Movq(v.inner, DX)
+	}
+}
+
+func (v *vertical) nextline(a *Asm) {
+	a.Addq(DI, v.dstoff)
+	if v.xtaps == 0 {
+		a.Movq(DX, v.taps)
+		a.Shlq(DX, Constant(xshift))
+		a.Addq(R8, DX)
+	} else {
+		a.Addq(R8, Constant(xwidth*v.xtaps))
+	}
+	a.Addq(v.offref, Constant(xoffset))
+}
+
+func (v *vertical) line(a *Asm) {
+	taps := v.tapsn
+	if v.xtaps == 2 {
+		taps = v.taps2
+	}
+	if v.fold {
+		taps = v.tapsfold
+	}
+	// lines narrower than one register are computed one pixel at a time
+	narrow := a.NewLabel("narrow")
+	end := a.NewLabel("end")
+	a.Movq(CX, v.width)
+	a.Cmpq(Constant(v.s.width()), CX)
+	a.Jlt(narrow)
+	a.Movq(CX, v.maxroll)
+	a.Orq(CX, CX)
+	nomaxloop := a.NewLabel("nomaxloop")
+	a.Je(nomaxloop)
+	maxloop := a.NewLabel("maxloop")
+	a.Label(maxloop)
+	taps(a)
+	a.Subq(CX, Constant(1))
+	a.Jne(maxloop)
+	a.Label(nomaxloop)
+	a.Movq(CX, v.backroll)
+	a.Subq(SI, v.backroll)
+	a.Subq(DI, v.backroll)
+	a.Orq(CX, CX)
+	nobackroll := a.NewLabel("nobackroll")
+	a.Je(nobackroll)
+	taps(a)
+	a.Label(nobackroll)
+	a.Jmp(end)
+	a.Label(narrow)
+	v.narrow(a)
+	a.Label(end)
+}
+
+// narrow computes CX pixels without simd, where maxroll & backroll
+// registers are unused, R13 accumulates every pixel
+func (v *vertical) narrow(a *Asm) {
+	xloop := a.NewLabel("xloop")
+	a.Label(xloop)
+	a.Movq(R12, SI)
+	a.Movq(R13, Constant(0))
+	if v.xtaps > 0 {
+		for i := 0; i < v.xtaps; i++ {
+			v.tap1(a, Address(R8, i>>1*xwidth*2+i&1*xoffset))
+		}
+	} else {
+		a.Movq(R14, R8)
+		a.Movq(R15, v.taps)
+		a.Shrq(R15, Constant(1))
+		loop := a.NewLabel("loop")
+		a.Label(loop)
+		v.tap1(a, Address(R14))
+		v.tap1(a, Address(R14, xoffset))
+		a.Addq(R14, Constant(xwidth*2))
+		a.Subq(R15, Constant(1))
+		a.Jne(loop)
+	}
+	a.Movq(AX, R13)
+	a.Addq(AX, Constant(1<<(14-1)))
+	a.Cmovql(AX, v.zero)
+	a.Shrq(AX, Constant(14))
+	a.Cmpq(AX, v.u8max)
+	a.Cmovql(AX, v.u8max)
+	a.Movb(Address(DI), AL)
+	a.Addq(SI, Constant(1))
+	a.Addq(DI, Constant(1))
+	a.Subq(CX, Constant(1))
+	a.Jne(xloop)
+	if v.xtaps == 0 {
+		// restore inner taps count
+		a.Movq(R14, v.taps)
+		a.Subq(R14, Constant(4))
+		a.Shrq(R14, Constant(1))
+	}
+}
+
+// tap1 adds the R12 source pixel times cof to R13, then moves R12 to the
+// next source line
+func (v *vertical) tap1(a *Asm, cof Operand) {
+	a.Movbqzx(AX, Address(R12))
+	a.Movwqsx(DX, cof)
+	a.Imulq(DX)
+	a.Addq(R13, AX)
+	a.Addq(R12, BX)
+}
+
+func (v *vertical) taps2(a *Asm) {
+	v.s.movou(X12, Address(R8))
+	v.s.movou(X0, Address(SI, BX, SX0))
+	v.s.movou(X3, Address(SI, BX, SX1))
+	v.s.movo(X2, X0)
+	v.s.punpcklbw(X0, X3)
+	v.s.punpckhbw(X2, X3)
+	v.s.movo(X1, X0)
+	v.s.movo(X3, X2)
+	v.s.punpcklbw(X0, X14)
+	v.s.punpckhbw(X1, X14)
+	v.s.punpcklbw(X2, X14)
+	v.s.punpckhbw(X3, X14)
+	v.s.pmaddwd(X0, X12)
+	v.s.pmaddwd(X1, X12)
+	v.s.pmaddwd(X2, X12)
+	v.s.pmaddwd(X3, X12)
+	v.s.paddd(X0, X13)
+	v.s.paddd(X1, X13)
+	v.s.paddd(X2, X13)
+	v.s.paddd(X3, X13)
+	v.s.psrad(X0, Constant(14))
+	v.s.psrad(X1, Constant(14))
+	v.s.psrad(X2, Constant(14))
+	v.s.psrad(X3, Constant(14))
+	v.s.packssdw(X0, X1)
+	v.s.packssdw(X2, X3)
+	v.s.packuswb(X0, X2)
+	v.s.movou(Address(DI), X0)
+	a.Addq(SI, Constant(v.s.width()))
+	a.Addq(DI, Constant(v.s.width()))
+}
+
+func (v *vertical) tapsn(a *Asm) {
+	if v.xtaps != 4 {
+		a.Leaq(AX, Address(SI, BX, SX4))
+	}
+	v.tapsn4(a)
+	if v.xtaps == 0 {
+		v.leftntaps(a)
+	} else if v.xtaps != 4 {
+		v.left2taps(a)
+	}
+	v.s.paddd(X0, X13)
+	v.s.paddd(X1, X13)
+	v.s.paddd(X2, X13)
+	v.s.paddd(X3, X13)
+	v.s.psrad(X0, Constant(14))
+	v.s.psrad(X1, Constant(14))
+	v.s.psrad(X2, Constant(14))
+	v.s.psrad(X3, Constant(14))
+	v.s.packssdw(X0, X1)
+	v.s.packssdw(X2, X3)
+	v.s.packuswb(X0, X2)
+	v.s.movou(Address(DI), X0)
+	a.Addq(SI, Constant(v.s.width()))
+	a.Addq(DI, Constant(v.s.width()))
+}
+
+// tapsfold adds mirrored source lines sharing the same coeffs before
+// multiplying them, which halves pmaddwd work on symmetric kernels
+func (v *vertical) tapsfold(a *Asm) {
+	a.Movq(AX, SI)
+	a.Leaq(DX, Address(SI, R14, SX1))
+	pairs := v.xtaps >> 1
+	v.fold2(a, X0, X1, X2, X3, AX, Address(R8))
+	for i := 1; i < pairs>>1; i++ {
+		a.Leaq(AX, Address(AX, BX, SX2))
+		a.Subq(DX, BX)
+		a.Subq(DX, BX)
+		v.fold2(a, X4, X5, X6, X7, AX, Address(R8, i*xwidth*2))
+		v.s.paddd(X0, X4)
+		v.s.paddd(X1, X5)
+		v.s.paddd(X2, X6)
+		v.s.paddd(X3, X7)
+	}
+	if pairs&1 != 0 {
+		a.Leaq(AX, Address(AX, BX, SX2))
+	}
+	if pairs&1 != 0 {
+		// the middle pair is symmetric on its own
+		v.tapsn2(a, X4, X5, X6, X7, AX, Address(R8, pairs>>1*xwidth*2))
+		v.s.paddd(X0, X4)
+		v.s.paddd(X1, X5)
+		v.s.paddd(X2, X6)
+		v.s.paddd(X3, X7)
+	}
+	v.s.paddd(X0, X13)
+	v.s.paddd(X1, X13)
+	v.s.paddd(X2, X13)
+	v.s.paddd(X3, X13)
+	v.s.psrad(X0, Constant(14))
+	v.s.psrad(X1, Constant(14))
+	v.s.psrad(X2, Constant(14))
+	v.s.psrad(X3, Constant(14))
+	v.s.packssdw(X0, X1)
+	v.s.packssdw(X2, X3)
+	v.s.packuswb(X0, X2)
+	v.s.movou(Address(DI), X0)
+	a.Addq(SI, Constant(v.s.width()))
+	a.Addq(DI, Constant(v.s.width()))
+}
+
+// fold2 multiplies lines src & src+sp added to mirrored lines dst+sp &
+// dst by one pair of coeffs
+func (v *vertical) fold2(a *Asm, xa, xb, xc, xd SimdRegister, src Register, cof Operand) {
+	v.unpack2(a, xa, xb, xc, xd, Address(src, BX, SX0), Address(src, BX, SX1))
+	v.unpack2(a, X8, X9, X10, X11, Address(DX, BX, SX1), Address(DX, BX, SX0))
+	v.s.paddw(xa, X8)
+	v.s.paddw(xb, X9)
+	v.s.paddw(xc, X10)
+	v.s.paddw(xd, X11)
+	v.s.pmaddwd(xa, cof)
+	v.s.pmaddwd(xb, cof)
+	v.s.pmaddwd(xc, cof)
+	v.s.pmaddwd(xd, cof)
+}
+
+// unpack2 interleaves 16-bit samples from lines a & b
+func (v *vertical) unpack2(a *Asm, xa, xb, xc, xd SimdRegister, la, lb Operand) {
+	v.s.movou(xa, la)
+	v.s.movou(xd, lb)
+	v.s.movo(xc, xa)
+	v.s.punpcklbw(xa, xd)
+	v.s.punpckhbw(xc, xd)
+	v.s.movo(xb, xa)
+	v.s.movo(xd, xc)
+	v.s.punpcklbw(xa, X14)
+	v.s.punpckhbw(xb, X14)
+	v.s.punpcklbw(xc, X14)
+	v.s.punpckhbw(xd, X14)
+}
+
+func (v *vertical) tapsn4(a *Asm) {
+	v.s.movou(X0, Address(SI, BX, SX0))
+	v.s.movou(X3, Address(SI, BX, SX1))
+	v.s.movou(X4, Address(SI, BX, SX2))
+	v.s.movou(X10, Address(R8))
+	v.s.movou(X11, Address(R8, xwidth*2))
+	a.Addq(SI, BX)
+	v.s.movou(X7, Address(SI, BX, SX2))
+	v.s.movo(X2, X0)
+	v.s.movo(X6, X4)
+	v.s.punpcklbw(X0, X3)
+	v.s.punpcklbw(X4, X7)
+	v.s.punpckhbw(X2, X3)
+	v.s.punpckhbw(X6, X7)
+	v.s.movo(X1, X0)
+	v.s.movo(X5, X4)
+	v.s.movo(X3, X2)
+	v.s.movo(X7, X6)
+	a.Subq(SI, BX)
+	v.s.punpcklbw(X0, X14)
+	v.s.punpckhbw(X1, X14)
+	v.s.punpcklbw(X4, X14)
+	v.s.punpckhbw(X5, X14)
+	v.s.punpcklbw(X2, X14)
+	v.s.punpckhbw(X3, X14)
+	v.s.punpcklbw(X6, X14)
+	v.s.punpckhbw(X7, X14)
+	v.s.pmaddwd(X0, X10)
+	v.s.pmaddwd(X1, X10)
+	v.s.pmaddwd(X4, X11)
+	v.s.pmaddwd(X5, X11)
+	v.s.pmaddwd(X2, X10)
+	v.s.pmaddwd(X3, X10)
+	v.s.pmaddwd(X6, X11)
+	v.s.pmaddwd(X7, X11)
+	v.s.paddd(X0, X4)
+	v.s.paddd(X1, X5)
+	v.s.paddd(X2, X6)
+	v.s.paddd(X3, X7)
+}
+
+func (v *vertical) left2taps(a *Asm) {
+	for i := 2; i*2 < v.xtaps; i++ {
+		v.tapsn2(a, X4, X5, X6, X7, AX, Address(R8, i*xwidth*2))
+		if i*2+1 < v.xtaps {
+			a.Leaq(AX, Address(AX, BX, SX2))
+		}
+		v.s.paddd(X0, X4)
+		v.s.paddd(X1, X5)
+		v.s.paddd(X2, X6)
+		v.s.paddd(X3, X7)
+	}
+}
+
+func (v *vertical) leftntaps(a *Asm) {
+	a.Movq(R15, v.inner)
+	a.Movq(DX, R8)
+	a.Addq(DX, Constant(xwidth*2))
+	innerloop := a.NewLabel("innerloop")
+	a.Label(innerloop)
+	a.Addq(DX, Constant(xwidth*2))
+	v.tapsn2(a, X4, X5, X6, X7, AX, Address(DX))
+	a.Leaq(AX, Address(AX, BX, SX2))
+	v.s.paddd(X0, X4)
+	v.s.paddd(X1, X5)
+	v.s.paddd(X2, X6)
+	v.s.paddd(X3, X7)
+	a.Subq(R15, Constant(1))
+	a.Jne(innerloop)
+}
+
+func (v *vertical) tapsn2(a *Asm, xa, xb, xc, xd SimdRegister, src Register, cof Operand) {
+	v.s.movou(xa, Address(src, BX, SX0))
+	v.s.movou(xd, Address(src, BX, SX1))
+	v.s.movo(xc, xa)
+	v.s.punpcklbw(xa, xd)
+	v.s.punpckhbw(xc, xd)
+	v.s.movo(xb, xa)
+	v.s.movo(xd, xc)
+	v.s.punpcklbw(xa, X14)
+	v.s.punpckhbw(xb, X14)
+	v.s.punpcklbw(xc, X14)
+	v.s.punpckhbw(xd, X14)
+	v.s.pmaddwd(xa, cof)
+	v.s.pmaddwd(xb, cof)
+	v.s.pmaddwd(xc, cof)
+	v.s.pmaddwd(xd, cof)
+}
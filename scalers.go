@@ -23,6 +23,16 @@ func u8(x int) byte {
 	return byte(x)
 }
 
+func u16(x int) uint16 {
+	if x < 0 {
+		x = 0
+	}
+	if x > 0xFFFF {
+		x = 0xFFFF
+	}
+	return uint16(x)
+}
+
 func copyPlane(dst, src []byte, width, height, dp, sp int) {
 	di := 0
 	si := 0
@@ -73,8 +83,11 @@ func h8scaleNGo(dst, src []byte, cof, off []int16,
 func v8scaleNGo(dst, src []byte, cof, off []int16,
 	taps, width, height, dp, sp int) {
 	di := 0
+	si := 0
 	for _, yoff := range off[:height] {
-		src = src[sp*int(yoff):]
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
 		for x := range dst[di : di+width] {
 			pix := 0
 			for i, c := range cof[:taps] {
@@ -86,3 +99,220 @@ func v8scaleNGo(dst, src []byte, cof, off []int16,
 		di += dp
 	}
 }
+
+// h8p4scaleNGo scales 4-bytes packed pixels, width is in pixels and
+// offsets are in bytes
+func h8p4scaleNGo(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di : di+width*4]
+		for x, xoff := range off[:width] {
+			r, g, b, a := 0, 0, 0, 0
+			for i, v := range c[:taps] {
+				p := s[int(xoff)+i*4 : int(xoff)+i*4+4]
+				r += int(p[0]) * int(v)
+				g += int(p[1]) * int(v)
+				b += int(p[2]) * int(v)
+				a += int(p[3]) * int(v)
+			}
+			d[x*4+0] = u8((r + 1<<(Bits-1)) >> Bits)
+			d[x*4+1] = u8((g + 1<<(Bits-1)) >> Bits)
+			d[x*4+2] = u8((b + 1<<(Bits-1)) >> Bits)
+			d[x*4+3] = u8((a + 1<<(Bits-1)) >> Bits)
+			c = c[taps:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+// h16scaleNGo scales little-endian 16-bit samples, width & offsets are in
+// samples
+func h16scaleNGo(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		c := cof
+		s := src[si:]
+		d := dst[di:]
+		for x, xoff := range off[:width] {
+			pix := 0
+			for i, v := range c[:taps] {
+				pix += int(get16(s, int(xoff)+i, true)) * int(v)
+			}
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+			c = c[taps:]
+		}
+		di += dp
+		si += sp
+	}
+}
+
+// v16scaleNGo scales little-endian 16-bit samples, width is in samples
+func v16scaleNGo(dst, src []byte, cof, off []int16,
+	taps, width, height, dp, sp int) {
+	di := 0
+	si := 0
+	for _, yoff := range off[:height] {
+		// offsets step back when kernels are trimmed
+		si += sp * int(yoff)
+		src := src[si:]
+		d := dst[di:]
+		for x := 0; x < width; x++ {
+			pix := 0
+			for i, c := range cof[:taps] {
+				pix += int(c) * int(get16(src[sp*i:], x, true))
+			}
+			v := u16((pix + 1<<(Bits-1)) >> Bits)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		cof = cof[taps:]
+		di += dp
+	}
+}
+
+// newHorizontalBitsScaler returns a scaler for coeffs with bits
+// fractional bits
+func newHorizontalBitsScaler(bits uint) scaler {
+	return func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+		di := 0
+		si := 0
+		for y := 0; y < height; y++ {
+			c := cof
+			s := src[si:]
+			d := dst[di:]
+			for x, xoff := range off[:width] {
+				pix := 0
+				for i, v := range s[xoff : xoff+int16(taps)] {
+					pix += int(v) * int(c[i])
+				}
+				d[x] = u8((pix + 1<<(bits-1)) >> bits)
+				c = c[taps:]
+			}
+			di += dp
+			si += sp
+		}
+	}
+}
+
+// newVerticalBitsScaler returns a scaler for coeffs with bits
+// fractional bits
+func newVerticalBitsScaler(bits uint) scaler {
+	return func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+		di := 0
+		si := 0
+		for _, yoff := range off[:height] {
+			// offsets step back when kernels are trimmed
+			si += sp * int(yoff)
+			src := src[si:]
+			for x := range dst[di : di+width] {
+				pix := 0
+				for i, c := range cof[:taps] {
+					pix += int(c) * int(src[sp*i+x])
+				}
+				dst[di+x] = u8((pix + 1<<(bits-1)) >> bits)
+			}
+			cof = cof[taps:]
+			di += dp
+		}
+	}
+}
+
+// newPackedBitsScaler returns a 4-bytes packed pixels scaler for coeffs
+// with bits fractional bits
+func newPackedBitsScaler(bits uint) scaler {
+	return func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+		di := 0
+		si := 0
+		for y := 0; y < height; y++ {
+			c := cof
+			s := src[si:]
+			d := dst[di : di+width*4]
+			for x, xoff := range off[:width] {
+				for k := 0; k < 4; k++ {
+					pix := 0
+					for i, v := range c[:taps] {
+						pix += int(s[int(xoff)+i*4+k]) * int(v)
+					}
+					d[x*4+k] = u8((pix + 1<<(bits-1)) >> bits)
+				}
+				c = c[taps:]
+			}
+			di += dp
+			si += sp
+		}
+	}
+}
+
+// newHorizontalWideScaler returns a scaler for coeffs with bits fractional
+// bits reading and/or writing 16-bit 8.8 fixed point samples
+func newHorizontalWideScaler(bits uint, in, out bool) scaler {
+	down := bits + 8*bin(in)
+	up := 8 * bin(out)
+	return func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+		di := 0
+		si := 0
+		for y := 0; y < height; y++ {
+			c := cof
+			s := src[si:]
+			d := dst[di:]
+			for x, xoff := range off[:width] {
+				pix := 0
+				for i := range c[:taps] {
+					if in {
+						j := (int(xoff) + i) * 2
+						pix += (int(s[j]) | int(s[j+1])<<8) * int(c[i])
+					} else {
+						pix += int(s[int(xoff)+i]) * int(c[i])
+					}
+				}
+				pix = (pix<<up + 1<<(down-1)) >> down
+				if out {
+					v := u16(pix)
+					d[x*2] = byte(v)
+					d[x*2+1] = byte(v >> 8)
+				} else {
+					d[x] = u8(pix)
+				}
+				c = c[taps:]
+			}
+			di += dp
+			si += sp
+		}
+	}
+}
+
+// newVerticalWideScaler returns a scaler writing 16-bit 8.8 fixed point
+// samples for coeffs with bits fractional bits, reading 16-bit samples if
+// in is set
+func newVerticalWideScaler(bits uint, in bool) scaler {
+	return func(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int) {
+		di := 0
+		si := 0
+		for _, yoff := range off[:height] {
+			// offsets step back when kernels are trimmed
+			si += sp * int(yoff)
+			src := src[si:]
+			d := dst[di : di+width*2]
+			for x := 0; x < width; x++ {
+				pix := 0
+				for i, c := range cof[:taps] {
+					pix += int(c) * int(get16(src[sp*i:], x, in))
+				}
+				v := u16((pix + 1<<(bits-1)) >> bits)
+				d[x*2] = byte(v)
+				d[x*2+1] = byte(v >> 8)
+			}
+			cof = cof[taps:]
+			di += dp
+		}
+	}
+}
@@ -6,56 +6,276 @@ package rez
 
 func hasAsm() bool { return true }
 
+func cpuid(op, op2 uint32) (eax, ebx, ecx, edx uint32)
+func xgetbv() (eax, edx uint32)
+
+// detectISA returns the best instruction set supported by the cpu & the os
+func detectISA() ISA {
+	max, _, _, _ := cpuid(0, 0)
+	_, _, ecx, _ := cpuid(1, 0)
+	isa := ISASSE2
+	if ecx&(1<<9) != 0 {
+		isa = ISASSSE3
+	}
+	if isa == ISASSSE3 && ecx&(1<<19) != 0 {
+		isa = ISASSE41
+	}
+	// osxsave & avx
+	if max < 7 || isa != ISASSE41 || ecx&(1<<27) == 0 || ecx&(1<<28) == 0 {
+		return isa
+	}
+	// xmm & ymm states must be saved by the os
+	xcr0, _ := xgetbv()
+	_, ebx, _, _ := cpuid(7, 0)
+	if xcr0&6 != 6 || ebx&(1<<5) == 0 {
+		return isa
+	}
+	isa = ISAAVX2
+	// avx512f & avx512bw with opmask & zmm states
+	if xcr0&0xE6 == 0xE6 && ebx&(1<<16) != 0 && ebx&(1<<30) != 0 {
+		isa = ISAAVX512BW
+	}
+	return isa
+}
+
 func h8scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func h8scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func h8scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func h8scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func h8scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func h8scaleNAmd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8p4scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8p4scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8p4scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8p4scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8p4scaleNAmd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
 func v8scaleNAmd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale2Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale4Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale6Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale8Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale10Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale12Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale14Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scale16Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8scaleNAvx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale2Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale4Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale6Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale8Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale10Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale12Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale14Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scale16Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8scaleNAvx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold4Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold6Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold8Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold10Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold12Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold14Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8fold16Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8shuffle2Ssse3(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8shuffle4Ssse3(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8shuffle2Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8shuffle4Avx2(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v16scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8to16scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h16to8scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale2Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale4Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale6Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale8Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale10Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale12Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale14Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func v8to16scale16Amd64(dst, src []byte, cof, off []int16, taps, width, height, dp, sp int)
+func h8deringAmd64(dst, src []byte, near []int32, strength, width, height, dp, sp int)
+func v8deringAmd64(dst, src []byte, near []int32, strength, width, height, dp, sp int)
 
-func getHorizontalScaler(taps int, asm bool) scaler {
-	if !asm {
-		return getHorizontalScalerGo(taps)
-	}
-	switch taps {
-	case 2:
-		return h8scale2Amd64
-	case 4:
-		return h8scale4Amd64
-	case 8:
-		return h8scale8Amd64
-	case 10:
-		return h8scale10Amd64
-	case 12:
-		return h8scale12Amd64
-	}
-	return h8scaleNAmd64
+// h8deringSse2 is h8deringGo on unpacked samples, 8 samples at a time
+func h8deringSse2(dst, src []byte, near []int32, strength, width, height, dp, sp int) {
+	n := width &^ 7
+	if n > 0 && height > 0 {
+		h8deringAmd64(dst, src, near, strength, n, height, dp, sp)
+	}
+	if n < width {
+		h8deringGo(dst[n:], src, near[n:], strength, width-n, height, dp, sp, 1)
+	}
 }
 
-func getVerticalScaler(taps int, asm bool) scaler {
-	if !asm {
-		return getVerticalScalerGo(taps)
-	}
-	switch taps {
-	case 2:
-		return v8scale2Amd64
-	case 4:
-		return v8scale4Amd64
-	case 6:
-		return v8scale6Amd64
-	case 8:
-		return v8scale8Amd64
-	case 10:
-		return v8scale10Amd64
-	case 12:
-		return v8scale12Amd64
-	}
-	return v8scaleNAmd64
+// v8deringSse2 is v8deringGo, 16 samples at a time
+func v8deringSse2(dst, src []byte, near []int32, strength, width, height, dp, sp int) {
+	n := width &^ 15
+	if n > 0 && height > 0 {
+		v8deringAmd64(dst, src, near, strength, n, height, dp, sp)
+	}
+	if n < width {
+		v8deringGo(dst[n:], src[n:], near, strength, width-n, height, dp, sp)
+	}
+}
+
+func init() {
+	for _, it := range []struct {
+		kind scalerKind
+		isa  ISA
+		taps int
+		name string
+		fn   scaler
+	}{
+		{horizontalScaler, ISASSE2, 2, "h8scale2Amd64", h8scale2Amd64},
+		{horizontalScaler, ISASSE2, 4, "h8scale4Amd64", h8scale4Amd64},
+		{horizontalScaler, ISASSE2, 6, "h8scale6Amd64", h8scale6Amd64},
+		{horizontalScaler, ISASSE2, 8, "h8scale8Amd64", h8scale8Amd64},
+		{horizontalScaler, ISASSE2, 10, "h8scale10Amd64", h8scale10Amd64},
+		{horizontalScaler, ISASSE2, 12, "h8scale12Amd64", h8scale12Amd64},
+		{horizontalScaler, ISASSE2, 14, "h8scale14Amd64", h8scale14Amd64},
+		{horizontalScaler, ISASSE2, 16, "h8scale16Amd64", h8scale16Amd64},
+		{horizontalScaler, ISASSE2, 0, "h8scaleNAmd64", h8scaleNAmd64},
+		{packedScaler, ISASSE2, 2, "h8p4scale2Amd64", h8p4scale2Amd64},
+		{packedScaler, ISASSE2, 4, "h8p4scale4Amd64", h8p4scale4Amd64},
+		{packedScaler, ISASSE2, 6, "h8p4scale6Amd64", h8p4scale6Amd64},
+		{packedScaler, ISASSE2, 8, "h8p4scale8Amd64", h8p4scale8Amd64},
+		{packedScaler, ISASSE2, 0, "h8p4scaleNAmd64", h8p4scaleNAmd64},
+		{verticalScaler, ISASSE2, 2, "v8scale2Amd64", v8scale2Amd64},
+		{verticalScaler, ISASSE2, 4, "v8scale4Amd64", v8scale4Amd64},
+		{verticalScaler, ISASSE2, 6, "v8scale6Amd64", v8scale6Amd64},
+		{verticalScaler, ISASSE2, 8, "v8scale8Amd64", v8scale8Amd64},
+		{verticalScaler, ISASSE2, 10, "v8scale10Amd64", v8scale10Amd64},
+		{verticalScaler, ISASSE2, 12, "v8scale12Amd64", v8scale12Amd64},
+		{verticalScaler, ISASSE2, 14, "v8scale14Amd64", v8scale14Amd64},
+		{verticalScaler, ISASSE2, 16, "v8scale16Amd64", v8scale16Amd64},
+		{verticalScaler, ISASSE2, 0, "v8scaleNAmd64", v8scaleNAmd64},
+		{horizontalScaler, ISAAVX2, 2, "h8scale2Avx2", h8scale2Avx2},
+		{horizontalScaler, ISAAVX2, 4, "h8scale4Avx2", h8scale4Avx2},
+		{horizontalScaler, ISAAVX2, 6, "h8scale6Avx2", h8scale6Avx2},
+		{horizontalScaler, ISAAVX2, 8, "h8scale8Avx2", h8scale8Avx2},
+		{horizontalScaler, ISAAVX2, 10, "h8scale10Avx2", h8scale10Avx2},
+		{horizontalScaler, ISAAVX2, 12, "h8scale12Avx2", h8scale12Avx2},
+		{horizontalScaler, ISAAVX2, 14, "h8scale14Avx2", h8scale14Avx2},
+		{horizontalScaler, ISAAVX2, 16, "h8scale16Avx2", h8scale16Avx2},
+		{horizontalScaler, ISAAVX2, 0, "h8scaleNAvx2", h8scaleNAvx2},
+		{verticalScaler, ISAAVX2, 2, "v8scale2Avx2", v8scale2Avx2},
+		{verticalScaler, ISAAVX2, 4, "v8scale4Avx2", v8scale4Avx2},
+		{verticalScaler, ISAAVX2, 6, "v8scale6Avx2", v8scale6Avx2},
+		{verticalScaler, ISAAVX2, 8, "v8scale8Avx2", v8scale8Avx2},
+		{verticalScaler, ISAAVX2, 10, "v8scale10Avx2", v8scale10Avx2},
+		{verticalScaler, ISAAVX2, 12, "v8scale12Avx2", v8scale12Avx2},
+		{verticalScaler, ISAAVX2, 14, "v8scale14Avx2", v8scale14Avx2},
+		{verticalScaler, ISAAVX2, 16, "v8scale16Avx2", v8scale16Avx2},
+		{verticalScaler, ISAAVX2, 0, "v8scaleNAvx2", v8scaleNAvx2},
+		{verticalFoldScaler, ISASSE2, 4, "v8fold4Amd64", v8fold4Amd64},
+		{verticalFoldScaler, ISASSE2, 6, "v8fold6Amd64", v8fold6Amd64},
+		{verticalFoldScaler, ISASSE2, 8, "v8fold8Amd64", v8fold8Amd64},
+		{verticalFoldScaler, ISASSE2, 10, "v8fold10Amd64", v8fold10Amd64},
+		{verticalFoldScaler, ISASSE2, 12, "v8fold12Amd64", v8fold12Amd64},
+		{verticalFoldScaler, ISASSE2, 14, "v8fold14Amd64", v8fold14Amd64},
+		{verticalFoldScaler, ISASSE2, 16, "v8fold16Amd64", v8fold16Amd64},
+		{verticalFoldScaler, ISAAVX2, 4, "v8fold4Avx2", v8fold4Avx2},
+		{verticalFoldScaler, ISAAVX2, 6, "v8fold6Avx2", v8fold6Avx2},
+		{verticalFoldScaler, ISAAVX2, 8, "v8fold8Avx2", v8fold8Avx2},
+		{verticalFoldScaler, ISAAVX2, 10, "v8fold10Avx2", v8fold10Avx2},
+		{verticalFoldScaler, ISAAVX2, 12, "v8fold12Avx2", v8fold12Avx2},
+		{verticalFoldScaler, ISAAVX2, 14, "v8fold14Avx2", v8fold14Avx2},
+		{verticalFoldScaler, ISAAVX2, 16, "v8fold16Avx2", v8fold16Avx2},
+		{shuffleScaler, ISASSSE3, 2, "h8shuffle2Ssse3", h8shuffle2Ssse3},
+		{shuffleScaler, ISASSSE3, 4, "h8shuffle4Ssse3", h8shuffle4Ssse3},
+		{shuffleScaler, ISAAVX2, 2, "h8shuffle2Avx2", h8shuffle2Avx2},
+		{shuffleScaler, ISAAVX2, 4, "h8shuffle4Avx2", h8shuffle4Avx2},
+		{horizontal16Scaler, ISASSE2, 2, "h16scale2Amd64", h16scale2Amd64},
+		{horizontal16Scaler, ISASSE2, 4, "h16scale4Amd64", h16scale4Amd64},
+		{horizontal16Scaler, ISASSE2, 6, "h16scale6Amd64", h16scale6Amd64},
+		{horizontal16Scaler, ISASSE2, 8, "h16scale8Amd64", h16scale8Amd64},
+		{horizontal16Scaler, ISASSE2, 10, "h16scale10Amd64", h16scale10Amd64},
+		{horizontal16Scaler, ISASSE2, 12, "h16scale12Amd64", h16scale12Amd64},
+		{horizontal16Scaler, ISASSE2, 14, "h16scale14Amd64", h16scale14Amd64},
+		{horizontal16Scaler, ISASSE2, 16, "h16scale16Amd64", h16scale16Amd64},
+		{vertical16Scaler, ISASSE2, 2, "v16scale2Amd64", v16scale2Amd64},
+		{vertical16Scaler, ISASSE2, 4, "v16scale4Amd64", v16scale4Amd64},
+		{vertical16Scaler, ISASSE2, 6, "v16scale6Amd64", v16scale6Amd64},
+		{vertical16Scaler, ISASSE2, 8, "v16scale8Amd64", v16scale8Amd64},
+		{vertical16Scaler, ISASSE2, 10, "v16scale10Amd64", v16scale10Amd64},
+		{vertical16Scaler, ISASSE2, 12, "v16scale12Amd64", v16scale12Amd64},
+		{vertical16Scaler, ISASSE2, 14, "v16scale14Amd64", v16scale14Amd64},
+		{vertical16Scaler, ISASSE2, 16, "v16scale16Amd64", v16scale16Amd64},
+		{horizontal8to16Scaler, ISASSE2, 2, "h8to16scale2Amd64", h8to16scale2Amd64},
+		{horizontal8to16Scaler, ISASSE2, 4, "h8to16scale4Amd64", h8to16scale4Amd64},
+		{horizontal8to16Scaler, ISASSE2, 6, "h8to16scale6Amd64", h8to16scale6Amd64},
+		{horizontal8to16Scaler, ISASSE2, 8, "h8to16scale8Amd64", h8to16scale8Amd64},
+		{horizontal8to16Scaler, ISASSE2, 10, "h8to16scale10Amd64", h8to16scale10Amd64},
+		{horizontal8to16Scaler, ISASSE2, 12, "h8to16scale12Amd64", h8to16scale12Amd64},
+		{horizontal8to16Scaler, ISASSE2, 14, "h8to16scale14Amd64", h8to16scale14Amd64},
+		{horizontal8to16Scaler, ISASSE2, 16, "h8to16scale16Amd64", h8to16scale16Amd64},
+		{horizontal16to8Scaler, ISASSE2, 2, "h16to8scale2Amd64", h16to8scale2Amd64},
+		{horizontal16to8Scaler, ISASSE2, 4, "h16to8scale4Amd64", h16to8scale4Amd64},
+		{horizontal16to8Scaler, ISASSE2, 6, "h16to8scale6Amd64", h16to8scale6Amd64},
+		{horizontal16to8Scaler, ISASSE2, 8, "h16to8scale8Amd64", h16to8scale8Amd64},
+		{horizontal16to8Scaler, ISASSE2, 10, "h16to8scale10Amd64", h16to8scale10Amd64},
+		{horizontal16to8Scaler, ISASSE2, 12, "h16to8scale12Amd64", h16to8scale12Amd64},
+		{horizontal16to8Scaler, ISASSE2, 14, "h16to8scale14Amd64", h16to8scale14Amd64},
+		{horizontal16to8Scaler, ISASSE2, 16, "h16to8scale16Amd64", h16to8scale16Amd64},
+		{vertical8to16Scaler, ISASSE2, 2, "v8to16scale2Amd64", v8to16scale2Amd64},
+		{vertical8to16Scaler, ISASSE2, 4, "v8to16scale4Amd64", v8to16scale4Amd64},
+		{vertical8to16Scaler, ISASSE2, 6, "v8to16scale6Amd64", v8to16scale6Amd64},
+		{vertical8to16Scaler, ISASSE2, 8, "v8to16scale8Amd64", v8to16scale8Amd64},
+		{vertical8to16Scaler, ISASSE2, 10, "v8to16scale10Amd64", v8to16scale10Amd64},
+		{vertical8to16Scaler, ISASSE2, 12, "v8to16scale12Amd64", v8to16scale12Amd64},
+		{vertical8to16Scaler, ISASSE2, 14, "v8to16scale14Amd64", v8to16scale14Amd64},
+		{vertical8to16Scaler, ISASSE2, 16, "v8to16scale16Amd64", v8to16scale16Amd64},
+	} {
+		registerScaler(it.kind, it.isa, it.taps, it.name, it.fn)
+	}
+	registerDeringer(false, ISASSE2, h8deringSse2)
+	registerDeringer(true, ISASSE2, v8deringSse2)
+	// avx2 vertical scalers compute slices smaller than 32 pixels one pixel
+	// at a time, sse2 ones are faster there
+	setScalerMinWidth(verticalScaler, ISAAVX2, 32)
+	setScalerMinWidth(verticalFoldScaler, ISAAVX2, 32)
 }
@@ -8,10 +8,4 @@ package rez
 
 func hasAsm() bool { return false }
 
-func getHorizontalScaler(taps int, asm bool) scaler {
-	return getHorizontalScalerGo(taps)
-}
-
-func getVerticalScaler(taps int, asm bool) scaler {
-	return getVerticalScalerGo(taps)
-}
+func detectISA() ISA { return ISAGo }
@@ -0,0 +1,490 @@
+// Copyright 2014 Benoît Amiaux. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rez
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// TileLayout is a tile pyramid directory layout
+type TileLayout int
+
+const (
+	// LayoutDZI is the Deep Zoom Image layout
+	// <name>.dzi & <name>_files/<level>/<column>_<row>.<ext>
+	LayoutDZI TileLayout = iota
+	// LayoutXYZ is the slippy map layout
+	// <name>/<z>/<x>/<y>.<ext>
+	LayoutXYZ
+)
+
+// TileFormat is a tile image format
+type TileFormat int
+
+const (
+	// TilePNG encodes tiles with PNG
+	TilePNG TileFormat = iota
+	// TileJPEG encodes tiles with JPEG
+	TileJPEG
+)
+
+// TilerConfig is a configuration used with NewTiler
+type TilerConfig struct {
+	TileSize   int        // tile size in pixels [default=256]
+	Overlap    int        // overlapping pixels on each tile side, DZI only
+	Layout     TileLayout // output directory layout
+	Format     TileFormat // output tile format
+	Quality    int        // JPEG quality [default=jpeg.DefaultQuality]
+	Strip      int        // source rows read at once [default=TileSize]
+	Threads    int        // number of allowed "threads"
+	DisableAsm bool       // disable asm optimisations
+}
+
+// Tiler is an interface that cuts images into tile pyramids
+type Tiler interface {
+	// Export writes every tile of every src zoom level into dir
+	// dir = output directory
+	// name = base name used for the descriptor and tile directories
+	// src = source image, read from top to bottom in strips
+	// Returns an error if src format is not supported or if writing fails
+	Export(dir, name string, src image.Image) error
+}
+
+type tilerContext struct {
+	TilerConfig
+	filter Filter
+}
+
+// NewTiler returns a Tiler interface
+// cfg = tiler configuration
+// filter = filter used for computing lower zoom levels
+// Returns an error if the configuration is invalid
+func NewTiler(cfg *TilerConfig, filter Filter) (Tiler, error) {
+	ctx := &tilerContext{
+		TilerConfig: *cfg,
+		filter:      filter,
+	}
+	if ctx.TileSize == 0 {
+		ctx.TileSize = 256
+	}
+	if ctx.Quality == 0 {
+		ctx.Quality = jpeg.DefaultQuality
+	}
+	if ctx.Strip == 0 {
+		ctx.Strip = ctx.TileSize
+	}
+	if ctx.Threads == 0 {
+		ctx.Threads = runtime.GOMAXPROCS(0)
+	}
+	if ctx.TileSize < 1 {
+		return nil, fmt.Errorf("invalid tile size %v", ctx.TileSize)
+	}
+	if ctx.Overlap < 0 || ctx.Overlap >= ctx.TileSize {
+		return nil, fmt.Errorf("invalid tile overlap %v", ctx.Overlap)
+	}
+	if ctx.Strip < 1 {
+		return nil, fmt.Errorf("invalid strip size %v", ctx.Strip)
+	}
+	if ctx.Layout != LayoutDZI && ctx.Layout != LayoutXYZ {
+		return nil, fmt.Errorf("invalid tile layout %v", ctx.Layout)
+	}
+	if ctx.Format != TilePNG && ctx.Format != TileJPEG {
+		return nil, fmt.Errorf("invalid tile format %v", ctx.Format)
+	}
+	return ctx, nil
+}
+
+// tileLevel holds a sliding window of rows for one zoom level
+type tileLevel struct {
+	level   int    // output zoom level
+	width   int    // width in pixels
+	height  int    // height in pixels
+	pitch   int    // pitch in bytes
+	data    []byte // rows [first, first+rows)
+	first   int    // index of the first available row
+	rows    int    // number of available rows
+	tileRow int    // next tile row to write
+	next    int    // next row to compute in the following level
+}
+
+func (l *tileLevel) complete() bool {
+	return l.first+l.rows == l.height
+}
+
+func (l *tileLevel) grow(rows int) []byte {
+	size := (l.rows + rows) * l.pitch
+	if size > cap(l.data) {
+		data := make([]byte, size, size*2)
+		copy(data, l.data)
+		l.data = data
+	}
+	l.data = l.data[:size]
+	dst := l.data[l.rows*l.pitch:]
+	l.rows += rows
+	return dst
+}
+
+func (l *tileLevel) drop(first int) {
+	n := first - l.first
+	if n <= 0 {
+		return
+	}
+	n = min(n, l.rows)
+	copy(l.data, l.data[n*l.pitch:l.rows*l.pitch])
+	l.rows -= n
+	l.first += n
+	l.data = l.data[:l.rows*l.pitch]
+}
+
+func (l *tileLevel) row(y int) []byte {
+	return l.data[(y-l.first)*l.pitch:]
+}
+
+type resizerKey struct {
+	vertical bool
+	input    int
+	output   int
+	threads  int
+	asm      bool
+}
+
+type tilerExport struct {
+	*tilerContext
+	dir     string
+	gray    bool
+	pack    int
+	margin  int
+	levels  []*tileLevel
+	buffer  []byte // odd heights band
+	scaled  []byte // vertically resized band
+	resizer map[resizerKey]Resizer
+	jobs    chan struct{}
+	group   sync.WaitGroup
+	errs    chan error
+}
+
+func getTileLevelCount(layout TileLayout, tile, width, height int) int {
+	size := max(width, height)
+	count := 1
+	for size > 1 {
+		if layout == LayoutXYZ && size <= tile {
+			break
+		}
+		size = (size + 1) >> 1
+		count++
+	}
+	return count
+}
+
+func isGrayImage(img image.Image) bool {
+	switch img.(type) {
+	case *image.Gray, *image.Gray16:
+		return true
+	}
+	return false
+}
+
+func (t *tilerContext) Export(dir, name string, src image.Image) error {
+	b := src.Bounds()
+	if b.Empty() {
+		return fmt.Errorf("invalid empty input")
+	}
+	ctx := &tilerExport{
+		tilerContext: t,
+		gray:         isGrayImage(src),
+		pack:         4,
+		margin:       t.filter.Taps()*2 + 1,
+		resizer:      make(map[resizerKey]Resizer),
+		jobs:         make(chan struct{}, t.Threads),
+		errs:         make(chan error, 1),
+	}
+	if ctx.gray {
+		ctx.pack = 1
+	}
+	ctx.dir = filepath.Join(dir, name)
+	if t.Layout == LayoutDZI {
+		ctx.dir += "_files"
+		err := ctx.writeDescriptor(filepath.Join(dir, name+".dzi"), b.Dx(), b.Dy())
+		if err != nil {
+			return err
+		}
+	}
+	count := getTileLevelCount(t.Layout, t.TileSize, b.Dx(), b.Dy())
+	w, h := b.Dx(), b.Dy()
+	for i := 0; i < count; i++ {
+		ctx.levels = append(ctx.levels, &tileLevel{
+			level:  count - 1 - i,
+			width:  w,
+			height: h,
+			pitch:  w * ctx.pack,
+		})
+		w = (w + 1) >> 1
+		h = (h + 1) >> 1
+	}
+	top := ctx.levels[0]
+	for y := 0; y < top.height && ctx.err() == nil; y += t.Strip {
+		rows := min(t.Strip, top.height-y)
+		ctx.read(top.grow(rows), top.pitch, src, b.Min.X, b.Min.Y+y, top.width, rows)
+		ctx.fail(ctx.flush(0))
+	}
+	ctx.group.Wait()
+	return ctx.err()
+}
+
+func (ctx *tilerExport) err() error {
+	select {
+	case err := <-ctx.errs:
+		ctx.errs <- err
+		return err
+	default:
+		return nil
+	}
+}
+
+func (ctx *tilerExport) fail(err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case ctx.errs <- err:
+	default:
+	}
+}
+
+func (ctx *tilerExport) read(dst []byte, pitch int, src image.Image, x, y, width, height int) {
+	rect := image.Rect(0, 0, width, height)
+	var img draw.Image
+	if ctx.gray {
+		img = &image.Gray{Pix: dst, Stride: pitch, Rect: rect}
+	} else {
+		img = &image.RGBA{Pix: dst, Stride: pitch, Rect: rect}
+	}
+	draw.Draw(img, rect, src, image.Pt(x, y), draw.Src)
+}
+
+func (ctx *tilerExport) getResizer(vertical bool, input, output, width, rows int) (Resizer, error) {
+	asm := !ctx.DisableAsm
+	key := resizerKey{vertical, input, output, min(ctx.Threads, rows), asm}
+	if r, ok := ctx.resizer[key]; ok {
+		return r, nil
+	}
+	r, err := NewResize(&ResizerConfig{
+		Depth:      8,
+		Input:      input,
+		Output:     output,
+		Vertical:   vertical,
+		Pack:       ctx.pack,
+		Threads:    key.threads,
+		DisableAsm: !asm,
+	}, ctx.filter)
+	if err != nil {
+		return nil, err
+	}
+	ctx.resizer[key] = r
+	return r, nil
+}
+
+// getTileSpan returns the [begin, end) pixel span for tile idx
+func (ctx *tilerExport) getTileSpan(idx, size int) (int, int) {
+	t := ctx.TileSize
+	if ctx.Layout == LayoutXYZ {
+		return idx * t, min((idx+1)*t, size)
+	}
+	begin := idx*t - ctx.Overlap
+	if idx == 0 {
+		begin = 0
+	}
+	return begin, min((idx+1)*t+ctx.Overlap, size)
+}
+
+func (ctx *tilerExport) flush(idx int) error {
+	l := ctx.levels[idx]
+	tileRows := (l.height + ctx.TileSize - 1) / ctx.TileSize
+	for ; l.tileRow < tileRows; l.tileRow++ {
+		begin, end := ctx.getTileSpan(l.tileRow, l.height)
+		if end > l.first+l.rows {
+			break
+		}
+		ctx.writeTiles(l, begin, end)
+	}
+	keep := l.height
+	if l.tileRow < tileRows {
+		keep, _ = ctx.getTileSpan(l.tileRow, l.height)
+	}
+	if idx+1 < len(ctx.levels) {
+		next := ctx.levels[idx+1]
+		ready := next.height
+		if !l.complete() {
+			ready = (l.first+l.rows)>>1 - ctx.margin
+		}
+		if ready > next.next {
+			err := ctx.downscale(l, next, next.next, ready)
+			if err != nil {
+				return err
+			}
+			next.next = ready
+			err = ctx.flush(idx + 1)
+			if err != nil {
+				return err
+			}
+		}
+		keep = min(keep, max(0, next.next-ctx.margin)<<1)
+	}
+	// tiles may still be encoding from our rows
+	ctx.group.Wait()
+	l.drop(keep)
+	return nil
+}
+
+// downscale computes rows [begin, end) of dst from src
+// each band is computed with margin rows so that results are identical to
+// a full-image 2:1 vertical resize
+func (ctx *tilerExport) downscale(src, dst *tileLevel, begin, end int) error {
+	ob := max(0, begin-ctx.margin)
+	oe := min(dst.height, end+ctx.margin)
+	sb := ob << 1
+	se := min(src.height, oe<<1)
+	band := src.row(sb)
+	pitch := src.pitch
+	if src.height != dst.height {
+		rows := (oe - ob) << 1
+		if se-sb < rows {
+			// odd heights duplicate their last row
+			size := rows * pitch
+			if cap(ctx.buffer) < size {
+				ctx.buffer = make([]byte, size)
+			}
+			buf := ctx.buffer[:size]
+			copy(buf, band[:(se-sb)*pitch])
+			copy(buf[(se-sb)*pitch:], band[(se-sb-1)*pitch:(se-sb)*pitch])
+			band = buf
+		}
+		size := (oe - ob) * pitch
+		if cap(ctx.scaled) < size {
+			ctx.scaled = make([]byte, size)
+		}
+		tmp := ctx.scaled[:size]
+		r, err := ctx.getResizer(true, rows, oe-ob, src.width, oe-ob)
+		if err != nil {
+			return err
+		}
+		r.Resize(tmp, band, src.width, rows, pitch, pitch)
+		band = tmp
+	}
+	band = band[(begin-ob)*pitch:]
+	rows := end - begin
+	out := dst.grow(rows)
+	if src.width == dst.width {
+		copyPlane(out, band, src.width*ctx.pack, rows, dst.pitch, pitch)
+		return nil
+	}
+	r, err := ctx.getResizer(false, src.width, dst.width, dst.width, rows)
+	if err != nil {
+		return err
+	}
+	r.Resize(out, band, src.width, rows, dst.pitch, pitch)
+	return nil
+}
+
+func (ctx *tilerExport) getTileName(level, col, row int) string {
+	ext := ctx.extension()
+	if ctx.Layout == LayoutXYZ {
+		return filepath.Join(ctx.dir, fmt.Sprint(level), fmt.Sprint(col),
+			fmt.Sprintf("%v.%v", row, ext))
+	}
+	return filepath.Join(ctx.dir, fmt.Sprint(level), fmt.Sprintf("%v_%v.%v", col, row, ext))
+}
+
+func (ctx *tilerExport) writeTiles(l *tileLevel, top, bottom int) {
+	cols := (l.width + ctx.TileSize - 1) / ctx.TileSize
+	for col := 0; col < cols; col++ {
+		left, right := ctx.getTileSpan(col, l.width)
+		name := ctx.getTileName(l.level, col, l.tileRow)
+		img := ctx.getTile(l, left, top, right, bottom)
+		ctx.jobs <- struct{}{}
+		ctx.group.Add(1)
+		go func() {
+			ctx.fail(ctx.writeTile(name, img))
+			<-ctx.jobs
+			ctx.group.Done()
+		}()
+	}
+}
+
+func (ctx *tilerExport) getTile(l *tileLevel, left, top, right, bottom int) image.Image {
+	w, h := right-left, bottom-top
+	pix := l.row(top)[left*ctx.pack:]
+	pitch := l.pitch
+	if ctx.Layout == LayoutXYZ && (w != ctx.TileSize || h != ctx.TileSize) {
+		// xyz tiles are always full-sized
+		pitch = ctx.TileSize * ctx.pack
+		buf := make([]byte, pitch*ctx.TileSize)
+		copyPlane(buf, pix, w*ctx.pack, h, pitch, l.pitch)
+		pix = buf
+		w, h = ctx.TileSize, ctx.TileSize
+	}
+	rect := image.Rect(0, 0, w, h)
+	if ctx.gray {
+		return &image.Gray{Pix: pix, Stride: pitch, Rect: rect}
+	}
+	return &image.RGBA{Pix: pix, Stride: pitch, Rect: rect}
+}
+
+func (ctx *tilerExport) extension() string {
+	if ctx.Format == TileJPEG {
+		return "jpg"
+	}
+	return "png"
+}
+
+func (ctx *tilerExport) writeTile(name string, img image.Image) error {
+	err := os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+	fh, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if ctx.Format == TileJPEG {
+		err = jpeg.Encode(fh, img, &jpeg.Options{Quality: ctx.Quality})
+	} else {
+		err = png.Encode(fh, img)
+	}
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
+
+func (ctx *tilerExport) writeDescriptor(name string, width, height int) error {
+	err := os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+	fh, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(fh, `<?xml version="1.0" encoding="UTF-8"?>
+<Image xmlns="http://schemas.microsoft.com/deepzoom/2008" Format="%v" Overlap="%v" TileSize="%v">
+  <Size Width="%v" Height="%v"/>
+</Image>
+`, ctx.extension(), ctx.Overlap, ctx.TileSize, width, height)
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
@@ -41,3 +41,118 @@ func DumpImage(prefix string, img image.Image) error {
 	}
 	return nil
 }
+
+// derange moves v towards [lo, hi] by strength/256
+func derange(v, a, b byte, strength int) byte {
+	if a > b {
+		a, b = b, a
+	}
+	if v < a {
+		return v + byte((int(a-v)*strength+0x80)>>8)
+	}
+	if v > b {
+		return v - byte((int(v-b)*strength+0x80)>>8)
+	}
+	return v
+}
+
+func h8deringGo(dst, src []byte, near []int32, strength, width, height, dp, sp, pack int) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		d := dst[di : di+width*pack]
+		s := src[si:]
+		for x, n := range near[:width] {
+			a := s[int(n)*pack:]
+			b := a[pack:]
+			for c := 0; c < pack; c++ {
+				d[x*pack+c] = derange(d[x*pack+c], a[c], b[c], strength)
+			}
+		}
+		di += dp
+		si += sp
+	}
+}
+
+func v8deringGo(dst, src []byte, near []int32, strength, width, height, dp, sp int) {
+	di := 0
+	for _, n := range near[:height] {
+		a := src[sp*int(n):]
+		b := a[sp:]
+		d := dst[di : di+width]
+		for x, v := range d {
+			d[x] = derange(v, a[x], b[x], strength)
+		}
+		di += dp
+	}
+}
+
+// derange16 is derange on 16-bit samples
+func derange16(v, a, b uint16, strength int) uint16 {
+	if a > b {
+		a, b = b, a
+	}
+	if v < a {
+		return v + uint16((int(a-v)*strength+0x80)>>8)
+	}
+	if v > b {
+		return v - uint16((int(v-b)*strength+0x80)>>8)
+	}
+	return v
+}
+
+// get16 returns sample idx of buf as 16-bit 8.8 fixed point
+func get16(buf []byte, idx int, wide bool) uint16 {
+	if wide {
+		return uint16(buf[idx*2]) | uint16(buf[idx*2+1])<<8
+	}
+	return uint16(buf[idx]) << 8
+}
+
+// h16deringGo is h8deringGo on 16-bit 8.8 fixed point sources and/or
+// destinations
+func h16deringGo(dst, src []byte, near []int32, strength, width, height, dp, sp, pack int, in, out bool) {
+	di := 0
+	si := 0
+	for y := 0; y < height; y++ {
+		d := dst[di:]
+		s := src[si:]
+		for x, n := range near[:width] {
+			for c := 0; c < pack; c++ {
+				a := get16(s, int(n)*pack+c, in)
+				b := get16(s, int(n+1)*pack+c, in)
+				j := x*pack + c
+				if out {
+					v := derange16(get16(d, j, true), a, b, strength)
+					d[j*2] = byte(v)
+					d[j*2+1] = byte(v >> 8)
+					continue
+				}
+				// round bounds to the nearest 8-bit value
+				a8 := u8((int(a) + 0x80) >> 8)
+				b8 := u8((int(b) + 0x80) >> 8)
+				d[j] = derange(d[j], a8, b8, strength)
+			}
+		}
+		di += dp
+		si += sp
+	}
+}
+
+// v16deringGo is v8deringGo on 16-bit 8.8 fixed point destinations, and
+// sources if in is set
+func v16deringGo(dst, src []byte, near []int32, strength, width, height, dp, sp int, in bool) {
+	di := 0
+	for _, n := range near[:height] {
+		a := src[sp*int(n):]
+		b := a[sp:]
+		d := dst[di : di+width*2]
+		for x := 0; x < width; x++ {
+			v := uint16(d[x*2]) | uint16(d[x*2+1])<<8
+			v = derange16(v, get16(a, x, in), get16(b, x, in), strength)
+			d[x*2] = byte(v)
+			d[x*2+1] = byte(v >> 8)
+		}
+		di += dp
+	}
+}
@@ -10,6 +10,9 @@ GLOBL	zero_0<>(SB), 8, $16
 DATA	hbits_1<>+0x00(SB)/8, $0x0000200000002000
 DATA	hbits_1<>+0x08(SB)/8, $0x0000200000002000
 GLOBL	hbits_1<>(SB), 8, $16
+DATA	u8max_2<>+0x00(SB)/8, $0x00000000000000FF
+DATA	u8max_2<>+0x08(SB)/8, $0x00000000000000FF
+GLOBL	u8max_2<>(SB), 8, $16
 
 TEXT ·v8scale2Amd64(SB),4,$0-136
 		MOVQ	dp+120(FP), BX
@@ -34,7 +37,7 @@ norollback_0:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
 yloop_1:
 		MOVQ	R9, SI
@@ -43,11 +46,14 @@ yloop_1:
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_2
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_2
-maxloop_3:
-		MOVOU	(BP), X12
+		JE	nomaxloop_4
+maxloop_5:
+		MOVOU	(R8), X12
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVO	X0, X2
@@ -78,14 +84,14 @@ maxloop_3:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_3
-nomaxloop_2:
+		JNE	maxloop_5
+nomaxloop_4:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_4
-		MOVOU	(BP), X12
+		JE	nobackroll_6
+		MOVOU	(R8), X12
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVO	X0, X2
@@ -115,9 +121,36 @@ nomaxloop_2:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_4:
+nobackroll_6:
+		JMP	end_3
+narrow_2:
+xloop_7:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_7
+end_3:
 		ADDQ	R11, DI
-		ADDQ	$32, BP
+		ADDQ	$32, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
 		JNE	yloop_1
@@ -134,10 +167,10 @@ TEXT ·v8scale4Amd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_5
+		JE	norollback_8
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_5:
+norollback_8:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
@@ -146,24 +179,27 @@ norollback_5:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_6:
+yloop_9:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_10
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_7
-maxloop_8:
+		JE	nomaxloop_12
+maxloop_13:
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -212,18 +248,18 @@ maxloop_8:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_8
-nomaxloop_7:
+		JNE	maxloop_13
+nomaxloop_12:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_9
+		JE	nobackroll_14
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -271,12 +307,49 @@ nomaxloop_7:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_9:
+nobackroll_14:
+		JMP	end_11
+narrow_10:
+xloop_15:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_15
+end_11:
 		ADDQ	R11, DI
-		ADDQ	$64, BP
+		ADDQ	$64, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_6
+		JNE	yloop_9
 		RET
 
 TEXT ·v8scale6Amd64(SB),4,$0-136
@@ -290,10 +363,10 @@ TEXT ·v8scale6Amd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_10
+		JE	norollback_16
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_10:
+norollback_16:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
@@ -302,25 +375,28 @@ norollback_10:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_11:
+yloop_17:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_18
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_12
-maxloop_13:
+		JE	nomaxloop_20
+maxloop_21:
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -365,10 +441,10 @@ maxloop_13:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -389,19 +465,19 @@ maxloop_13:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_13
-nomaxloop_12:
+		JNE	maxloop_21
+nomaxloop_20:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_14
+		JE	nobackroll_22
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -446,10 +522,10 @@ nomaxloop_12:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -469,12 +545,59 @@ nomaxloop_12:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_14:
+nobackroll_22:
+		JMP	end_19
+narrow_18:
+xloop_23:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_23
+end_19:
 		ADDQ	R11, DI
-		ADDQ	$96, BP
+		ADDQ	$96, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_11
+		JNE	yloop_17
 		RET
 
 TEXT ·v8scale8Amd64(SB),4,$0-136
@@ -488,10 +611,10 @@ TEXT ·v8scale8Amd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_15
+		JE	norollback_24
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_15:
+norollback_24:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
@@ -500,25 +623,28 @@ norollback_15:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_16:
+yloop_25:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_26
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_17
-maxloop_18:
+		JE	nomaxloop_28
+maxloop_29:
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -563,10 +689,10 @@ maxloop_18:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -583,10 +709,10 @@ maxloop_18:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -607,19 +733,19 @@ maxloop_18:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_18
-nomaxloop_17:
+		JNE	maxloop_29
+nomaxloop_28:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_19
+		JE	nobackroll_30
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -664,10 +790,10 @@ nomaxloop_17:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -684,10 +810,10 @@ nomaxloop_17:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -707,12 +833,69 @@ nomaxloop_17:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_19:
+nobackroll_30:
+		JMP	end_27
+narrow_26:
+xloop_31:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_31
+end_27:
 		ADDQ	R11, DI
-		ADDQ	$128, BP
+		ADDQ	$128, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_16
+		JNE	yloop_25
 		RET
 
 TEXT ·v8scale10Amd64(SB),4,$0-136
@@ -726,10 +909,10 @@ TEXT ·v8scale10Amd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_20
+		JE	norollback_32
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_20:
+norollback_32:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
@@ -738,25 +921,28 @@ norollback_20:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_21:
+yloop_33:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_34
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_22
-maxloop_23:
+		JE	nomaxloop_36
+maxloop_37:
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -801,10 +987,10 @@ maxloop_23:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -821,10 +1007,10 @@ maxloop_23:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -841,10 +1027,10 @@ maxloop_23:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	128(BP), X4
-		PMADDWL	128(BP), X5
-		PMADDWL	128(BP), X6
-		PMADDWL	128(BP), X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -865,19 +1051,19 @@ maxloop_23:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_23
-nomaxloop_22:
+		JNE	maxloop_37
+nomaxloop_36:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_24
+		JE	nobackroll_38
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -922,10 +1108,10 @@ nomaxloop_22:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -942,10 +1128,10 @@ nomaxloop_22:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -962,10 +1148,10 @@ nomaxloop_22:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	128(BP), X4
-		PMADDWL	128(BP), X5
-		PMADDWL	128(BP), X6
-		PMADDWL	128(BP), X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -985,12 +1171,79 @@ nomaxloop_22:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_24:
+nobackroll_38:
+		JMP	end_35
+narrow_34:
+xloop_39:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_39
+end_35:
 		ADDQ	R11, DI
-		ADDQ	$160, BP
+		ADDQ	$160, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_21
+		JNE	yloop_33
 		RET
 
 TEXT ·v8scale12Amd64(SB),4,$0-136
@@ -1004,10 +1257,10 @@ TEXT ·v8scale12Amd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_25
+		JE	norollback_40
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_25:
+norollback_40:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
@@ -1016,25 +1269,28 @@ norollback_25:
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_26:
+yloop_41:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_42
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_27
-maxloop_28:
+		JE	nomaxloop_44
+maxloop_45:
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -1079,10 +1335,10 @@ maxloop_28:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1099,10 +1355,10 @@ maxloop_28:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1119,10 +1375,10 @@ maxloop_28:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	128(BP), X4
-		PMADDWL	128(BP), X5
-		PMADDWL	128(BP), X6
-		PMADDWL	128(BP), X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1139,10 +1395,10 @@ maxloop_28:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	160(BP), X4
-		PMADDWL	160(BP), X5
-		PMADDWL	160(BP), X6
-		PMADDWL	160(BP), X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1163,19 +1419,19 @@ maxloop_28:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_28
-nomaxloop_27:
+		JNE	maxloop_45
+nomaxloop_44:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_29
+		JE	nobackroll_46
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -1220,10 +1476,10 @@ nomaxloop_27:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	64(BP), X4
-		PMADDWL	64(BP), X5
-		PMADDWL	64(BP), X6
-		PMADDWL	64(BP), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1240,10 +1496,10 @@ nomaxloop_27:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	96(BP), X4
-		PMADDWL	96(BP), X5
-		PMADDWL	96(BP), X6
-		PMADDWL	96(BP), X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1260,10 +1516,10 @@ nomaxloop_27:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	128(BP), X4
-		PMADDWL	128(BP), X5
-		PMADDWL	128(BP), X6
-		PMADDWL	128(BP), X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1280,10 +1536,10 @@ nomaxloop_27:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	160(BP), X4
-		PMADDWL	160(BP), X5
-		PMADDWL	160(BP), X6
-		PMADDWL	160(BP), X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
@@ -1303,15 +1559,92 @@ nomaxloop_27:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_29:
+nobackroll_46:
+		JMP	end_43
+narrow_42:
+xloop_47:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_47
+end_43:
 		ADDQ	R11, DI
-		ADDQ	$192, BP
+		ADDQ	$192, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_26
+		JNE	yloop_41
 		RET
 
-TEXT ·v8scaleNAmd64(SB),4,$0-136
+TEXT ·v8scale14Amd64(SB),4,$0-136
 		MOVQ	dp+120(FP), BX
 		MOVQ	width+104(FP), CX
 		MOVQ	CX, DX
@@ -1322,41 +1655,40 @@ TEXT ·v8scaleNAmd64(SB),4,$0-136
 		MOVQ	CX, R12
 		MOVQ	DX, AX
 		ORQ	AX, AX
-		JE	norollback_30
+		JE	norollback_48
 		SUBQ	$16, DX
 		NEGQ	DX
-norollback_30:
+norollback_48:
 		MOVQ	DX, R13
 		MOVQ	off+72(FP), CX
 		MOVQ	CX, R10
 		MOVO	zero_0<>(SB), X14
 		MOVO	hbits_1<>(SB), X13
-		MOVQ	taps+96(FP), DX
-		SUBQ	$4, DX
-		SHRQ	$1, DX
-		MOVQ	DX, R14
 		MOVQ	src+24(FP), SI
 		MOVQ	SI, R9
 		MOVQ	dst+0(FP), DI
-		MOVQ	cof+48(FP), BP
+		MOVQ	cof+48(FP), R8
 		MOVQ	sp+128(FP), BX
-yloop_31:
+yloop_49:
 		MOVQ	R9, SI
 		MOVQ	R10, DX
 		MOVWQSX	(DX), AX
 		MULQ	BX
 		ADDQ	AX, SI
 		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_50
 		MOVQ	R12, CX
 		ORQ	CX, CX
-		JE	nomaxloop_32
-maxloop_33:
+		JE	nomaxloop_52
+maxloop_53:
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -1390,11 +1722,6 @@ maxloop_33:
 		PADDL	X5, X1
 		PADDL	X6, X2
 		PADDL	X7, X3
-		MOVQ	R14, R15
-		MOVQ	BP, DX
-		ADDQ	$32, DX
-innerloop_34:
-		ADDQ	$32, DX
 		MOVOU	(AX), X4
 		MOVOU	(AX)(BX*1), X7
 		MOVO	X4, X6
@@ -1406,17 +1733,95 @@ innerloop_34:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	(DX), X4
-		PMADDWL	(DX), X5
-		PMADDWL	(DX), X6
-		PMADDWL	(DX), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	192(R8), X4
+		PMADDWL	192(R8), X5
+		PMADDWL	192(R8), X6
+		PMADDWL	192(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
 		PADDL	X6, X2
 		PADDL	X7, X3
-		SUBQ	$1, R15
-		JNE	innerloop_34
 		PADDL	X13, X0
 		PADDL	X13, X1
 		PADDL	X13, X2
@@ -1432,19 +1837,19 @@ innerloop_34:
 		ADDQ	$16, SI
 		ADDQ	$16, DI
 		SUBQ	$1, CX
-		JNE	maxloop_33
-nomaxloop_32:
+		JNE	maxloop_53
+nomaxloop_52:
 		MOVQ	R13, CX
 		SUBQ	R13, SI
 		SUBQ	R13, DI
 		ORQ	CX, CX
-		JE	nobackroll_35
+		JE	nobackroll_54
 		LEAQ	(SI)(BX*4), AX
 		MOVOU	(SI), X0
 		MOVOU	(SI)(BX*1), X3
 		MOVOU	(SI)(BX*2), X4
-		MOVOU	(BP), X10
-		MOVOU	32(BP), X11
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
 		ADDQ	BX, SI
 		MOVOU	(SI)(BX*2), X7
 		MOVO	X0, X2
@@ -1478,11 +1883,6 @@ nomaxloop_32:
 		PADDL	X5, X1
 		PADDL	X6, X2
 		PADDL	X7, X3
-		MOVQ	R14, R15
-		MOVQ	BP, DX
-		ADDQ	$32, DX
-innerloop_36:
-		ADDQ	$32, DX
 		MOVOU	(AX), X4
 		MOVOU	(AX)(BX*1), X7
 		MOVO	X4, X6
@@ -1494,17 +1894,95 @@ innerloop_36:
 		PUNPCKHBW	X14, X5
 		PUNPCKLBW	X14, X6
 		PUNPCKHBW	X14, X7
-		PMADDWL	(DX), X4
-		PMADDWL	(DX), X5
-		PMADDWL	(DX), X6
-		PMADDWL	(DX), X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	192(R8), X4
+		PMADDWL	192(R8), X5
+		PMADDWL	192(R8), X6
+		PMADDWL	192(R8), X7
 		LEAQ	(AX)(BX*2), AX
 		PADDL	X4, X0
 		PADDL	X5, X1
 		PADDL	X6, X2
 		PADDL	X7, X3
-		SUBQ	$1, R15
-		JNE	innerloop_36
 		PADDL	X13, X0
 		PADDL	X13, X1
 		PADDL	X13, X2
@@ -1519,12 +1997,11852 @@ innerloop_36:
 		MOVOU	X0, (DI)
 		ADDQ	$16, SI
 		ADDQ	$16, DI
-nobackroll_35:
+nobackroll_54:
+		JMP	end_51
+narrow_50:
+xloop_55:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_55
+end_51:
 		ADDQ	R11, DI
-		MOVQ	taps+96(FP), DX
-		SHLQ	$4, DX
-		ADDQ	DX, BP
+		ADDQ	$224, R8
 		ADDQ	$2, R10
 		SUBQ	$1, height+112(FP)
-		JNE	yloop_31
+		JNE	yloop_49
+		RET
+
+TEXT ·v8scale16Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_56
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_56:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_57:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_58
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_60
+maxloop_61:
+		LEAQ	(SI)(BX*4), AX
+		MOVOU	(SI), X0
+		MOVOU	(SI)(BX*1), X3
+		MOVOU	(SI)(BX*2), X4
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
+		ADDQ	BX, SI
+		MOVOU	(SI)(BX*2), X7
+		MOVO	X0, X2
+		MOVO	X4, X6
+		PUNPCKLBW	X3, X0
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X3, X2
+		PUNPCKHBW	X7, X6
+		MOVO	X0, X1
+		MOVO	X4, X5
+		MOVO	X2, X3
+		MOVO	X6, X7
+		SUBQ	BX, SI
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	X10, X0
+		PMADDWL	X10, X1
+		PMADDWL	X11, X4
+		PMADDWL	X11, X5
+		PMADDWL	X10, X2
+		PMADDWL	X10, X3
+		PMADDWL	X11, X6
+		PMADDWL	X11, X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	192(R8), X4
+		PMADDWL	192(R8), X5
+		PMADDWL	192(R8), X6
+		PMADDWL	192(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	224(R8), X4
+		PMADDWL	224(R8), X5
+		PMADDWL	224(R8), X6
+		PMADDWL	224(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_61
+nomaxloop_60:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_62
+		LEAQ	(SI)(BX*4), AX
+		MOVOU	(SI), X0
+		MOVOU	(SI)(BX*1), X3
+		MOVOU	(SI)(BX*2), X4
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
+		ADDQ	BX, SI
+		MOVOU	(SI)(BX*2), X7
+		MOVO	X0, X2
+		MOVO	X4, X6
+		PUNPCKLBW	X3, X0
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X3, X2
+		PUNPCKHBW	X7, X6
+		MOVO	X0, X1
+		MOVO	X4, X5
+		MOVO	X2, X3
+		MOVO	X6, X7
+		SUBQ	BX, SI
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	X10, X0
+		PMADDWL	X10, X1
+		PMADDWL	X11, X4
+		PMADDWL	X11, X5
+		PMADDWL	X10, X2
+		PMADDWL	X10, X3
+		PMADDWL	X11, X6
+		PMADDWL	X11, X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	128(R8), X4
+		PMADDWL	128(R8), X5
+		PMADDWL	128(R8), X6
+		PMADDWL	128(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	160(R8), X4
+		PMADDWL	160(R8), X5
+		PMADDWL	160(R8), X6
+		PMADDWL	160(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	192(R8), X4
+		PMADDWL	192(R8), X5
+		PMADDWL	192(R8), X6
+		PMADDWL	192(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	224(R8), X4
+		PMADDWL	224(R8), X5
+		PMADDWL	224(R8), X6
+		PMADDWL	224(R8), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_62:
+		JMP	end_59
+narrow_58:
+xloop_63:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	224(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	226(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_63
+end_59:
+		ADDQ	R11, DI
+		ADDQ	$256, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_57
+		RET
+
+TEXT ·v8scaleNAmd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_64
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_64:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	taps+96(FP), DX
+		SUBQ	$4, DX
+		SHRQ	$1, DX
+		MOVQ	DX, R14
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_65:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_66
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_68
+maxloop_69:
+		LEAQ	(SI)(BX*4), AX
+		MOVOU	(SI), X0
+		MOVOU	(SI)(BX*1), X3
+		MOVOU	(SI)(BX*2), X4
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
+		ADDQ	BX, SI
+		MOVOU	(SI)(BX*2), X7
+		MOVO	X0, X2
+		MOVO	X4, X6
+		PUNPCKLBW	X3, X0
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X3, X2
+		PUNPCKHBW	X7, X6
+		MOVO	X0, X1
+		MOVO	X4, X5
+		MOVO	X2, X3
+		MOVO	X6, X7
+		SUBQ	BX, SI
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	X10, X0
+		PMADDWL	X10, X1
+		PMADDWL	X11, X4
+		PMADDWL	X11, X5
+		PMADDWL	X10, X2
+		PMADDWL	X10, X3
+		PMADDWL	X11, X6
+		PMADDWL	X11, X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVQ	R14, R15
+		MOVQ	R8, DX
+		ADDQ	$32, DX
+innerloop_70:
+		ADDQ	$32, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	(DX), X4
+		PMADDWL	(DX), X5
+		PMADDWL	(DX), X6
+		PMADDWL	(DX), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		SUBQ	$1, R15
+		JNE	innerloop_70
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_69
+nomaxloop_68:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_71
+		LEAQ	(SI)(BX*4), AX
+		MOVOU	(SI), X0
+		MOVOU	(SI)(BX*1), X3
+		MOVOU	(SI)(BX*2), X4
+		MOVOU	(R8), X10
+		MOVOU	32(R8), X11
+		ADDQ	BX, SI
+		MOVOU	(SI)(BX*2), X7
+		MOVO	X0, X2
+		MOVO	X4, X6
+		PUNPCKLBW	X3, X0
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X3, X2
+		PUNPCKHBW	X7, X6
+		MOVO	X0, X1
+		MOVO	X4, X5
+		MOVO	X2, X3
+		MOVO	X6, X7
+		SUBQ	BX, SI
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	X10, X0
+		PMADDWL	X10, X1
+		PMADDWL	X11, X4
+		PMADDWL	X11, X5
+		PMADDWL	X10, X2
+		PMADDWL	X10, X3
+		PMADDWL	X11, X6
+		PMADDWL	X11, X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		MOVQ	R14, R15
+		MOVQ	R8, DX
+		ADDQ	$32, DX
+innerloop_72:
+		ADDQ	$32, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	(DX), X4
+		PMADDWL	(DX), X5
+		PMADDWL	(DX), X6
+		PMADDWL	(DX), X7
+		LEAQ	(AX)(BX*2), AX
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		SUBQ	$1, R15
+		JNE	innerloop_72
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_71:
+		JMP	end_67
+narrow_66:
+xloop_73:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVQ	R8, R14
+		MOVQ	taps+96(FP), R15
+		SHRQ	$1, R15
+loop_74:
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		ADDQ	$32, R14
+		SUBQ	$1, R15
+		JNE	loop_74
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_73
+		MOVQ	taps+96(FP), R14
+		SUBQ	$4, R14
+		SHRQ	$1, R14
+end_67:
+		ADDQ	R11, DI
+		MOVQ	taps+96(FP), DX
+		SHLQ	$4, DX
+		ADDQ	DX, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_65
+		RET
+
+TEXT ·v8scale2Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_75
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_75:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_76:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_77
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_79
+maxloop_80:
+		VMOVDQU	(R8), Y12
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPMADDWD	Y12, Y0, Y0
+		VPMADDWD	Y12, Y1, Y1
+		VPMADDWD	Y12, Y2, Y2
+		VPMADDWD	Y12, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_80
+nomaxloop_79:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_81
+		VMOVDQU	(R8), Y12
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPMADDWD	Y12, Y0, Y0
+		VPMADDWD	Y12, Y1, Y1
+		VPMADDWD	Y12, Y2, Y2
+		VPMADDWD	Y12, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_81:
+		JMP	end_78
+narrow_77:
+xloop_82:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_82
+end_78:
+		ADDQ	R11, DI
+		ADDQ	$32, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_76
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale4Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_83
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_83:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_84:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_85
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_87
+maxloop_88:
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_88
+nomaxloop_87:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_89
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_89:
+		JMP	end_86
+narrow_85:
+xloop_90:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_90
+end_86:
+		ADDQ	R11, DI
+		ADDQ	$64, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_84
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale6Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_91
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_91:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_92:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_93
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_95
+maxloop_96:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_96
+nomaxloop_95:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_97
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_97:
+		JMP	end_94
+narrow_93:
+xloop_98:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_98
+end_94:
+		ADDQ	R11, DI
+		ADDQ	$96, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_92
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale8Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_99
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_99:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_100:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_101
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_103
+maxloop_104:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_104
+nomaxloop_103:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_105
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_105:
+		JMP	end_102
+narrow_101:
+xloop_106:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_106
+end_102:
+		ADDQ	R11, DI
+		ADDQ	$128, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_100
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale10Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_107
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_107:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_108:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_109
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_111
+maxloop_112:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_112
+nomaxloop_111:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_113
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_113:
+		JMP	end_110
+narrow_109:
+xloop_114:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_114
+end_110:
+		ADDQ	R11, DI
+		ADDQ	$160, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_108
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale12Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_115
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_115:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_116:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_117
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_119
+maxloop_120:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_120
+nomaxloop_119:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_121
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_121:
+		JMP	end_118
+narrow_117:
+xloop_122:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_122
+end_118:
+		ADDQ	R11, DI
+		ADDQ	$192, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_116
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale14Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_123
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_123:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_124:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_125
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_127
+maxloop_128:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	192(R8), Y4, Y4
+		VPMADDWD	192(R8), Y5, Y5
+		VPMADDWD	192(R8), Y6, Y6
+		VPMADDWD	192(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_128
+nomaxloop_127:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_129
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	192(R8), Y4, Y4
+		VPMADDWD	192(R8), Y5, Y5
+		VPMADDWD	192(R8), Y6, Y6
+		VPMADDWD	192(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_129:
+		JMP	end_126
+narrow_125:
+xloop_130:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_130
+end_126:
+		ADDQ	R11, DI
+		ADDQ	$224, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_124
+		VZEROUPPER
+		RET
+
+TEXT ·v8scale16Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_131
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_131:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_132:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_133
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_135
+maxloop_136:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	192(R8), Y4, Y4
+		VPMADDWD	192(R8), Y5, Y5
+		VPMADDWD	192(R8), Y6, Y6
+		VPMADDWD	192(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	224(R8), Y4, Y4
+		VPMADDWD	224(R8), Y5, Y5
+		VPMADDWD	224(R8), Y6, Y6
+		VPMADDWD	224(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_136
+nomaxloop_135:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_137
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	128(R8), Y4, Y4
+		VPMADDWD	128(R8), Y5, Y5
+		VPMADDWD	128(R8), Y6, Y6
+		VPMADDWD	128(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	160(R8), Y4, Y4
+		VPMADDWD	160(R8), Y5, Y5
+		VPMADDWD	160(R8), Y6, Y6
+		VPMADDWD	160(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	192(R8), Y4, Y4
+		VPMADDWD	192(R8), Y5, Y5
+		VPMADDWD	192(R8), Y6, Y6
+		VPMADDWD	192(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	224(R8), Y4, Y4
+		VPMADDWD	224(R8), Y5, Y5
+		VPMADDWD	224(R8), Y6, Y6
+		VPMADDWD	224(R8), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_137:
+		JMP	end_134
+narrow_133:
+xloop_138:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	224(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	226(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_138
+end_134:
+		ADDQ	R11, DI
+		ADDQ	$256, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_132
+		VZEROUPPER
+		RET
+
+TEXT ·v8scaleNAvx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_139
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_139:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	taps+96(FP), DX
+		SUBQ	$4, DX
+		SHRQ	$1, DX
+		MOVQ	DX, R14
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+yloop_140:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_141
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_143
+maxloop_144:
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	R14, R15
+		MOVQ	R8, DX
+		ADDQ	$32, DX
+innerloop_145:
+		ADDQ	$32, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	(DX), Y4, Y4
+		VPMADDWD	(DX), Y5, Y5
+		VPMADDWD	(DX), Y6, Y6
+		VPMADDWD	(DX), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		SUBQ	$1, R15
+		JNE	innerloop_145
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_144
+nomaxloop_143:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_146
+		LEAQ	(SI)(BX*4), AX
+		VMOVDQU	(SI), Y0
+		VMOVDQU	(SI)(BX*1), Y3
+		VMOVDQU	(SI)(BX*2), Y4
+		VMOVDQU	(R8), Y10
+		VMOVDQU	32(R8), Y11
+		ADDQ	BX, SI
+		VMOVDQU	(SI)(BX*2), Y7
+		VMOVDQA	Y0, Y2
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y3, Y2, Y2
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y2, Y3
+		VMOVDQA	Y6, Y7
+		SUBQ	BX, SI
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	Y10, Y0, Y0
+		VPMADDWD	Y10, Y1, Y1
+		VPMADDWD	Y11, Y4, Y4
+		VPMADDWD	Y11, Y5, Y5
+		VPMADDWD	Y10, Y2, Y2
+		VPMADDWD	Y10, Y3, Y3
+		VPMADDWD	Y11, Y6, Y6
+		VPMADDWD	Y11, Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		MOVQ	R14, R15
+		MOVQ	R8, DX
+		ADDQ	$32, DX
+innerloop_147:
+		ADDQ	$32, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	(DX), Y4, Y4
+		VPMADDWD	(DX), Y5, Y5
+		VPMADDWD	(DX), Y6, Y6
+		VPMADDWD	(DX), Y7, Y7
+		LEAQ	(AX)(BX*2), AX
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		SUBQ	$1, R15
+		JNE	innerloop_147
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_146:
+		JMP	end_142
+narrow_141:
+xloop_148:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVQ	R8, R14
+		MOVQ	taps+96(FP), R15
+		SHRQ	$1, R15
+loop_149:
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		ADDQ	$32, R14
+		SUBQ	$1, R15
+		JNE	loop_149
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_148
+		MOVQ	taps+96(FP), R14
+		SUBQ	$4, R14
+		SHRQ	$1, R14
+end_142:
+		ADDQ	R11, DI
+		MOVQ	taps+96(FP), DX
+		SHLQ	$4, DX
+		ADDQ	DX, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_140
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold4Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_150
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_150:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$2, BX, R14
+yloop_151:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_152
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_154
+maxloop_155:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_155
+nomaxloop_154:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_156
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_156:
+		JMP	end_153
+narrow_152:
+xloop_157:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_157
+end_153:
+		ADDQ	R11, DI
+		ADDQ	$64, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_151
+		RET
+
+TEXT ·v8fold6Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_158
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_158:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$4, BX, R14
+yloop_159:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_160
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_162
+maxloop_163:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_163
+nomaxloop_162:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_164
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_164:
+		JMP	end_161
+narrow_160:
+xloop_165:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_165
+end_161:
+		ADDQ	R11, DI
+		ADDQ	$96, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_159
+		RET
+
+TEXT ·v8fold8Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_166
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_166:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$6, BX, R14
+yloop_167:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_168
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_170
+maxloop_171:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_171
+nomaxloop_170:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_172
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_172:
+		JMP	end_169
+narrow_168:
+xloop_173:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_173
+end_169:
+		ADDQ	R11, DI
+		ADDQ	$128, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_167
+		RET
+
+TEXT ·v8fold10Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_174
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_174:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$8, BX, R14
+yloop_175:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_176
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_178
+maxloop_179:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_179
+nomaxloop_178:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_180
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_180:
+		JMP	end_177
+narrow_176:
+xloop_181:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_181
+end_177:
+		ADDQ	R11, DI
+		ADDQ	$160, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_175
+		RET
+
+TEXT ·v8fold12Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_182
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_182:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$10, BX, R14
+yloop_183:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_184
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_186
+maxloop_187:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_187
+nomaxloop_186:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_188
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_188:
+		JMP	end_185
+narrow_184:
+xloop_189:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_189
+end_185:
+		ADDQ	R11, DI
+		ADDQ	$192, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_183
+		RET
+
+TEXT ·v8fold14Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_190
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_190:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$12, BX, R14
+yloop_191:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_192
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_194
+maxloop_195:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_195
+nomaxloop_194:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_196
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_196:
+		JMP	end_193
+narrow_192:
+xloop_197:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_197
+end_193:
+		ADDQ	R11, DI
+		ADDQ	$224, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_191
+		RET
+
+TEXT ·v8fold16Amd64(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$15, DX
+		SHRQ	$4, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_198
+		SUBQ	$16, DX
+		NEGQ	DX
+norollback_198:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		MOVO	zero_0<>(SB), X14
+		MOVO	hbits_1<>(SB), X13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$14, BX, R14
+yloop_199:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $16
+		JLT	narrow_200
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_202
+maxloop_203:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	maxloop_203
+nomaxloop_202:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_204
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X3
+		MOVO	X0, X2
+		PUNPCKLBW	X3, X0
+		PUNPCKHBW	X3, X2
+		MOVO	X0, X1
+		MOVO	X2, X3
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X1
+		PUNPCKLBW	X14, X2
+		PUNPCKHBW	X14, X3
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X0
+		PADDW	X9, X1
+		PADDW	X10, X2
+		PADDW	X11, X3
+		PMADDWL	(R8), X0
+		PMADDWL	(R8), X1
+		PMADDWL	(R8), X2
+		PMADDWL	(R8), X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	32(R8), X4
+		PMADDWL	32(R8), X5
+		PMADDWL	32(R8), X6
+		PMADDWL	32(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	64(R8), X4
+		PMADDWL	64(R8), X5
+		PMADDWL	64(R8), X6
+		PMADDWL	64(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		MOVOU	(AX), X4
+		MOVOU	(AX)(BX*1), X7
+		MOVO	X4, X6
+		PUNPCKLBW	X7, X4
+		PUNPCKHBW	X7, X6
+		MOVO	X4, X5
+		MOVO	X6, X7
+		PUNPCKLBW	X14, X4
+		PUNPCKHBW	X14, X5
+		PUNPCKLBW	X14, X6
+		PUNPCKHBW	X14, X7
+		MOVOU	(DX)(BX*1), X8
+		MOVOU	(DX), X11
+		MOVO	X8, X10
+		PUNPCKLBW	X11, X8
+		PUNPCKHBW	X11, X10
+		MOVO	X8, X9
+		MOVO	X10, X11
+		PUNPCKLBW	X14, X8
+		PUNPCKHBW	X14, X9
+		PUNPCKLBW	X14, X10
+		PUNPCKHBW	X14, X11
+		PADDW	X8, X4
+		PADDW	X9, X5
+		PADDW	X10, X6
+		PADDW	X11, X7
+		PMADDWL	96(R8), X4
+		PMADDWL	96(R8), X5
+		PMADDWL	96(R8), X6
+		PMADDWL	96(R8), X7
+		PADDL	X4, X0
+		PADDL	X5, X1
+		PADDL	X6, X2
+		PADDL	X7, X3
+		PADDL	X13, X0
+		PADDL	X13, X1
+		PADDL	X13, X2
+		PADDL	X13, X3
+		PSRAL	$14, X0
+		PSRAL	$14, X1
+		PSRAL	$14, X2
+		PSRAL	$14, X3
+		PACKSSLW	X1, X0
+		PACKSSLW	X3, X2
+		PACKUSWB	X2, X0
+		MOVOU	X0, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+nobackroll_204:
+		JMP	end_201
+narrow_200:
+xloop_205:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	224(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	226(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_205
+end_201:
+		ADDQ	R11, DI
+		ADDQ	$256, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_199
+		RET
+
+TEXT ·v8fold4Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_206
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_206:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$2, BX, R14
+yloop_207:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_208
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_210
+maxloop_211:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_211
+nomaxloop_210:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_212
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_212:
+		JMP	end_209
+narrow_208:
+xloop_213:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_213
+end_209:
+		ADDQ	R11, DI
+		ADDQ	$64, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_207
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold6Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_214
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_214:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$4, BX, R14
+yloop_215:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_216
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_218
+maxloop_219:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_219
+nomaxloop_218:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_220
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_220:
+		JMP	end_217
+narrow_216:
+xloop_221:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_221
+end_217:
+		ADDQ	R11, DI
+		ADDQ	$96, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_215
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold8Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_222
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_222:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$6, BX, R14
+yloop_223:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_224
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_226
+maxloop_227:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_227
+nomaxloop_226:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_228
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_228:
+		JMP	end_225
+narrow_224:
+xloop_229:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_229
+end_225:
+		ADDQ	R11, DI
+		ADDQ	$128, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_223
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold10Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_230
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_230:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$8, BX, R14
+yloop_231:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_232
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_234
+maxloop_235:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_235
+nomaxloop_234:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_236
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_236:
+		JMP	end_233
+narrow_232:
+xloop_237:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_237
+end_233:
+		ADDQ	R11, DI
+		ADDQ	$160, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_231
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold12Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_238
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_238:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$10, BX, R14
+yloop_239:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_240
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_242
+maxloop_243:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_243
+nomaxloop_242:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_244
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_244:
+		JMP	end_241
+narrow_240:
+xloop_245:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_245
+end_241:
+		ADDQ	R11, DI
+		ADDQ	$192, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_239
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold14Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_246
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_246:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$12, BX, R14
+yloop_247:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_248
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_250
+maxloop_251:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_251
+nomaxloop_250:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_252
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_252:
+		JMP	end_249
+narrow_248:
+xloop_253:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_253
+end_249:
+		ADDQ	R11, DI
+		ADDQ	$224, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_247
+		VZEROUPPER
+		RET
+
+TEXT ·v8fold16Avx2(SB),4,$0-136
+		MOVQ	dp+120(FP), BX
+		MOVQ	width+104(FP), CX
+		MOVQ	CX, DX
+		SUBQ	CX, BX
+		ANDQ	$31, DX
+		SHRQ	$5, CX
+		MOVQ	BX, R11
+		MOVQ	CX, R12
+		MOVQ	DX, AX
+		ORQ	AX, AX
+		JE	norollback_254
+		SUBQ	$32, DX
+		NEGQ	DX
+norollback_254:
+		MOVQ	DX, R13
+		MOVQ	off+72(FP), CX
+		MOVQ	CX, R10
+		VBROADCASTI128	zero_0<>(SB), Y14
+		VBROADCASTI128	hbits_1<>(SB), Y13
+		MOVQ	src+24(FP), SI
+		MOVQ	SI, R9
+		MOVQ	dst+0(FP), DI
+		MOVQ	cof+48(FP), R8
+		MOVQ	sp+128(FP), BX
+		IMUL3Q	$14, BX, R14
+yloop_255:
+		MOVQ	R9, SI
+		MOVQ	R10, DX
+		MOVWQSX	(DX), AX
+		MULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R9
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $32
+		JLT	narrow_256
+		MOVQ	R12, CX
+		ORQ	CX, CX
+		JE	nomaxloop_258
+maxloop_259:
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+		SUBQ	$1, CX
+		JNE	maxloop_259
+nomaxloop_258:
+		MOVQ	R13, CX
+		SUBQ	R13, SI
+		SUBQ	R13, DI
+		ORQ	CX, CX
+		JE	nobackroll_260
+		MOVQ	SI, AX
+		LEAQ	(SI)(R14*1), DX
+		VMOVDQU	(AX), Y0
+		VMOVDQU	(AX)(BX*1), Y3
+		VMOVDQA	Y0, Y2
+		VPUNPCKLBW	Y3, Y0, Y0
+		VPUNPCKHBW	Y3, Y2, Y2
+		VMOVDQA	Y0, Y1
+		VMOVDQA	Y2, Y3
+		VPUNPCKLBW	Y14, Y0, Y0
+		VPUNPCKHBW	Y14, Y1, Y1
+		VPUNPCKLBW	Y14, Y2, Y2
+		VPUNPCKHBW	Y14, Y3, Y3
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y0, Y0
+		VPADDW	Y9, Y1, Y1
+		VPADDW	Y10, Y2, Y2
+		VPADDW	Y11, Y3, Y3
+		VPMADDWD	(R8), Y0, Y0
+		VPMADDWD	(R8), Y1, Y1
+		VPMADDWD	(R8), Y2, Y2
+		VPMADDWD	(R8), Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	32(R8), Y4, Y4
+		VPMADDWD	32(R8), Y5, Y5
+		VPMADDWD	32(R8), Y6, Y6
+		VPMADDWD	32(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	64(R8), Y4, Y4
+		VPMADDWD	64(R8), Y5, Y5
+		VPMADDWD	64(R8), Y6, Y6
+		VPMADDWD	64(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		LEAQ	(AX)(BX*2), AX
+		SUBQ	BX, DX
+		SUBQ	BX, DX
+		VMOVDQU	(AX), Y4
+		VMOVDQU	(AX)(BX*1), Y7
+		VMOVDQA	Y4, Y6
+		VPUNPCKLBW	Y7, Y4, Y4
+		VPUNPCKHBW	Y7, Y6, Y6
+		VMOVDQA	Y4, Y5
+		VMOVDQA	Y6, Y7
+		VPUNPCKLBW	Y14, Y4, Y4
+		VPUNPCKHBW	Y14, Y5, Y5
+		VPUNPCKLBW	Y14, Y6, Y6
+		VPUNPCKHBW	Y14, Y7, Y7
+		VMOVDQU	(DX)(BX*1), Y8
+		VMOVDQU	(DX), Y11
+		VMOVDQA	Y8, Y10
+		VPUNPCKLBW	Y11, Y8, Y8
+		VPUNPCKHBW	Y11, Y10, Y10
+		VMOVDQA	Y8, Y9
+		VMOVDQA	Y10, Y11
+		VPUNPCKLBW	Y14, Y8, Y8
+		VPUNPCKHBW	Y14, Y9, Y9
+		VPUNPCKLBW	Y14, Y10, Y10
+		VPUNPCKHBW	Y14, Y11, Y11
+		VPADDW	Y8, Y4, Y4
+		VPADDW	Y9, Y5, Y5
+		VPADDW	Y10, Y6, Y6
+		VPADDW	Y11, Y7, Y7
+		VPMADDWD	96(R8), Y4, Y4
+		VPMADDWD	96(R8), Y5, Y5
+		VPMADDWD	96(R8), Y6, Y6
+		VPMADDWD	96(R8), Y7, Y7
+		VPADDD	Y4, Y0, Y0
+		VPADDD	Y5, Y1, Y1
+		VPADDD	Y6, Y2, Y2
+		VPADDD	Y7, Y3, Y3
+		VPADDD	Y13, Y0, Y0
+		VPADDD	Y13, Y1, Y1
+		VPADDD	Y13, Y2, Y2
+		VPADDD	Y13, Y3, Y3
+		VPSRAD	$14, Y0, Y0
+		VPSRAD	$14, Y1, Y1
+		VPSRAD	$14, Y2, Y2
+		VPSRAD	$14, Y3, Y3
+		VPACKSSDW	Y1, Y0, Y0
+		VPACKSSDW	Y3, Y2, Y2
+		VPACKUSWB	Y2, Y0, Y0
+		VMOVDQU	Y0, (DI)
+		ADDQ	$32, SI
+		ADDQ	$32, DI
+nobackroll_260:
+		JMP	end_257
+narrow_256:
+xloop_261:
+		MOVQ	SI, R12
+		MOVQ	$0, R13
+		MOVBQZX	(R12), AX
+		MOVWQSX	(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	2(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	32(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	34(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	64(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	66(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	96(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	98(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	128(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	130(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	160(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	162(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	192(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	194(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	224(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVBQZX	(R12), AX
+		MOVWQSX	226(R8), DX
+		IMULQ	DX
+		ADDQ	AX, R13
+		ADDQ	BX, R12
+		MOVQ	R13, AX
+		ADDQ	$8192, AX
+		CMOVQLT	zero_0<>(SB), AX
+		SHRQ	$14, AX
+		CMPQ	u8max_2<>(SB), AX
+		CMOVQLT	u8max_2<>(SB), AX
+		MOVB	AL, (DI)
+		ADDQ	$1, SI
+		ADDQ	$1, DI
+		SUBQ	$1, CX
+		JNE	xloop_261
+end_257:
+		ADDQ	R11, DI
+		ADDQ	$256, R8
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_255
+		VZEROUPPER
+		RET
+DATA	sign_3<>+0x00(SB)/8, $0x8000800080008000
+DATA	sign_3<>+0x08(SB)/8, $0x8000800080008000
+GLOBL	sign_3<>(SB), 8, $16
+DATA	zero_4<>+0x00(SB)/8, $0x0000000000000000
+GLOBL	zero_4<>(SB), 8, $8
+DATA	u8max_5<>+0x00(SB)/8, $0x00000000000000FF
+GLOBL	u8max_5<>(SB), 8, $8
+DATA	u16max_6<>+0x00(SB)/8, $0x000000000000FFFF
+GLOBL	u16max_6<>(SB), 8, $8
+
+TEXT ·v16scale2Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_262:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_263
+		SHRQ	$3, CX
+simdloop_265:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		PADDL	16(R14), X4
+		PSRAL	$14, X4
+		PADDL	16(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_265
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_264
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		PADDL	16(R14), X4
+		PSRAL	$14, X4
+		PADDL	16(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_264
+narrow_263:
+xloop_266:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_266
+end_264:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$32, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_262
+		RET
+
+TEXT ·v16scale4Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_267:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_268
+		SHRQ	$3, CX
+simdloop_270:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	32(R14), X4
+		PSRAL	$14, X4
+		PADDL	32(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_270
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_269
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	32(R14), X4
+		PSRAL	$14, X4
+		PADDL	32(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_269
+narrow_268:
+xloop_271:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_271
+end_269:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$48, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_267
+		RET
+
+TEXT ·v16scale6Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_272:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_273
+		SHRQ	$3, CX
+simdloop_275:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	48(R14), X4
+		PSRAL	$14, X4
+		PADDL	48(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_275
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_274
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	48(R14), X4
+		PSRAL	$14, X4
+		PADDL	48(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_274
+narrow_273:
+xloop_276:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_276
+end_274:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$64, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_272
+		RET
+
+TEXT ·v16scale8Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_277:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_278
+		SHRQ	$3, CX
+simdloop_280:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	64(R14), X4
+		PSRAL	$14, X4
+		PADDL	64(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_280
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_279
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	64(R14), X4
+		PSRAL	$14, X4
+		PADDL	64(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_279
+narrow_278:
+xloop_281:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_281
+end_279:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$80, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_277
+		RET
+
+TEXT ·v16scale10Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_282:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_283
+		SHRQ	$3, CX
+simdloop_285:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	80(R14), X4
+		PSRAL	$14, X4
+		PADDL	80(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_285
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_284
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	80(R14), X4
+		PSRAL	$14, X4
+		PADDL	80(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_284
+narrow_283:
+xloop_286:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_286
+end_284:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$96, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_282
+		RET
+
+TEXT ·v16scale12Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_287:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_288
+		SHRQ	$3, CX
+simdloop_290:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	96(R14), X4
+		PSRAL	$14, X4
+		PADDL	96(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_290
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_289
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	96(R14), X4
+		PSRAL	$14, X4
+		PADDL	96(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_289
+narrow_288:
+xloop_291:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_291
+end_289:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$112, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_287
+		RET
+
+TEXT ·v16scale14Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_292:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_293
+		SHRQ	$3, CX
+simdloop_295:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	112(R14), X4
+		PSRAL	$14, X4
+		PADDL	112(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_295
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_294
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	112(R14), X4
+		PSRAL	$14, X4
+		PADDL	112(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_294
+narrow_293:
+xloop_296:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	96(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	98(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_296
+end_294:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$128, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_292
+		RET
+
+TEXT ·v16scale16Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_297:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_298
+		SHRQ	$3, CX
+simdloop_300:
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	112(R14), X0
+		PMADDWL	112(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	128(R14), X4
+		PSRAL	$14, X4
+		PADDL	128(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_300
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_299
+		SUBQ	$8, CX
+		SHLQ	$1, CX
+		ADDQ	CX, SI
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVOU	(AX), X0
+		MOVOU	(AX)(BX*1), X1
+		PXOR	X15, X0
+		PXOR	X15, X1
+		MOVO	X0, X2
+		PUNPCKLWL	X1, X0
+		PUNPCKHWL	X1, X2
+		PMADDWL	112(R14), X0
+		PMADDWL	112(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	128(R14), X4
+		PSRAL	$14, X4
+		PADDL	128(R14), X5
+		PSRAL	$14, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$16, SI
+		ADDQ	$16, DI
+		JMP	end_299
+narrow_298:
+xloop_301:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVWQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	96(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	98(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	112(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVWQZX	(R8), AX
+		MOVWQSX	114(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$8192, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$14, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$2, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_301
+end_299:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$144, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_297
+		RET
+
+TEXT ·v8to16scale2Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_302:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_303
+		SHRQ	$3, CX
+simdloop_305:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		PADDL	16(R14), X4
+		PSRAL	$6, X4
+		PADDL	16(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_305
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_304
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		PADDL	16(R14), X4
+		PSRAL	$6, X4
+		PADDL	16(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_304
+narrow_303:
+xloop_306:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_306
+end_304:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$32, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_302
+		RET
+
+TEXT ·v8to16scale4Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_307:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_308
+		SHRQ	$3, CX
+simdloop_310:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	32(R14), X4
+		PSRAL	$6, X4
+		PADDL	32(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_310
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_309
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	32(R14), X4
+		PSRAL	$6, X4
+		PADDL	32(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_309
+narrow_308:
+xloop_311:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_311
+end_309:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$48, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_307
+		RET
+
+TEXT ·v8to16scale6Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_312:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_313
+		SHRQ	$3, CX
+simdloop_315:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	48(R14), X4
+		PSRAL	$6, X4
+		PADDL	48(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_315
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_314
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	48(R14), X4
+		PSRAL	$6, X4
+		PADDL	48(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_314
+narrow_313:
+xloop_316:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_316
+end_314:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$64, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_312
+		RET
+
+TEXT ·v8to16scale8Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_317:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_318
+		SHRQ	$3, CX
+simdloop_320:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	64(R14), X4
+		PSRAL	$6, X4
+		PADDL	64(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_320
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_319
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	64(R14), X4
+		PSRAL	$6, X4
+		PADDL	64(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_319
+narrow_318:
+xloop_321:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_321
+end_319:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$80, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_317
+		RET
+
+TEXT ·v8to16scale10Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_322:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_323
+		SHRQ	$3, CX
+simdloop_325:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	80(R14), X4
+		PSRAL	$6, X4
+		PADDL	80(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_325
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_324
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	80(R14), X4
+		PSRAL	$6, X4
+		PADDL	80(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_324
+narrow_323:
+xloop_326:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_326
+end_324:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$96, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_322
+		RET
+
+TEXT ·v8to16scale12Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_327:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_328
+		SHRQ	$3, CX
+simdloop_330:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	96(R14), X4
+		PSRAL	$6, X4
+		PADDL	96(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_330
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_329
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	96(R14), X4
+		PSRAL	$6, X4
+		PADDL	96(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_329
+narrow_328:
+xloop_331:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_331
+end_329:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$112, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_327
+		RET
+
+TEXT ·v8to16scale14Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_332:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_333
+		SHRQ	$3, CX
+simdloop_335:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	112(R14), X4
+		PSRAL	$6, X4
+		PADDL	112(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_335
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_334
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	112(R14), X4
+		PSRAL	$6, X4
+		PADDL	112(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_334
+narrow_333:
+xloop_336:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	96(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	98(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_336
+end_334:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$128, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_332
+		RET
+
+TEXT ·v8to16scale16Amd64(SB),4,$0-136
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		MOVO	sign_3<>(SB), X15
+		PXOR	X14, X14
+		MOVQ	cof+48(FP), R14
+		MOVQ	sp+128(FP), BX
+		MOVQ	off+72(FP), R10
+yloop_337:
+		MOVWQSX	(R10), AX
+		IMULQ	BX
+		ADDQ	AX, SI
+		MOVQ	SI, R11
+		MOVQ	DI, R13
+		MOVQ	width+104(FP), CX
+		CMPQ	CX, $8
+		JLT	narrow_338
+		SHRQ	$3, CX
+simdloop_340:
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	112(R14), X0
+		PMADDWL	112(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	128(R14), X4
+		PSRAL	$6, X4
+		PADDL	128(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		SUBQ	$1, CX
+		JNE	simdloop_340
+		MOVQ	width+104(FP), CX
+		ANDQ	$7, CX
+		JE	end_339
+		SUBQ	$8, CX
+		ADDQ	CX, SI
+		SHLQ	$1, CX
+		ADDQ	CX, DI
+		MOVQ	SI, AX
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	(R14), X0
+		PMADDWL	(R14), X2
+		MOVO	X0, X4
+		MOVO	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	16(R14), X0
+		PMADDWL	16(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	32(R14), X0
+		PMADDWL	32(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	48(R14), X0
+		PMADDWL	48(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	64(R14), X0
+		PMADDWL	64(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	80(R14), X0
+		PMADDWL	80(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		LEAQ	(AX)(BX*2), AX
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	96(R14), X0
+		PMADDWL	96(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		MOVQ	(AX), X0
+		MOVQ	(AX)(BX*1), X1
+		PUNPCKLBW	X1, X0
+		MOVO	X0, X2
+		PUNPCKLBW	X14, X0
+		PUNPCKHBW	X14, X2
+		PMADDWL	112(R14), X0
+		PMADDWL	112(R14), X2
+		PADDL	X0, X4
+		PADDL	X2, X5
+		PADDL	128(R14), X4
+		PSRAL	$6, X4
+		PADDL	128(R14), X5
+		PSRAL	$6, X5
+		PACKSSLW	X5, X4
+		PXOR	X15, X4
+		MOVOU	X4, (DI)
+		ADDQ	$8, SI
+		ADDQ	$16, DI
+		JMP	end_339
+narrow_338:
+xloop_341:
+		MOVQ	SI, R8
+		MOVQ	$0, R12
+		MOVBQZX	(R8), AX
+		MOVWQSX	(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	2(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	16(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	18(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	32(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	34(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	48(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	50(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	64(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	66(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	80(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	82(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	96(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	98(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	112(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	BX, R8
+		MOVBQZX	(R8), AX
+		MOVWQSX	114(R14), DX
+		IMULQ	DX
+		ADDQ	AX, R12
+		ADDQ	$32, R12
+		CMOVQLT	zero_4<>(SB), R12
+		SHRQ	$6, R12
+		CMPQ	u16max_6<>(SB), R12
+		CMOVQLT	u16max_6<>(SB), R12
+		MOVW	R12, (DI)
+		ADDQ	$1, SI
+		ADDQ	$2, DI
+		SUBQ	$1, CX
+		JNE	xloop_341
+end_339:
+		MOVQ	R11, SI
+		MOVQ	R13, DI
+		ADDQ	dp+120(FP), DI
+		ADDQ	$144, R14
+		ADDQ	$2, R10
+		SUBQ	$1, height+112(FP)
+		JNE	yloop_337
+		RET
+DATA	round_7<>+0x00(SB)/8, $0x0080008000800080
+DATA	round_7<>+0x08(SB)/8, $0x0080008000800080
+GLOBL	round_7<>(SB), 8, $16
+DATA	low_8<>+0x00(SB)/8, $0x00FF00FF00FF00FF
+DATA	low_8<>+0x08(SB)/8, $0x00FF00FF00FF00FF
+GLOBL	low_8<>(SB), 8, $16
+
+TEXT ·v8deringAmd64(SB),4,$0-112
+		MOVQ	src+24(FP), SI
+		MOVQ	dst+0(FP), DI
+		PXOR	X13, X13
+		MOVO	round_7<>(SB), X14
+		MOVQ	strength+72(FP), AX
+		MOVQ	AX, X15
+		PUNPCKLWL	X15, X15
+		PUNPCKLLQ	X15, X15
+		PUNPCKLQDQ	X15, X15
+		MOVQ	sp+104(FP), BX
+		MOVQ	near+48(FP), R10
+yloop_342:
+		MOVL	(R10), AX
+		IMULQ	BX
+		LEAQ	(SI)(AX*1), R8
+		LEAQ	(R8)(BX*1), R9
+		MOVQ	DI, R11
+		MOVQ	width+80(FP), CX
+		SHRQ	$4, CX
+xloop_343:
+		MOVOU	(R8), X0
+		MOVOU	(R9), X1
+		MOVOU	(R11), X2
+		MOVO	X0, X3
+		PMINUB	X1, X3
+		PMAXUB	X1, X0
+		PSUBUSB	X2, X3
+		MOVO	X2, X4
+		PSUBUSB	X0, X4
+		MOVO	X3, X5
+		PUNPCKLBW	X13, X3
+		PUNPCKHBW	X13, X5
+		PMULLW	X15, X3
+		PMULLW	X15, X5
+		PADDW	X14, X3
+		PADDW	X14, X5
+		PSRLW	$8, X3
+		PSRLW	$8, X5
+		PACKUSWB	X5, X3
+		MOVO	X4, X6
+		PUNPCKLBW	X13, X4
+		PUNPCKHBW	X13, X6
+		PMULLW	X15, X4
+		PMULLW	X15, X6
+		PADDW	X14, X4
+		PADDW	X14, X6
+		PSRLW	$8, X4
+		PSRLW	$8, X6
+		PACKUSWB	X6, X4
+		PADDB	X3, X2
+		PSUBB	X4, X2
+		MOVOU	X2, (R11)
+		ADDQ	$16, R8
+		ADDQ	$16, R9
+		ADDQ	$16, R11
+		SUBQ	$1, CX
+		JNE	xloop_343
+		ADDQ	dp+96(FP), DI
+		ADDQ	$4, R10
+		SUBQ	$1, height+88(FP)
+		JNE	yloop_342
 		RET